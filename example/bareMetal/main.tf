@@ -20,6 +20,7 @@ resource "ksyun_bare_metal" "default" {
   //  dns1 = "198.18.224.10"
   //  dns2 = "198.18.224.11"
   force_re_install = false
+  charge_type = "Daily"
 }
 
 
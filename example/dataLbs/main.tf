@@ -10,6 +10,5 @@ data "ksyun_lbs" "default" {
   output_file="output_result"
   name_regex=""
   ids=["d3fd0421-a35a-4ddb-a939-xxxxxxx"]
-  state=""
   vpc_id=[]
 }
\ No newline at end of file
@@ -4,7 +4,7 @@ data "ksyun_private_dns_zones" "foo" {
 }
 
 data "ksyun_private_dns_records" "foo" {
-  zone_id = "" // Required
+  zone_id = "your zone id" // Required
   output_file = "pdns_records_output_result"
   region_name = ["cn-beijing-6"]
   record_ids = []
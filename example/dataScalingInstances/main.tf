@@ -6,5 +6,4 @@ provider "ksyun" {
 data "ksyun_scaling_instances" "default" {
   output_file="output_result"
   scaling_group_id = "541241314798505984"
-  protected_from_detach = 0
 }
@@ -10,7 +10,6 @@ data "ksyun_slbs" "default" {
   output_file="output_result"
   name_regex=""
   ids=["d3fd0421-a35a-4ddb-a939-xxxxxxx"]
-  state=""
   vpc_id=[]
 }
 
@@ -7,11 +7,11 @@ data "ksyun_subnets" "default" {
   output_file="output_result"
 
   ids=[]
-  vpc_id=[]
-  nat_id=[]
-  network_acl_id=[]
-  subnet_type=[]
-  availability_zone_name=[]
+  vpc_ids=[]
+  nat_ids=[]
+  network_acl_ids=[]
+  subnet_types=[]
+  availability_zone_names=[]
 
 }
 
@@ -10,7 +10,6 @@ data "ksyun_images" "centos-7_5" {
 }
 data "ksyun_availability_zones" "default" {
   output_file=""
-  ids=[]
 }
 data "ksyun_lines" "default" {
   output_file=""
@@ -50,8 +49,6 @@ resource "ksyun_security_group_entry" "test1" {
   protocol="ip"
   icmp_type=0
   icmp_code=0
-  port_range_from=0
-  port_range_to=0
 }
 resource "ksyun_security_group_entry" "test2" {
   description = "test2"
@@ -61,8 +58,6 @@ resource "ksyun_security_group_entry" "test2" {
   protocol="ip"
   icmp_type=0
   icmp_code=0
-  port_range_from=0
-  port_range_to=0
 }
 resource "ksyun_security_group_entry" "test3" {
   description = "test3"
@@ -72,8 +67,6 @@ resource "ksyun_security_group_entry" "test3" {
   protocol="ip"
   icmp_type=0
   icmp_code=0
-  port_range_from=0
-  port_range_to=0
 }
 resource "ksyun_ssh_key" "default" {
   key_name="ssh_key_tf"
@@ -86,7 +79,6 @@ resource "ksyun_instance" "default" {
     disk_type="SSD3.0"
     disk_size=30
   }
-  data_disk_gb=0
   subnet_id="${ksyun_subnet.default.id}"
   instance_password="Xuan663222"
   keep_image_login=false
@@ -95,7 +87,6 @@ resource "ksyun_instance" "default" {
   security_group_id=["${ksyun_security_group.default.id}","${ksyun_security_group.default2.id}"]
   private_ip_address=""
   instance_name="xuan-tf-combine"
-  instance_name_suffix=""
   sriov_net_support="false"
   project_id=0
   data_guard_id=""
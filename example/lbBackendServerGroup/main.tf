@@ -5,5 +5,5 @@ provider "ksyun" {
 resource "ksyun_lb_backend_server_group" "default" {
   backend_server_group_name="xuan-tf"
   vpc_id=""
-  backend_server_group_type=""
+  backend_server_group_type="Server"
 }
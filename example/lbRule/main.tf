@@ -2,8 +2,8 @@
 provider "ksyun" {
 }
 resource "ksyun_lb_rule" "default" {
-  path = "/tfxun/update",
-  host_header_id = "",
+  path = "/tfxun/update"
+  host_header_id = ""
   backend_server_group_id=""
   listener_sync="on"
   method="RoundRobin"
@@ -21,11 +21,11 @@ provider "ksyun" {
 //}
 
 resource "ksyun_lb_listener" "default" {
-  listener_name = "tf-xun",
-  listener_port = "8000",
-  listener_protocol = "HTTP",
-  listener_state = "stop",
-  load_balancer_id = "2aa32091-95b8-482c-b492-597c635201d1",
+  listener_name = "tf-xun"
+  listener_port = "8000"
+  listener_protocol = "HTTP"
+  listener_state = "stop"
+  load_balancer_id = "2aa32091-95b8-482c-b492-597c635201d1"
   method = "RoundRobin"
 //  certificate_id = "${ksyun_certificate.default.id}"
 //  tls_cipher_policy = "TlsCipherPolicy1.1"
@@ -7,7 +7,6 @@ provider "ksyun" {
 
 data "ksyun_availability_zones" "default" {
   output_file = ""
-  ids = []
 }
 resource "ksyun_vpc" "default" {
   vpc_name = "lzs-ksyun-vpc-tf"
@@ -39,7 +38,6 @@ resource "ksyun_rabbitmq_instance" "default" {
   node_num = 3
   bill_type = 87
   project_id = 103800
-  project_name = "测试部"
 }
 
 
@@ -19,7 +19,6 @@ resource "ksyun_subnet" "foo" {
 }
 
 resource "ksyun_krds_security_group" "krds_sec_group_237" {
-  output_file = "output_file"
   security_group_name = "terraform_security_group_237"
   security_group_description = "terraform-security-group-237"
   security_group_rule{
@@ -13,17 +13,36 @@ provider "ksyun" {
 
 resource "ksyun_krds_parameter_group" "dpg2" {
     name = "tf_dpg_on_hcl"
-    description = "tf configuration test"
+    description = "tf_configuration_test"
     engine = "mysql"
     engine_version = "5.6"
-    parameters = {
-        connect_timeout = 20
-        innodb_stats_on_metadata = "OFF"  
-		table_open_cache_instances = 1  
-		group_concat_max_len = 102
-		max_connect_errors = 2000
-        max_prepared_stmt_count = 65535
-        max_user_connections = 65535
+    parameters {
+        name  = "connect_timeout"
+        value = "20"
+    }
+    parameters {
+        name  = "innodb_stats_on_metadata"
+        value = "OFF"
+    }
+    parameters {
+        name  = "table_open_cache_instances"
+        value = "1"
+    }
+    parameters {
+        name  = "group_concat_max_len"
+        value = "102"
+    }
+    parameters {
+        name  = "max_connect_errors"
+        value = "2000"
+    }
+    parameters {
+        name  = "max_prepared_stmt_count"
+        value = "65535"
+    }
+    parameters {
+        name  = "max_user_connections"
+        value = "65535"
     }
 }
 
@@ -32,8 +51,9 @@ resource "ksyun_krds_parameter_group" "dpg1" {
     # description = "tf configuration test"
     engine = "mysql"
     engine_version = "5.6"
-    parameters = {
-        connect_timeout = "30"
+    parameters {
+        name  = "connect_timeout"
+        value = "30"
     }
 }
 
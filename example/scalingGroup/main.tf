@@ -41,11 +41,11 @@ resource "ksyun_lb" "foo" {
 }
 
 resource "ksyun_lb_listener" "foo" {
-  listener_name = "tf-acc-listener",
-  listener_port = "8080",
-  listener_protocol = "TCP",
-  listener_state = "start",
-  load_balancer_id = "${ksyun_lb.foo.id}",
+  listener_name = "tf-acc-listener"
+  listener_port = "8080"
+  listener_protocol = "TCP"
+  listener_state = "start"
+  load_balancer_id = "${ksyun_lb.foo.id}"
   method = "RoundRobin"
   health_check {
     health_check_state = "start"
@@ -5,6 +5,6 @@ resource "ksyun_tag" "test_tag" {
     key = "test_tag_key"
     value = "test_tag_value"
     resource_type = "eip"
-    resource_id = 'xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx'
+    resource_id = "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
 }
 
@@ -30,8 +30,6 @@ resource "ksyun_security_group_entry" "test1" {
   protocol="ip"
   icmp_type=0
   icmp_code=0
-  port_range_from=0
-  port_range_to=0
 }
 resource "ksyun_security_group_entry" "test2" {
   description = "26231a41-4c6b-4a10-94ed-27088d5679df"
@@ -41,6 +39,4 @@ resource "ksyun_security_group_entry" "test2" {
   protocol="ip"
   icmp_type=0
   icmp_code=0
-  port_range_from=0
-  port_range_to=0
 }
@@ -1,7 +1,10 @@
 package ksyun
 
 import (
+	"encoding/binary"
 	"fmt"
+	"net"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -59,3 +62,153 @@ func getIpSegRange(userSegIp, offset uint8) (int, int) {
 	segMaxIp := userSegIp&(255<<offset) | ^(255 << offset)
 	return int(segMinIp), int(segMaxIp)
 }
+
+// ipv4Range is an inclusive range of IPv4 addresses, uint64 is used so the end of 0.0.0.0/0 does not overflow
+type ipv4Range struct {
+	first, last uint64
+}
+
+func parseIpv4Range(cidr string) (r ipv4Range, err error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return r, err
+	}
+	ip := ipNet.IP.To4()
+	if ip == nil {
+		return r, fmt.Errorf("%q is not an IPv4 CIDR block", cidr)
+	}
+	ones, bits := ipNet.Mask.Size()
+	r.first = uint64(binary.BigEndian.Uint32(ip))
+	r.last = r.first + 1<<uint(bits-ones) - 1
+	return r, nil
+}
+
+func (r ipv4Range) cidrBlock() string {
+	prefixLength := 32
+	for size := r.last - r.first + 1; size > 1; size >>= 1 {
+		prefixLength--
+	}
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, uint32(r.first))
+	return fmt.Sprintf("%s/%d", ip, prefixLength)
+}
+
+// splitIpv4Range splits a range into the largest aligned CIDR blocks, sorted by address
+func splitIpv4Range(r ipv4Range) (blocks []ipv4Range) {
+	for first := r.first; first <= r.last; {
+		size := uint64(1) << 32
+		for first%size != 0 || first+size-1 > r.last {
+			size >>= 1
+		}
+		blocks = append(blocks, ipv4Range{first: first, last: first + size - 1})
+		first += size
+	}
+	return blocks
+}
+
+// freeCidrBlocks returns the space of the vpc CIDR blocks which is not used by the subnet CIDR blocks,
+// as the largest aligned CIDR blocks sorted by address
+func freeCidrBlocks(vpcCidrs []string, usedCidrs []string) ([]string, error) {
+	var used []ipv4Range
+	for _, cidr := range usedCidrs {
+		r, err := parseIpv4Range(cidr)
+		if err != nil {
+			return nil, err
+		}
+		used = append(used, r)
+	}
+	sort.Slice(used, func(i, j int) bool {
+		return used[i].first < used[j].first
+	})
+
+	var vpcRanges []ipv4Range
+	for _, cidr := range vpcCidrs {
+		r, err := parseIpv4Range(cidr)
+		if err != nil {
+			return nil, err
+		}
+		vpcRanges = append(vpcRanges, r)
+	}
+	sort.Slice(vpcRanges, func(i, j int) bool {
+		return vpcRanges[i].first < vpcRanges[j].first
+	})
+
+	var free []string
+	for _, vpcRange := range vpcRanges {
+		next := vpcRange.first
+		for _, u := range used {
+			if u.last < next || u.first > vpcRange.last {
+				continue
+			}
+			if u.first > next {
+				for _, block := range splitIpv4Range(ipv4Range{first: next, last: u.first - 1}) {
+					free = append(free, block.cidrBlock())
+				}
+			}
+			if u.last+1 > next {
+				next = u.last + 1
+			}
+		}
+		if next <= vpcRange.last {
+			for _, block := range splitIpv4Range(ipv4Range{first: next, last: vpcRange.last}) {
+				free = append(free, block.cidrBlock())
+			}
+		}
+	}
+	return free, nil
+}
+
+// nextFreeCidrBlock returns the lowest CIDR block of the prefix length in the free space of the vpc, the
+// same vpc and subnets always give the same block. An empty string is returned if there is no room left.
+func nextFreeCidrBlock(vpcCidrs []string, usedCidrs []string, prefixLength int) (string, error) {
+	free, err := freeCidrBlocks(vpcCidrs, usedCidrs)
+	if err != nil {
+		return "", err
+	}
+	for _, cidr := range free {
+		r, _ := parseIpv4Range(cidr)
+		size := uint64(1) << uint(32-prefixLength)
+		if r.last-r.first+1 >= size {
+			return ipv4Range{first: r.first, last: r.first + size - 1}.cidrBlock(), nil
+		}
+	}
+	return "", nil
+}
+
+// cidrPrefixLength returns the prefix length of a CIDR block, 0 if it is not a CIDR block
+func cidrPrefixLength(cidr string) int {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return 0
+	}
+	ones, _ := ipNet.Mask.Size()
+	return ones
+}
+
+// cidrContains reports whether the CIDR block inner lies within outer, both of them IPv4 or IPv6
+func cidrContains(outer string, inner string) bool {
+	_, outerNet, err := net.ParseCIDR(outer)
+	if err != nil {
+		return false
+	}
+	_, innerNet, err := net.ParseCIDR(inner)
+	if err != nil {
+		return false
+	}
+	outerOnes, outerBits := outerNet.Mask.Size()
+	innerOnes, innerBits := innerNet.Mask.Size()
+	return outerBits == innerBits && outerOnes <= innerOnes && outerNet.Contains(innerNet.IP)
+}
+
+// cidrOverlaps reports whether two IPv4 CIDR blocks share any address
+func cidrOverlaps(a string, b string) bool {
+	ra, err := parseIpv4Range(a)
+	if err != nil {
+		return false
+	}
+	rb, err := parseIpv4Range(b)
+	if err != nil {
+		return false
+	}
+	return ra.first <= rb.last && rb.first <= ra.last
+}
@@ -1,6 +1,9 @@
 package ksyun
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/KscSDK/ksc-sdk-go/service/bws"
 	"github.com/KscSDK/ksc-sdk-go/service/cen"
 	"github.com/KscSDK/ksc-sdk-go/service/clickhouse"
@@ -32,6 +35,7 @@ import (
 	klog "github.com/kingsoftcloud/sdk-go/v2/ksyun/client/klog/v20200731"
 	kmr "github.com/kingsoftcloud/sdk-go/v2/ksyun/client/kmr/v20210902" // 别名导入kmr SDK
 	"github.com/ks3sdklib/ksyun-ks3-go-sdk/ks3"
+	"github.com/terraform-providers/terraform-provider-ksyun/ksyun/internal/pkg/network"
 )
 
 type KsyunClient struct {
@@ -69,7 +73,84 @@ type KsyunClient struct {
 	kmrconn        *kmr.Client            `json:"kmrconn,omitempty"`
 	klogconn       *klog.Client           `json:"klogconn,omitempty"`
 
-	config *Config
+	config      *Config
+	ks3Endpoint string
+	// ks3EndpointErr is returned by the KS3 calls of a region without a known KS3 endpoint
+	ks3EndpointErr error
+	// regions caches the clients of other regions, it is shared by all of them
+	regions *regionClients
+	// describeCache memoizes the read-only calls when the provider enables describe_cache
+	describeCache *network.DescribeCache
+	// quotaTracker counts the resources created by the plan for the quota_check of the provider
+	quotaTracker *quotaTracker
+}
+
+type regionClients struct {
+	sync.Mutex
+	clients map[string]*KsyunClient
+}
+
+// ks3RegionEndpoints maps the regions to their KS3 endpoints, it is used when a resource
+// overrides the provider region since the provider endpoint only serves the provider region
+var ks3RegionEndpoints = map[string]string{
+	"cn-beijing-6":   "ks3-cn-beijing.ksyuncs.com",
+	"cn-shanghai-2":  "ks3-cn-shanghai.ksyuncs.com",
+	"cn-guangzhou-1": "ks3-cn-guangzhou.ksyuncs.com",
+	"cn-hongkong-2":  "ks3-cn-hk-1.ksyuncs.com",
+	"ap-singapore-1": "ks3-sgp.ksyuncs.com",
+	"eu-east-1":      "ks3-rus.ksyuncs.com",
+}
+
+// WithRegion returns a client of the given region built with the provider credentials and settings.
+// An empty region or the client region returns the client itself, the other ones are built once and cached.
+// The endpoint of the provider is replaced with the KS3 endpoint of the region, a custom endpoint only serves
+// the provider region so the other regions are rejected.
+func (client *KsyunClient) WithRegion(region string) (*KsyunClient, error) {
+	if region == "" || region == client.region {
+		return client, nil
+	}
+
+	client.regions.Lock()
+	defer client.regions.Unlock()
+	if c, ok := client.regions.clients[region]; ok {
+		return c, nil
+	}
+
+	if client.config.Endpoint != "" {
+		if _, ok := ks3EndpointRegion(client.config.Endpoint); !ok {
+			return nil, fmt.Errorf("the endpoint %s of the provider only serves region %s, region %s can not be used with a custom endpoint",
+				client.config.Endpoint, client.region, region)
+		}
+	}
+	// the provider endpoint only serves the provider region, a bucket of a region without a known
+	// KS3 endpoint would be created in the provider region
+	endpoint, ok := ks3RegionEndpoints[region]
+	config := *client.config
+	config.Region = region
+	if config.Endpoint != "" {
+		config.Endpoint = endpoint
+	}
+	c, err := config.Client()
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize the client of region %s: %s", region, err)
+	}
+	if !ok {
+		c.ks3EndpointErr = fmt.Errorf("the KS3 endpoint of region %s is unknown, KS3 resources and data sources can not use it as region", region)
+	}
+	c.ks3Endpoint = endpoint
+	c.regions = client.regions
+	client.regions.clients[region] = c
+	return c, nil
+}
+
+// ks3EndpointRegion returns the region served by a KS3 endpoint of ks3RegionEndpoints
+func ks3EndpointRegion(endpoint string) (string, bool) {
+	for region, v := range ks3RegionEndpoints {
+		if v == endpoint {
+			return region, true
+		}
+	}
+	return "", false
 }
 
 func (client *KsyunClient) GetVpcClient() *vpc.Vpc {
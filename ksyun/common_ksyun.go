@@ -415,6 +415,10 @@ func ConvertFilterStructPrefix(v interface{}, req *map[string]interface{}, prex
 	}
 */
 func dataSourceKscSave(d *schema.ResourceData, dataKey string, ids []string, datas []map[string]interface{}) error {
+	ids, datas, err := filterDataSourceItems(d, ids, datas)
+	if err != nil {
+		return err
+	}
 
 	d.SetId(hashStringArray(ids))
 	if err := d.Set("total_count", len(datas)); err != nil {
@@ -424,7 +428,7 @@ func dataSourceKscSave(d *schema.ResourceData, dataKey string, ids []string, dat
 		return fmt.Errorf("error set datas %v :%v", datas, err)
 	}
 	if outputFile, ok := d.GetOk("output_file"); ok && outputFile.(string) != "" {
-		return writeToFile(outputFile.(string), datas)
+		return writeToFile(outputFile.(string), datas, dataSourceOutputOptions(d))
 	}
 
 	return nil
@@ -440,13 +444,17 @@ func dataSourceKscSaveSlice(d *schema.ResourceData, dataKey string, ids []string
 		return fmt.Errorf("error set datas %v :%v", datas, err)
 	}
 	if outputFile, ok := d.GetOk("output_file"); ok && outputFile.(string) != "" {
-		return writeToFile(outputFile.(string), datas)
+		return writeToFile(outputFile.(string), datas, dataSourceOutputOptions(d))
 	}
 
 	return nil
 }
 
 func dataSourceDbSave(d *schema.ResourceData, dataKey string, ids []string, datas []map[string]interface{}) error {
+	ids, datas, err := filterDataSourceItems(d, ids, datas)
+	if err != nil {
+		return err
+	}
 	if len(ids) == 1 {
 		d.SetId(ids[0])
 	} else {
@@ -464,13 +472,17 @@ func dataSourceDbSave(d *schema.ResourceData, dataKey string, ids []string, data
 	}
 	if outputFile, ok := d.GetOk("output_file"); ok && outputFile.(string) != "" {
 		logger.DebugInfo(" output file name : %+v", outputFile.(string)+"_"+d.Id())
-		return writeToFile(outputFile.(string)+"_"+d.Id(), datas)
+		return writeToFile(outputFile.(string)+"_"+d.Id(), datas, dataSourceOutputOptions(d))
 	} else {
 		return fmt.Errorf(" output file error,  %+v", outputFile)
 	}
 }
 
 func dataDbSave(d *schema.ResourceData, dataKey string, ids []string, datas []map[string]interface{}) error {
+	ids, datas, err := filterDataSourceItems(d, ids, datas)
+	if err != nil {
+		return err
+	}
 	if len(ids) == 1 {
 		d.SetId(ids[0])
 	} else {
@@ -488,7 +500,7 @@ func dataDbSave(d *schema.ResourceData, dataKey string, ids []string, datas []ma
 	}
 	if outputFile, ok := d.GetOk("output_file"); ok && outputFile.(string) != "" {
 		logger.DebugInfo(" ------------ %+v", outputFile)
-		return writeToFile(outputFile.(string)+"_"+"data", datas)
+		return writeToFile(outputFile.(string)+"_"+"data", datas, dataSourceOutputOptions(d))
 	} else {
 		return fmt.Errorf(" !!! %+v", outputFile)
 	}
@@ -61,6 +61,10 @@ type Config struct {
 	MaxRetries    int
 	HttpProxy     string
 	UseSSL        bool
+	DescribeCache bool
+	QuotaCheck    bool
+	// QuotaLimits maps the quota services to the limits overriding the quotas of the account
+	QuotaLimits map[string]int
 }
 
 // Client will returns a client with connections for all product
@@ -69,9 +73,14 @@ func (c *Config) Client() (*KsyunClient, error) {
 	var err error
 	// init ksc client info
 	client.region = c.Region
+	client.quotaTracker = newQuotaTracker()
 	cli := ksc.NewClient(c.AccessKey, c.SecretKey)
 
 	registerClient(cli, c)
+	if c.DescribeCache {
+		client.describeCache = network.NewDescribeCache(network.DefaultDescribeCacheTTL)
+		client.describeCache.Register(&cli.Handlers)
+	}
 	// 重试去掉
 	var MaxRetries = c.MaxRetries
 	cli.Config.MaxRetries = &MaxRetries
@@ -79,6 +88,12 @@ func (c *Config) Client() (*KsyunClient, error) {
 		Region: &c.Region,
 	}
 	client.config = c
+	client.ks3Endpoint = c.Endpoint
+	client.regions = &regionClients{
+		clients: map[string]*KsyunClient{
+			c.Region: &client,
+		},
+	}
 	url := &utils.UrlInfo{
 		UseSSL:                      c.UseSSL,
 		Locate:                      false,
@@ -127,6 +142,7 @@ var goSdkMutex = sync.RWMutex{} // The Go SDK is not thread-safe
 var loadSdkfromRemoteMutex = sync.Mutex{}
 var loadSdkEndpointMutex = sync.Mutex{}
 var tagsMutex = sync.Mutex{}
+var natIpMutex = sync.Mutex{}
 
 func (client *KsyunClient) WithKs3BucketByName(bucketName string, do func(*ks3.Bucket) (interface{}, error)) (interface{}, error) {
 	return client.WithKs3Client(func(ks3Client *ks3.Client) (interface{}, error) {
@@ -142,7 +158,10 @@ func (client *KsyunClient) WithKs3Client(do func(*ks3.Client) (interface{}, erro
 	defer goSdkMutex.Unlock()
 	// Initialize the KS3 client if necessary
 	if client.ks3conn == nil {
-		ks3conn, err := ks3.New(client.config.Endpoint, client.config.AccessKey, client.config.SecretKey)
+		if client.ks3EndpointErr != nil {
+			return nil, client.ks3EndpointErr
+		}
+		ks3conn, err := ks3.New(client.ks3Endpoint, client.config.AccessKey, client.config.SecretKey)
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the KS3 client: %#v", err)
 		}
@@ -0,0 +1,89 @@
+/*
+This data source provides the identity of the credentials the provider runs with.
+
+The identity is read with the IAM action `GetUser`, which a sub-account or a role needs to be allowed, and the account
+id is taken from the KRN it returns. For the credentials of the main account the account id is read from the projects
+of the account with `GetAccountAllProjectList`, which the main account is always allowed.
+
+# Example Usage
+
+```hcl
+
+	data "ksyun_caller_identity" "current" {}
+
+	output "account_id" {
+	  value = data.ksyun_caller_identity.current.account_id
+	}
+
+	resource "ksyun_iam_policy" "bucket" {
+	  policy_name     = "bucket-read"
+	  policy_document = jsonencode({
+	    Version = "2015-11-01"
+	    Statement = [{
+	      Effect   = "Allow"
+	      Action   = ["ks3:GetObject"]
+	      Resource = ["krn:ksc:ks3:::example-${data.ksyun_caller_identity.current.account_id}/*"]
+	    }]
+	  })
+	}
+
+```
+*/
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunCallerIdentity() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunCallerIdentityRead,
+		Schema: map[string]*schema.Schema{
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File name where to save data source results (after running `terraform plan`).",
+			},
+			"account_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the account owning the credentials.",
+			},
+			"identity_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the caller, `root` for the main account, `user` for a sub-account and `role` for the credentials of a role.",
+			},
+			"krn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The KRN of the caller.",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the sub-account, empty for the main account.",
+			},
+			"user_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the sub-account, empty for the main account.",
+			},
+			"role_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the role, empty unless the caller is a role.",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The region the provider runs in.",
+			},
+		},
+	}
+}
+
+func dataSourceKsyunCallerIdentityRead(d *schema.ResourceData, meta interface{}) error {
+	iamCallerIdentityService := IamCallerIdentityService{meta.(*KsyunClient)}
+	return iamCallerIdentityService.ReadAndSetCallerIdentity(d, dataSourceKsyunCallerIdentity())
+}
@@ -0,0 +1,77 @@
+package ksyun
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunCallerIdentityDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataCallerIdentityConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ksyun_caller_identity.current"),
+					resource.TestCheckResourceAttrSet("data.ksyun_caller_identity.current", "account_id"),
+					resource.TestMatchResourceAttr("data.ksyun_caller_identity.current", "krn", regexp.MustCompile(`^krn:ksc:iam::\d+:`)),
+					resource.TestCheckResourceAttrSet("data.ksyun_caller_identity.current", "region"),
+				),
+			},
+		},
+	})
+}
+
+func TestCallerIdentityFromGetUser(t *testing.T) {
+	// the error as the SDK returns it for the main account
+	root := awserr.NewRequestFailure(awserr.New(callerIdentityRootErrorCode, "user not exist", nil), 400, "req-1")
+	data, err := callerIdentityFromGetUser(nil, root)
+	if err != nil || data["identity_type"] != callerIdentityTypeRoot || data["account_id"] != "" {
+		t.Errorf("the error of the main account is read as %v, %v, want the root without account", data, err)
+	}
+
+	for _, callErr := range []error{
+		awserr.NewRequestFailure(awserr.New("AccessDenied", "no permission", nil), 403, "req-2"),
+		awserr.NewRequestFailure(awserr.New("Throttling", "too many requests", nil), 400, "req-3"),
+		awserr.New("RequestError", "send request failed", nil),
+	} {
+		if data, err = callerIdentityFromGetUser(nil, callErr); err == nil {
+			t.Errorf("the error %s is read as %v, want the error", callErr, data)
+		}
+	}
+
+	user := &map[string]interface{}{"GetUserResult": map[string]interface{}{"User": map[string]interface{}{
+		"UserName": "ops", "UserId": "u-1", "Krn": "krn:ksc:iam::2000001:user/ops",
+	}}}
+	data, err = callerIdentityFromGetUser(user, nil)
+	if err != nil || data["identity_type"] != callerIdentityTypeUser || data["user_name"] != "ops" ||
+		data["account_id"] != "2000001" || data["krn"] != "krn:ksc:iam::2000001:user/ops" {
+		t.Errorf("the sub-account is read as %v, %v", data, err)
+	}
+
+	role := &map[string]interface{}{"GetUserResult": map[string]interface{}{"User": map[string]interface{}{
+		"Krn": "krn:ksc:iam::2000002:assumed-role/deployer/session-1",
+	}}}
+	data, err = callerIdentityFromGetUser(role, nil)
+	if err != nil || data["identity_type"] != callerIdentityTypeRole || data["role_name"] != "deployer" ||
+		data["account_id"] != "2000002" || data["user_name"] != "" {
+		t.Errorf("the role is read as %v, %v", data, err)
+	}
+
+	noKrn := &map[string]interface{}{"GetUserResult": map[string]interface{}{"User": map[string]interface{}{
+		"UserName": "ops",
+	}}}
+	if data, err = callerIdentityFromGetUser(noKrn, nil); err == nil {
+		t.Errorf("a user without KRN is read as %v, want the error", data)
+	}
+}
+
+const testAccDataCallerIdentityConfig = `
+data "ksyun_caller_identity" "current" {}
+`
@@ -0,0 +1,138 @@
+/*
+This data source provides a list of the routes learned by a cen from its network instances.
+
+# Example Usage
+
+```hcl
+
+	data "ksyun_cen_routes" "default" {
+	  output_file = "output_result"
+	  cen_ids     = ["7385c8ea-79f7-4e9c-b99f-517fc3726256"]
+	}
+
+```
+*/
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunCenRoutes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunCenRoutesRead,
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "A list of cen route IDs.",
+			},
+			"cen_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "A list of cen IDs, the routes of which are retrieved.",
+			},
+			"instance_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "A list of the IDs of the network instances, the routes learned from which are retrieved.",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File name where to save data source results (after running `terraform plan`).",
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of routes that satisfy the condition.",
+			},
+			"routes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "An information list of routes. Each element contains the following attributes:",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the route.",
+						},
+						"cen_route_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the route.",
+						},
+						"cen_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the cen.",
+						},
+						"destination_cidr_block": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The destination CIDR block of the route.",
+						},
+						"instance_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the network instance the route is learned from.",
+						},
+						"instance_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the network instance.",
+						},
+						"instance_region": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The region of the network instance.",
+						},
+						"instance_account_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The account ID of the network instance.",
+						},
+						"instance_route_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the route in the network instance.",
+						},
+						"network_route_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the route in the network instance.",
+						},
+						"self_route_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the self-defined route published to the cen.",
+						},
+						"create_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the route is learned.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunCenRoutesRead(d *schema.ResourceData, meta interface{}) error {
+	cenService := CenService{meta.(*KsyunClient)}
+	return cenService.ReadAndSetCenRoutes(d, dataSourceKsyunCenRoutes())
+}
@@ -0,0 +1,46 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunCenRoutesDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataCenRoutesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ksyun_cen_routes.foo"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataCenRoutesConfig = `
+resource "ksyun_cen" "default" {
+  cen_name = "tf-acc-cen-routes"
+}
+
+resource "ksyun_vpc" "default" {
+  vpc_name   = "tf-acc-cen-routes"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_cen_instance_attachment" "default" {
+  cen_id        = "${ksyun_cen.default.id}"
+  instance_type = "Vpc"
+  instance_id   = "${ksyun_vpc.default.id}"
+}
+
+data "ksyun_cen_routes" "foo" {
+  cen_ids     = ["${ksyun_cen_instance_attachment.default.cen_id}"]
+  output_file = "output_result_cen_routes"
+}
+`
@@ -0,0 +1,125 @@
+/*
+This data source provides a list of the routes of a Direct Connect Gateway.
+
+# Example Usage
+
+```hcl
+
+	data "ksyun_direct_connect_gateway_routes" "default" {
+	  output_file               = "output_result"
+	  direct_connect_gateway_id = "a8979fe2-cf1a-47b9-80f6-57445227c541"
+	}
+
+```
+*/
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunDirectConnectGatewayRoutes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunDirectConnectGatewayRoutesRead,
+		Schema: map[string]*schema.Schema{
+			"direct_connect_gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the Direct Connect Gateway.",
+			},
+			"destination_cidr_blocks": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "A list of destination CIDR blocks of the routes.",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File name where to save data source results (after running `terraform plan`).",
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of routes that satisfy the condition.",
+			},
+			"routes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "An information list of routes. Each element contains the following attributes:",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the route.",
+						},
+						"direct_connect_gateway_route_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the route.",
+						},
+						"destination_cidr_block": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The destination CIDR block of the route.",
+						},
+						"next_hop_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the next hop.",
+						},
+						"next_hop_instance": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the next hop instance.",
+						},
+						"next_hop_instance_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the next hop instance.",
+						},
+						"direct_connect_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the Direct Connect.",
+						},
+						"priority": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The priority of the route.",
+						},
+						"as_path": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The AS path of the route.",
+						},
+						"route_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the route.",
+						},
+						"bgp_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Whether the route is published by BGP, `Published` or `Unpublished`.",
+						},
+						"create_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The creation time of the route.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunDirectConnectGatewayRoutesRead(d *schema.ResourceData, meta interface{}) error {
+	srv := VpcService{meta.(*KsyunClient)}
+	return srv.ReadAndSetDirectConnectGatewayRoutes(d, dataSourceKsyunDirectConnectGatewayRoutes())
+}
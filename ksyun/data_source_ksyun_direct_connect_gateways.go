@@ -0,0 +1,155 @@
+/*
+This data source provides a list of Direct Connect Gateway resources according to their ID, name and the vpc they belong to.
+
+# Example Usage
+
+```hcl
+
+	data "ksyun_direct_connect_gateways" "default" {
+	  output_file = "output_result"
+	  vpc_ids     = ["a8979fe2-cf1a-47b9-80f6-57445227c541"]
+	  name_regex  = "tf-.*"
+	}
+
+```
+*/
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceKsyunDirectConnectGateways() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunDirectConnectGatewaysRead,
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "A list of Direct Connect Gateway IDs.",
+			},
+			"vpc_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "A list of vpc IDs, the Direct Connect Gateways of which are retrieved.",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "A regex string to filter results by Direct Connect Gateway name.",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File name where to save data source results (after running `terraform plan`).",
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of Direct Connect Gateways that satisfy the condition.",
+			},
+			"direct_connect_gateways": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "An information list of Direct Connect Gateways. Each element contains the following attributes:",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the Direct Connect Gateway.",
+						},
+						"direct_connect_gateway_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the Direct Connect Gateway.",
+						},
+						"direct_connect_gateway_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the Direct Connect Gateway.",
+						},
+						"vpc_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the vpc.",
+						},
+						"nat_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the nat.",
+						},
+						"band_width": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The bandwidth of the Direct Connect Gateway.",
+						},
+						"associated_instance_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the instance associated with the Direct Connect Gateway.",
+						},
+						"cen_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the cen the Direct Connect Gateway is attached to.",
+						},
+						"cen_account_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The account ID of the cen.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the Direct Connect Gateway.",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version of the Direct Connect Gateway.",
+						},
+						"remote_cidr_set": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The CIDR blocks of the customer side.",
+						},
+						"extra_cidr_set": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The extra CIDR blocks of the cloud side.",
+						},
+						"direct_connect_interface_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The IDs of the Direct Connect Interfaces attached to the Direct Connect Gateway.",
+						},
+						"create_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The creation time of the Direct Connect Gateway.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunDirectConnectGatewaysRead(d *schema.ResourceData, meta interface{}) error {
+	srv := VpcService{meta.(*KsyunClient)}
+	return srv.ReadAndSetDirectConnectGateways(d, dataSourceKsyunDirectConnectGateways())
+}
@@ -0,0 +1,44 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunDirectConnectGatewaysDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataDirectConnectGatewaysConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ksyun_direct_connect_gateways.foo"),
+					resource.TestCheckResourceAttr("data.ksyun_direct_connect_gateways.foo", "direct_connect_gateways.#", "1"),
+					resource.TestCheckResourceAttrPair("data.ksyun_direct_connect_gateways.foo", "direct_connect_gateways.0.vpc_id", "ksyun_vpc.default", "id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataDirectConnectGatewaysConfig = `
+resource "ksyun_vpc" "default" {
+  vpc_name   = "tf-acc-dc-gateway-vpc"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_direct_connect_gateway" "default" {
+  direct_connect_gateway_name = "tf-acc-dc-gateway"
+  vpc_id                      = "${ksyun_vpc.default.id}"
+}
+
+data "ksyun_direct_connect_gateways" "foo" {
+  vpc_ids     = ["${ksyun_vpc.default.id}"]
+  name_regex  = "${ksyun_direct_connect_gateway.default.direct_connect_gateway_name}"
+  output_file = "output_result_direct_connect_gateways"
+}
+`
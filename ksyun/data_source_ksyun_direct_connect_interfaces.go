@@ -0,0 +1,208 @@
+/*
+This data source provides a list of Direct Connect Interface resources according to their ID, name and the Direct Connect they belong to.
+
+The `bgp_status` of the interfaces with the `BGP` route type is the state of the BGP session with the customer peer.
+
+# Example Usage
+
+```hcl
+
+	data "ksyun_direct_connect_interfaces" "default" {
+	  output_file        = "output_result"
+	  direct_connect_ids = ["dc-8c3d2a1b-7e6f-4a5b-9c8d-1e2f3a4b5c6d"]
+	}
+
+```
+*/
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceKsyunDirectConnectInterfaces() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunDirectConnectInterfacesRead,
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "A list of Direct Connect Interface IDs.",
+			},
+			"direct_connect_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "A list of Direct Connect IDs, the interfaces of which are retrieved.",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "A regex string to filter results by Direct Connect Interface name.",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File name where to save data source results (after running `terraform plan`).",
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of Direct Connect Interfaces that satisfy the condition.",
+			},
+			"direct_connect_interfaces": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "An information list of Direct Connect Interfaces. Each element contains the following attributes:",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the Direct Connect Interface.",
+						},
+						"direct_connect_interface_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the Direct Connect Interface.",
+						},
+						"direct_connect_interface_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the Direct Connect Interface.",
+						},
+						"direct_connect_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the Direct Connect.",
+						},
+						"account_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The account ID of the Direct Connect.",
+						},
+						"direct_connect_interface_account_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The account ID of the Direct Connect Interface.",
+						},
+						"vlan_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The vlan ID of the Direct Connect Interface.",
+						},
+						"customer_peer_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address of the customer side.",
+						},
+						"local_peer_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address of the cloud side.",
+						},
+						"route_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The route type of the Direct Connect Interface, `BGP` or `STATIC`.",
+						},
+						"bgp_peer": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The BGP ASN of the customer side.",
+						},
+						"bgp_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The state of the BGP session, it is empty if the route type is not `BGP`.",
+						},
+						"reliability_method": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The reliability method of the Direct Connect Interface.",
+						},
+						"bfd_config_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the BFD config.",
+						},
+						"priority": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The priority of the Direct Connect Interface.",
+						},
+						"ha_direct_connect_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the high availability Direct Connect.",
+						},
+						"ha_direct_connect_interface_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the high availability Direct Connect Interface.",
+						},
+						"ha_direct_connect_interface_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the high availability Direct Connect Interface.",
+						},
+						"ha_vlan_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The vlan ID of the high availability Direct Connect Interface.",
+						},
+						"ha_customer_peer_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address of the customer side of the high availability Direct Connect Interface.",
+						},
+						"ha_local_peer_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address of the cloud side of the high availability Direct Connect Interface.",
+						},
+						"enable_ipv6": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether IPv6 is enabled.",
+						},
+						"customer_peer_ipv6": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IPv6 address of the customer side.",
+						},
+						"local_peer_ipv6": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IPv6 address of the cloud side.",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The state of the Direct Connect Interface.",
+						},
+						"create_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The creation time of the Direct Connect Interface.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunDirectConnectInterfacesRead(d *schema.ResourceData, meta interface{}) error {
+	srv := VpcService{meta.(*KsyunClient)}
+	return srv.ReadAndSetDirectConnectInterfaces(d, dataSourceKsyunDirectConnectInterfaces())
+}
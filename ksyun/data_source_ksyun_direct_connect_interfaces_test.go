@@ -0,0 +1,37 @@
+package ksyun
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDirectConnectInterfacesBgpStatus(t *testing.T) {
+	got := directConnectInterfacesBgpStatus(map[string]interface{}{
+		"RequestId": "request",
+		"DirectConnectInterfaceBgpStatusSet": []interface{}{
+			map[string]interface{}{
+				"DirectConnectInterfaceId": "dci-1",
+				"BgpStatus":                "Established",
+			},
+			map[string]interface{}{
+				"DirectConnectInterfaceId": "dci-2",
+				"BgpStatus":                "Idle",
+			},
+			map[string]interface{}{
+				"DirectConnectInterfaceId": "dci-3",
+			},
+		},
+		"OtherSet": []interface{}{"dci-4"},
+	})
+	want := map[string]string{
+		"dci-1": "Established",
+		"dci-2": "Idle",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("directConnectInterfacesBgpStatus() = %v, want %v", got, want)
+	}
+
+	if got = directConnectInterfacesBgpStatus(map[string]interface{}{"RequestId": "request"}); len(got) != 0 {
+		t.Errorf("directConnectInterfacesBgpStatus() = %v without the status set, want none", got)
+	}
+}
@@ -18,6 +18,18 @@ This data source provides a list of EIP resources (Elastic IP address) according
 	  public_ip=[]
 	}
 
+```
+
+Export the EIPs to a CSV file.
+
+```hcl
+
+	data "ksyun_eips" "csv" {
+	  output_file    = "eips.csv"
+	  output_format  = "csv"
+	  output_columns = ["allocation_id", "public_ip", "band_width", "instance_id"]
+	}
+
 ```
 */
 package ksyun
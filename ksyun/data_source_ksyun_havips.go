@@ -0,0 +1,119 @@
+/*
+This data source provides a list of HaVip resources according to their ID, vpc and subnet.
+
+# Example Usage
+
+```hcl
+
+	data "ksyun_havips" "default" {
+	  output_file = "output_result"
+	  subnet_ids  = ["a8979fe2-cf1a-47b9-80f6-57445227c541"]
+	}
+
+```
+*/
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunHaVips() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunHaVipsRead,
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "A list of HaVip IDs.",
+			},
+			"vpc_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "A list of vpc IDs, the HaVips of which are retrieved.",
+			},
+			"subnet_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "A list of subnet IDs, the HaVips of which are retrieved.",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File name where to save data source results (after running `terraform plan`).",
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of HaVips that satisfy the condition.",
+			},
+			"havips": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "An information list of HaVips. Each element contains the following attributes:",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the HaVip.",
+						},
+						"ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The private IP address of the HaVip.",
+						},
+						"subnet_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the subnet.",
+						},
+						"vpc_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the vpc.",
+						},
+						"master_network_interface_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the master network interface.",
+						},
+						"slave_network_interface_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The IDs of the slave network interfaces.",
+						},
+						"allocation_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the EIP associated with the HaVip.",
+						},
+						"create_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The creation time of the HaVip.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunHaVipsRead(d *schema.ResourceData, meta interface{}) error {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	return vpcService.ReadAndSetHaVips(d, dataSourceKsyunHaVips())
+}
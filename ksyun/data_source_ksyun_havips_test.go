@@ -0,0 +1,53 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunHaVipsDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataHaVipsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ksyun_havips.foo"),
+					resource.TestCheckResourceAttr("data.ksyun_havips.foo", "havips.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataHaVipsConfig = `
+variable "available_zone" {
+  default = "cn-beijing-6a"
+}
+
+resource "ksyun_vpc" "default" {
+  vpc_name   = "tf-acc-havip-vpc"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_subnet" "default" {
+  subnet_name       = "tf-acc-havip-subnet"
+  cidr_block        = "10.7.0.0/21"
+  subnet_type       = "Normal"
+  vpc_id            = "${ksyun_vpc.default.id}"
+  availability_zone = "${var.available_zone}"
+}
+
+resource "ksyun_havip" "default" {
+  subnet_id = "${ksyun_subnet.default.id}"
+}
+
+data "ksyun_havips" "foo" {
+  ids         = ["${ksyun_havip.default.id}"]
+  output_file = "output_result_havips"
+}
+`
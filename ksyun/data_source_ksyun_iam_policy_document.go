@@ -0,0 +1,510 @@
+/*
+This data source generates an IAM policy document in JSON, for use with `ksyun_iam_policy` and the KS3 bucket policies.
+
+# Example Usage
+
+```hcl
+
+	data "ksyun_caller_identity" "current" {}
+
+	data "ksyun_iam_policy_document" "bucket_read" {
+	  statement {
+	    sid       = "ReadObjects"
+	    actions   = ["ks3:GetObject", "ks3:ListBucket"]
+	    resources = ["krn:ksc:ks3:::example-bucket", "krn:ksc:ks3:::example-bucket/*"]
+
+	    condition {
+	      test     = "IpAddress"
+	      variable = "ksc:SourceIp"
+	      values   = ["10.0.0.0/8"]
+	    }
+	  }
+
+	  statement {
+	    effect      = "Deny"
+	    not_actions = ["iam:Get*", "iam:List*"]
+	    resources   = ["krn:ksc:iam::${data.ksyun_caller_identity.current.account_id}:*"]
+	  }
+	}
+
+	resource "ksyun_iam_policy" "bucket_read" {
+	  policy_name     = "bucket-read"
+	  policy_document = data.ksyun_iam_policy_document.bucket_read.json
+	}
+
+```
+*/
+package ksyun
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+const iamPolicyDocumentVersion = "2015-11-01"
+
+var (
+	iamPolicyActionRegexp = regexp.MustCompile(`^(\*|[a-z0-9-]+:[A-Za-z0-9*?]+)$`)
+	iamPolicySidRegexp    = regexp.MustCompile(`^[A-Za-z0-9]*$`)
+
+	// iamPolicyListKeys are the statement keys whose single value means a list of the value
+	iamPolicyListKeys = map[string]bool{"Action": true, "NotAction": true, "Resource": true, "NotResource": true}
+	// iamPolicyStatementKeys is the order of the keys in the json of a statement, the other keys follow sorted
+	iamPolicyStatementKeys = []string{"Sid", "Effect", "Principal", "NotPrincipal", "Action", "NotAction", "Resource",
+		"NotResource", "Condition"}
+)
+
+func dataSourceKsyunIamPolicyDocument() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunIamPolicyDocumentRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     iamPolicyDocumentVersion,
+				Description: "The version of the policy language.",
+			},
+			"source_policy_documents": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The JSON policy documents merged first, their statements with a `sid` are replaced by the statements of the data source with the same `sid`.",
+			},
+			"override_policy_documents": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The JSON policy documents merged last in order, their statements replace the statements with the same `sid`.",
+			},
+			"statement": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The statements of the policy.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"sid": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringMatch(iamPolicySidRegexp, "sid must be alphanumeric"),
+							Description:  "The ID of the statement, it must be unique in the document.",
+						},
+						"effect": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "Allow",
+							ValidateFunc: validation.StringInSlice([]string{"Allow", "Deny"}, false),
+							Description:  "The effect of the statement, valid values: `Allow`, `Deny`. Default is `Allow`.",
+						},
+						"actions": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Description: "The actions the statement applies to, such as `kec:DescribeInstances` or `ks3:*`.",
+						},
+						"not_actions": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Description: "The actions the statement does not apply to.",
+						},
+						"resources": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Description: "The KRNs of the resources the statement applies to, or `*`.",
+						},
+						"condition": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "The conditions of the statement.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"test": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The condition operator, such as `StringEquals` or `IpAddress`.",
+									},
+									"variable": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The condition key, such as `ksc:SourceIp`.",
+									},
+									"values": {
+										Type:        schema.TypeList,
+										Required:    true,
+										MinItems:    1,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "The values of the condition key.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File name where to save data source results (after running `terraform plan`).",
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The policy document in canonical JSON.",
+			},
+		},
+	}
+}
+
+// iamPolicyDocument is the canonical form of a policy, the lists are sorted and deduplicated
+type iamPolicyDocument struct {
+	Version   string                        `json:"Version"`
+	Statement []iamPolicyCanonicalStatement `json:"Statement"`
+}
+
+// iamPolicyCanonicalStatement is a statement with every key of the policy, the values of iamPolicyListKeys, the
+// principals and the condition values are sorted and deduplicated lists
+type iamPolicyCanonicalStatement map[string]interface{}
+
+type iamPolicyStatement struct {
+	Sid       string                         `json:"Sid,omitempty"`
+	Effect    string                         `json:"Effect"`
+	Action    []string                       `json:"Action,omitempty"`
+	NotAction []string                       `json:"NotAction,omitempty"`
+	Resource  []string                       `json:"Resource,omitempty"`
+	Condition map[string]map[string][]string `json:"Condition,omitempty"`
+}
+
+func dataSourceKsyunIamPolicyDocumentRead(d *schema.ResourceData, meta interface{}) error {
+	doc := &iamPolicyDocument{Version: d.Get("version").(string)}
+	for i, v := range d.Get("source_policy_documents").([]interface{}) {
+		source, err := parseIamPolicyDocument(v)
+		if err != nil {
+			return fmt.Errorf("source_policy_documents.%d: %s", i, err)
+		}
+		doc.merge(source.Statement)
+	}
+
+	var statements []*iamPolicyStatement
+	for i, v := range d.Get("statement").([]interface{}) {
+		statement, err := expandIamPolicyStatement(v.(map[string]interface{}))
+		if err != nil {
+			return fmt.Errorf("statement.%d: %s", i, err)
+		}
+		statements = append(statements, statement)
+	}
+	if err := checkIamPolicySids(statements); err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		doc.merge([]iamPolicyCanonicalStatement{statement.canonical()})
+	}
+
+	for i, v := range d.Get("override_policy_documents").([]interface{}) {
+		override, err := parseIamPolicyDocument(v)
+		if err != nil {
+			return fmt.Errorf("override_policy_documents.%d: %s", i, err)
+		}
+		doc.merge(override.Statement)
+	}
+
+	if len(doc.Statement) == 0 {
+		return fmt.Errorf("the policy document has no statement")
+	}
+	bs, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	policy := string(bs)
+
+	d.SetId(fmt.Sprintf("%d", schema.HashString(policy)))
+	if err = d.Set("json", policy); err != nil {
+		return err
+	}
+	if outputFile, ok := d.GetOk("output_file"); ok && outputFile.(string) != "" {
+		return writeToFile(outputFile.(string), policy, dataSourceOutputOptions(d))
+	}
+	return nil
+}
+
+// merge replaces the statements having the sid of a new statement and appends the others
+func (doc *iamPolicyDocument) merge(statements []iamPolicyCanonicalStatement) {
+	for _, statement := range statements {
+		replaced := false
+		if sid := stringOrEmpty(statement["Sid"]); sid != "" {
+			for i, old := range doc.Statement {
+				if stringOrEmpty(old["Sid"]) == sid {
+					doc.Statement[i] = statement
+					replaced = true
+					break
+				}
+			}
+		}
+		if !replaced {
+			doc.Statement = append(doc.Statement, statement)
+		}
+	}
+}
+
+func checkIamPolicySids(statements []*iamPolicyStatement) error {
+	sids := make(map[string]bool)
+	for _, statement := range statements {
+		if statement.Sid == "" {
+			continue
+		}
+		if sids[statement.Sid] {
+			return fmt.Errorf("duplicate statement sid %q", statement.Sid)
+		}
+		sids[statement.Sid] = true
+	}
+	return nil
+}
+
+func expandIamPolicyStatement(m map[string]interface{}) (*iamPolicyStatement, error) {
+	statement := &iamPolicyStatement{
+		Sid:       m["sid"].(string),
+		Effect:    m["effect"].(string),
+		Action:    canonicalIamPolicyList(SchemaSetToStringSlice(m["actions"])),
+		NotAction: canonicalIamPolicyList(SchemaSetToStringSlice(m["not_actions"])),
+		Resource:  canonicalIamPolicyList(SchemaSetToStringSlice(m["resources"])),
+	}
+	for _, v := range m["condition"].(*schema.Set).List() {
+		condition := v.(map[string]interface{})
+		var values []string
+		for _, value := range condition["values"].([]interface{}) {
+			values = append(values, value.(string))
+		}
+		statement.addCondition(condition["test"].(string), condition["variable"].(string), values)
+	}
+	return statement, statement.validate()
+}
+
+func (statement *iamPolicyStatement) addCondition(test, variable string, values []string) {
+	if statement.Condition == nil {
+		statement.Condition = make(map[string]map[string][]string)
+	}
+	if statement.Condition[test] == nil {
+		statement.Condition[test] = make(map[string][]string)
+	}
+	statement.Condition[test][variable] = canonicalIamPolicyList(append(statement.Condition[test][variable], values...))
+}
+
+func (statement *iamPolicyStatement) canonical() iamPolicyCanonicalStatement {
+	bs, _ := json.Marshal(statement)
+	var m map[string]interface{}
+	_ = json.Unmarshal(bs, &m)
+	return canonicalIamPolicyStatement(m)
+}
+
+// validate checks the syntax of the statement offline, the service checks the semantics
+func (statement *iamPolicyStatement) validate() error {
+	if len(statement.Action) > 0 && len(statement.NotAction) > 0 {
+		return fmt.Errorf("actions and not_actions are mutually exclusive")
+	}
+	if len(statement.Action) == 0 && len(statement.NotAction) == 0 {
+		return fmt.Errorf("one of actions and not_actions is required")
+	}
+	if len(statement.Resource) == 0 {
+		return fmt.Errorf("resources is required")
+	}
+	var errs []string
+	for _, action := range append(append([]string{}, statement.Action...), statement.NotAction...) {
+		if !iamPolicyActionRegexp.MatchString(action) {
+			errs = append(errs, fmt.Sprintf("invalid action %q, expected `<service>:<action>` or `*`", action))
+		}
+	}
+	for _, resource := range statement.Resource {
+		if err := validateKrn(resource); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// validateKrn checks a resource is `*` or a KRN `krn:ksc:<service>:<region>:<account>:<resource>`,
+// the region and the account may be empty
+func validateKrn(krn string) error {
+	if krn == "*" {
+		return nil
+	}
+	parts := strings.SplitN(krn, ":", 6)
+	if len(parts) != 6 || parts[0] != "krn" || parts[1] == "" || parts[2] == "" || parts[5] == "" {
+		return fmt.Errorf("invalid resource %q, expected `krn:ksc:<service>:<region>:<account>:<resource>` or `*`", krn)
+	}
+	return nil
+}
+
+func canonicalIamPolicyList(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool)
+	var result []string
+	for _, v := range values {
+		if !set[v] {
+			set[v] = true
+			result = append(result, v)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// parseIamPolicyDocument reads a JSON policy, a single statement and the single values may be written
+// without the brackets
+func parseIamPolicyDocument(v interface{}) (*iamPolicyDocument, error) {
+	s, _ := v.(string)
+	var raw struct {
+		Version   string          `json:"Version"`
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(s), &raw); err != nil {
+		return nil, fmt.Errorf("invalid policy document: %s", err)
+	}
+	var rawStatements []map[string]interface{}
+	if err := json.Unmarshal(raw.Statement, &rawStatements); err != nil {
+		var single map[string]interface{}
+		if err = json.Unmarshal(raw.Statement, &single); err != nil {
+			return nil, fmt.Errorf("invalid policy statement: %s", err)
+		}
+		rawStatements = append(rawStatements, single)
+	}
+
+	doc := &iamPolicyDocument{Version: raw.Version}
+	for _, m := range rawStatements {
+		doc.Statement = append(doc.Statement, canonicalIamPolicyStatement(m))
+	}
+	return doc, nil
+}
+
+// canonicalIamPolicyStatement keeps every key of a statement, only the values known to be lists are changed
+func canonicalIamPolicyStatement(m map[string]interface{}) iamPolicyCanonicalStatement {
+	statement := make(iamPolicyCanonicalStatement, len(m))
+	for k, v := range m {
+		switch {
+		case iamPolicyListKeys[k]:
+			if values := canonicalIamPolicyList(iamPolicyStrings(v)); len(values) > 0 {
+				statement[k] = values
+			}
+		case k == "Principal" || k == "NotPrincipal":
+			// `*` or the principals by their type
+			statement[k] = canonicalIamPolicyListMap(v)
+		case k == "Condition":
+			conditions, ok := v.(map[string]interface{})
+			if !ok {
+				statement[k] = v
+				continue
+			}
+			canonical := make(map[string]interface{}, len(conditions))
+			for test, keys := range conditions {
+				canonical[test] = canonicalIamPolicyListMap(keys)
+			}
+			statement[k] = canonical
+		default:
+			statement[k] = v
+		}
+	}
+	return statement
+}
+
+func canonicalIamPolicyListMap(v interface{}) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	result := make(map[string]interface{}, len(m))
+	for k, values := range m {
+		result[k] = canonicalIamPolicyList(iamPolicyStrings(values))
+	}
+	return result
+}
+
+// MarshalJSON writes the keys of iamPolicyStatementKeys first, so a statement reads like the policies of the console
+func (statement iamPolicyCanonicalStatement) MarshalJSON() ([]byte, error) {
+	var keys, others []string
+	for _, k := range iamPolicyStatementKeys {
+		if _, ok := statement[k]; ok {
+			keys = append(keys, k)
+		}
+	}
+	for k := range statement {
+		if !stringSliceContains(iamPolicyStatementKeys, k) {
+			others = append(others, k)
+		}
+	}
+	sort.Strings(others)
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range append(keys, others...) {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		value, err := json.Marshal(statement[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func stringOrEmpty(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return ""
+}
+
+func iamPolicyStrings(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var result []string
+		for _, item := range v {
+			result = append(result, fmt.Sprintf("%v", item))
+		}
+		return result
+	case nil:
+		return nil
+	default:
+		return []string{fmt.Sprintf("%v", v)}
+	}
+}
+
+// iamPolicyDocumentDiffSuppressFunc ignores the changes of a policy document which do not change the policy,
+// such as the formatting, the order of the actions or the brackets around a single value
+func iamPolicyDocumentDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return old == new
+	}
+	oldDoc, err := parseIamPolicyDocument(old)
+	if err != nil {
+		return false
+	}
+	newDoc, err := parseIamPolicyDocument(new)
+	if err != nil {
+		return false
+	}
+	oldJson, _ := json.Marshal(oldDoc)
+	newJson, _ := json.Marshal(newDoc)
+	return string(oldJson) == string(newJson)
+}
@@ -0,0 +1,162 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testIamPolicyDocument(t *testing.T, raw map[string]interface{}) (string, error) {
+	r := dataSourceKsyunIamPolicyDocument()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if err := r.Read(d, nil); err != nil {
+		return "", err
+	}
+	return d.Get("json").(string), nil
+}
+
+func TestIamPolicyDocument(t *testing.T) {
+	policy, err := testIamPolicyDocument(t, map[string]interface{}{
+		"statement": []interface{}{
+			map[string]interface{}{
+				"sid":       "Read",
+				"actions":   []interface{}{"ks3:ListBucket", "ks3:GetObject", "ks3:GetObject"},
+				"resources": []interface{}{"krn:ksc:ks3:::bucket/*", "krn:ksc:ks3:::bucket"},
+				"condition": []interface{}{
+					map[string]interface{}{"test": "IpAddress", "variable": "ksc:SourceIp", "values": []interface{}{"10.0.0.0/8"}},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+  "Version": "2015-11-01",
+  "Statement": [
+    {
+      "Sid": "Read",
+      "Effect": "Allow",
+      "Action": [
+        "ks3:GetObject",
+        "ks3:ListBucket"
+      ],
+      "Resource": [
+        "krn:ksc:ks3:::bucket",
+        "krn:ksc:ks3:::bucket/*"
+      ],
+      "Condition": {
+        "IpAddress": {
+          "ksc:SourceIp": [
+            "10.0.0.0/8"
+          ]
+        }
+      }
+    }
+  ]
+}`, policy)
+}
+
+func TestIamPolicyDocumentMerge(t *testing.T) {
+	source := `{"Version":"2015-11-01","Statement":[{"Sid":"Read","Effect":"Allow","Action":"kec:Describe*","Resource":"*"},{"Effect":"Allow","Action":["iam:List*"],"Resource":["*"]}]}`
+	override := `{"Statement":{"Sid":"Deny","Effect":"Deny","Action":"kec:TerminateInstances","Resource":"*"}}`
+
+	policy, err := testIamPolicyDocument(t, map[string]interface{}{
+		"source_policy_documents":   []interface{}{source},
+		"override_policy_documents": []interface{}{override},
+		"statement": []interface{}{
+			map[string]interface{}{
+				"sid":       "Read",
+				"actions":   []interface{}{"vpc:Describe*"},
+				"resources": []interface{}{"*"},
+			},
+			map[string]interface{}{
+				"sid":         "Deny",
+				"effect":      "Deny",
+				"not_actions": []interface{}{"kec:Describe*"},
+				"resources":   []interface{}{"*"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	doc, err := parseIamPolicyDocument(policy)
+	assert.NoError(t, err)
+	assert.Len(t, doc.Statement, 3)
+	assert.Equal(t, []string{"vpc:Describe*"}, doc.Statement[0]["Action"])
+	assert.Equal(t, []string{"iam:List*"}, doc.Statement[1]["Action"])
+	assert.Equal(t, []string{"kec:TerminateInstances"}, doc.Statement[2]["Action"])
+	assert.Empty(t, doc.Statement[2]["NotAction"])
+}
+
+func TestIamPolicyDocumentValidation(t *testing.T) {
+	statement := func(m map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"statement": []interface{}{m}}
+	}
+
+	_, err := testIamPolicyDocument(t, statement(map[string]interface{}{
+		"actions":   []interface{}{"DescribeInstances"},
+		"resources": []interface{}{"*"},
+	}))
+	assert.EqualError(t, err, "statement.0: invalid action \"DescribeInstances\", expected `<service>:<action>` or `*`")
+
+	_, err = testIamPolicyDocument(t, statement(map[string]interface{}{
+		"actions":   []interface{}{"kec:*"},
+		"resources": []interface{}{"krn:ksc:kec"},
+	}))
+	assert.Error(t, err)
+
+	_, err = testIamPolicyDocument(t, statement(map[string]interface{}{
+		"actions":     []interface{}{"kec:*"},
+		"not_actions": []interface{}{"kec:RunInstances"},
+		"resources":   []interface{}{"*"},
+	}))
+	assert.EqualError(t, err, "statement.0: actions and not_actions are mutually exclusive")
+
+	_, err = testIamPolicyDocument(t, map[string]interface{}{
+		"source_policy_documents": []interface{}{"{"},
+	})
+	assert.Error(t, err)
+}
+
+func TestIamPolicyDocumentDiffSuppressFunc(t *testing.T) {
+	old := `{"Version": "2015-11-01","Statement": [{"Effect": "Allow","Action": ["iam:List*", "iam:Get*"],"Resource": ["*"]}]}`
+	assert.True(t, iamPolicyDocumentDiffSuppressFunc("policy_document", old,
+		`{"Version":"2015-11-01","Statement":{"Effect":"Allow","Action":["iam:Get*","iam:List*"],"Resource":"*"}}`, nil))
+	assert.False(t, iamPolicyDocumentDiffSuppressFunc("policy_document", old,
+		`{"Version":"2015-11-01","Statement":{"Effect":"Deny","Action":["iam:Get*","iam:List*"],"Resource":"*"}}`, nil))
+}
+
+func TestIamPolicyDocumentDiffSuppressFuncOtherKeys(t *testing.T) {
+	old := `{"Version":"2015-11-01","Statement":[{"Effect":"Allow","Principal":{"KSC":["krn:ksc:iam::2000001:root","krn:ksc:iam::2000002:root"]},"Action":"ks3:GetObject","NotResource":["krn:ksc:ks3:::bucket/private/*"]}]}`
+	assert.True(t, iamPolicyDocumentDiffSuppressFunc("policy_document", old,
+		`{"Statement":[{"NotResource":"krn:ksc:ks3:::bucket/private/*","Action":["ks3:GetObject"],"Principal":{"KSC":["krn:ksc:iam::2000002:root","krn:ksc:iam::2000001:root"]},"Effect":"Allow"}],"Version":"2015-11-01"}`, nil))
+	assert.False(t, iamPolicyDocumentDiffSuppressFunc("policy_document", old,
+		`{"Version":"2015-11-01","Statement":[{"Effect":"Allow","Principal":{"KSC":"krn:ksc:iam::2000001:root"},"Action":"ks3:GetObject","NotResource":["krn:ksc:ks3:::bucket/private/*"]}]}`, nil))
+	assert.False(t, iamPolicyDocumentDiffSuppressFunc("policy_document", old,
+		`{"Version":"2015-11-01","Statement":[{"Effect":"Allow","Principal":{"KSC":["krn:ksc:iam::2000001:root","krn:ksc:iam::2000002:root"]},"Action":"ks3:GetObject","NotResource":["krn:ksc:ks3:::bucket/*"]}]}`, nil))
+	assert.False(t, iamPolicyDocumentDiffSuppressFunc("policy_document", old,
+		`{"Version":"2015-11-01","Statement":[{"Effect":"Allow","Principal":{"KSC":["krn:ksc:iam::2000001:root","krn:ksc:iam::2000002:root"]},"Action":"ks3:GetObject","NotResource":["krn:ksc:ks3:::bucket/private/*"],"Extra":true}]}`, nil))
+}
+
+func TestIamPolicyDocumentSourceKeys(t *testing.T) {
+	source := `{"Version":"2015-11-01","Statement":{"Sid":"Public","Effect":"Allow","Principal":"*","Action":"ks3:GetObject","NotResource":"krn:ksc:ks3:::bucket/private/*"}}`
+	policy, err := testIamPolicyDocument(t, map[string]interface{}{
+		"source_policy_documents": []interface{}{source},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+  "Version": "2015-11-01",
+  "Statement": [
+    {
+      "Sid": "Public",
+      "Effect": "Allow",
+      "Principal": "*",
+      "Action": [
+        "ks3:GetObject"
+      ],
+      "NotResource": [
+        "krn:ksc:ks3:::bucket/private/*"
+      ]
+    }
+  ]
+}`, policy)
+}
@@ -0,0 +1,111 @@
+/*
+This data source provides a list of IAM projects.
+
+# Example Usage
+
+```hcl
+
+	data "ksyun_iam_projects" "projects" {
+	  name_regex  = "^test"
+	  output_file = "output_result"
+	}
+
+```
+*/
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceKsyunIamProjects() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunIamProjectsRead,
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "A list of project IDs.",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "A regex string to filter results by project name.",
+			},
+			"include_all": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to return the disabled projects as well.",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File name where to save data source results (after running `terraform plan`).",
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of projects that satisfy the condition.",
+			},
+			"projects": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "An information list of projects. Each element contains the following attributes:",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the project.",
+						},
+						"project_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the project.",
+						},
+						"project_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the project.",
+						},
+						"project_desc": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the project.",
+						},
+						"status": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The status of the project, `1` means enabled.",
+						},
+						"account_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the account owning the project.",
+						},
+						"krn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The KRN of the project.",
+						},
+						"create_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time when the project was created.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunIamProjectsRead(d *schema.ResourceData, meta interface{}) error {
+	iamProjectService := IamProjectService{meta.(*KsyunClient)}
+	return iamProjectService.ReadAndSetIamProjects(d, dataSourceKsyunIamProjects())
+}
@@ -0,0 +1,30 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunIAMProjectsDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataIAMProjectsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ksyun_iam_projects.projects"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataIAMProjectsConfig = `
+data "ksyun_iam_projects" "projects" {
+  output_file = "output_result"
+}
+`
@@ -0,0 +1,39 @@
+/*
+This data source provides the attributes of one image, the read fails unless exactly one image matches the arguments or `most_recent` is set.
+
+# Example Usage
+
+```hcl
+data "ksyun_image" "centos" {
+  platform    = "centos-7.5"
+  is_public   = true
+  name_regex  = "^centos-7.5"
+  most_recent = true
+}
+
+output "image_id" {
+  value = data.ksyun_image.centos.image_id
+}
+```
+*/
+
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunImage() *schema.Resource {
+	return newSingularDataSource(singularDataSource{
+		plural:      dataSourceKsyunImages,
+		targetField: "images",
+		idField:     "image_id",
+		arguments: map[string]string{
+			"image_id":     "ids",
+			"platform":     "platform",
+			"is_public":    "is_public",
+			"image_source": "image_source",
+		},
+		mostRecent: "creation_date",
+	})
+}
@@ -0,0 +1,34 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunImageDataSource_mostRecent(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataImageConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ksyun_image.foo"),
+					resource.TestCheckResourceAttrSet("data.ksyun_image.foo", "image_id"),
+					resource.TestCheckResourceAttr("data.ksyun_image.foo", "is_public", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataImageConfig = `
+data "ksyun_image" "foo" {
+  is_public   = true
+  name_regex  = "centos-7"
+  most_recent = true
+}
+`
@@ -0,0 +1,36 @@
+/*
+This data source provides the attributes of one instance, the read fails unless exactly one instance matches the arguments.
+
+# Example Usage
+
+```hcl
+data "ksyun_instance" "web" {
+  vpc_id     = "vpc-xxxx"
+  name_regex = "^web-1$"
+}
+
+output "private_ip" {
+  value = data.ksyun_instance.web.private_ip_address
+}
+```
+*/
+
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunInstance() *schema.Resource {
+	return newSingularDataSource(singularDataSource{
+		plural:      dataSourceKsyunInstances,
+		targetField: "instances",
+		idField:     "instance_id",
+		arguments: map[string]string{
+			"instance_id": "ids",
+			"vpc_id":      "vpc_id",
+			"subnet_id":   "subnet_id",
+			"project_id":  "project_id",
+		},
+	})
+}
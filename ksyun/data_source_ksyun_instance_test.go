@@ -0,0 +1,34 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunInstanceDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataInstanceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ksyun_instance.foo", "id", "data.ksyun_instances.all", "instances.0.instance_id"),
+					resource.TestCheckResourceAttrSet("data.ksyun_instance.foo", "instance_name"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataInstanceConfig = `
+data "ksyun_instances" "all" {
+}
+
+data "ksyun_instance" "foo" {
+  instance_id = data.ksyun_instances.all.instances.0.instance_id
+}
+`
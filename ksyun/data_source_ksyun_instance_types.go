@@ -0,0 +1,236 @@
+/*
+This data source provides a list of KEC instance types with their specifications and the availability zones selling them.
+
+# Example Usage
+
+```hcl
+
+	data "ksyun_instance_types" "default" {
+	  availability_zone = "cn-beijing-6a"
+	  min_cpu           = 2
+	  max_cpu           = 4
+	  min_memory        = 4
+	  exclude_sold_out  = true
+	  output_file       = "output_result"
+	}
+
+	resource "ksyun_instance" "default" {
+	  instance_type = data.ksyun_instance_types.default.instance_types.0.instance_type
+	  # ...
+	}
+
+```
+*/
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceKsyunInstanceTypes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunInstanceTypesRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "A regex string to filter results by instance type.",
+			},
+			"instance_family": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The instance family, such as `N3`.",
+			},
+			"availability_zone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only the instance types sold in this availability zone are returned, the sold out state is evaluated for this zone.",
+			},
+			"min_cpu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The minimum number of vCPUs.",
+			},
+			"max_cpu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of vCPUs.",
+			},
+			"min_memory": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The minimum memory size in GB.",
+			},
+			"max_memory": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum memory size in GB.",
+			},
+			"min_gpu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The minimum number of GPUs, set it to `1` to only return the GPU instance types.",
+			},
+			"gpu_spec": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The GPU or vGPU specification of the instance types.",
+			},
+			"exclude_sold_out": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to drop the instance types which are sold out.",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File name where to save data source results (after running `terraform plan`).",
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of instance types that satisfy the condition.",
+			},
+			"instance_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "An information list of instance types. Each element contains the following attributes:",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The instance type.",
+						},
+						"instance_family": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The instance family.",
+						},
+						"instance_family_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the instance family.",
+						},
+						"cpu": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of vCPUs.",
+						},
+						"memory": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The memory size in GB.",
+						},
+						"gpu": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of GPUs.",
+						},
+						"gpu_spec": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The GPU or vGPU specification.",
+						},
+						"vgpu": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The vGPU of the instance type, empty for the types without vGPU.",
+						},
+						"network_bandwidth": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "The intranet bandwidth in Gbps, 0 when it is not reported.",
+						},
+						"network_pps": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The packet forwarding rate in ten thousand packets per second, 0 when it is not reported.",
+						},
+						"network_interface_quota": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The maximum number of network interfaces.",
+						},
+						"private_ip_quota": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The maximum number of private IPs of a network interface.",
+						},
+						"availability_zones": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The availability zones selling the instance type.",
+						},
+						"sold_out_zones": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The availability zones where the local disks of the instance type are sold out.",
+						},
+						"sold_out": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the instance type is sold out in `availability_zone`, or in all its availability zones if `availability_zone` is not set.",
+						},
+						"system_disk_types": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The supported system disk types.",
+						},
+						"data_disks": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The supported data disks, the local disks included.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"disk_type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The data disk type.",
+									},
+									"min_size": {
+										Type:        schema.TypeFloat,
+										Computed:    true,
+										Description: "The minimum data disk size in GB.",
+									},
+									"max_size": {
+										Type:        schema.TypeFloat,
+										Computed:    true,
+										Description: "The maximum data disk size in GB.",
+									},
+									"max_count": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "The maximum number of data disks.",
+									},
+									"sold_out_zones": {
+										Type:        schema.TypeList,
+										Computed:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "The availability zones where the data disk is sold out.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunInstanceTypesRead(d *schema.ResourceData, meta interface{}) error {
+	kecService := KecService{meta.(*KsyunClient)}
+	return kecService.ReadAndSetInstanceTypes(d, dataSourceKsyunInstanceTypes())
+}
@@ -0,0 +1,113 @@
+package ksyun
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-ksyun/ksyun/internal/pkg/helper"
+	"github.com/terraform-providers/terraform-provider-ksyun/ksyun/internal/structor/v1/kec"
+)
+
+func TestAccKsyunInstanceTypesDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataInstanceTypesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ksyun_instance_types.foo"),
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.foo", "instance_types.0.instance_family", "N3"),
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.foo", "instance_types.0.sold_out", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataInstanceTypesConfig = `
+data "ksyun_instance_types" "foo" {
+  output_file       = "output_result"
+  instance_family   = "N3"
+  availability_zone = "cn-beijing-6a"
+  min_cpu           = 2
+  max_cpu           = 4
+  exclude_sold_out  = true
+}
+`
+
+func TestFilterInstanceTypeConfigs(t *testing.T) {
+	configs := []kec.InstanceTypeConfig{
+		{
+			InstanceType: "N3.4B", InstanceFamily: "N3", CPU: 4, Memory: 8,
+			AvailabilityZoneSet: []kec.AvailabilityZone{{AzCode: "cn-beijing-6a"}, {AzCode: "cn-beijing-6b"}},
+		},
+		{
+			InstanceType: "N3.2B", InstanceFamily: "N3", CPU: 2, Memory: 4,
+			AvailabilityZoneSet: []kec.AvailabilityZone{{AzCode: "cn-beijing-6a"}},
+		},
+		{
+			InstanceType: "I4.4B", InstanceFamily: "I4", CPU: 4, Memory: 16,
+			AvailabilityZoneSet: []kec.AvailabilityZone{{AzCode: "cn-beijing-6a"}, {AzCode: "cn-beijing-6b"}},
+			DataDiskQuotaSet: []kec.DataDiskQuota{{
+				DataDiskType: "Local_SSD",
+				AvailabilityZoneSet: []kec.AvailabilityZone{
+					{AzCode: "cn-beijing-6a", IsSellOut: true},
+					{AzCode: "cn-beijing-6b"},
+				},
+			}},
+		},
+		{
+			InstanceType: "P3I.8B", InstanceFamily: "P3I", CPU: 8, Memory: 64, GPUSpec: "T4",
+			InstanceConfigure:   kec.InstanceConfigure{GPU: 1, VGPU: "1/2"},
+			NetworkPerformance:  kec.NetworkPerformance{Bandwidth: 10, Pps: 200},
+			AvailabilityZoneSet: []kec.AvailabilityZone{{AzCode: "cn-beijing-6b"}},
+		},
+	}
+	types := func(items []map[string]interface{}) (result []string) {
+		for _, item := range items {
+			result = append(result, item["instance_type"].(string))
+		}
+		return result
+	}
+
+	all := filterInstanceTypeConfigs(configs, instanceTypeCondition{})
+	assert.Equal(t, []string{"N3.2B", "N3.4B", "I4.4B", "P3I.8B"}, types(all))
+	assert.Equal(t, []string{"cn-beijing-6a"}, all[2]["sold_out_zones"])
+	assert.Equal(t, false, all[2]["sold_out"])
+
+	inZone := filterInstanceTypeConfigs(configs, instanceTypeCondition{availabilityZone: "cn-beijing-6a"})
+	assert.Equal(t, []string{"N3.2B", "N3.4B", "I4.4B"}, types(inZone))
+	assert.Equal(t, true, inZone[2]["sold_out"])
+
+	available := filterInstanceTypeConfigs(configs, instanceTypeCondition{availabilityZone: "cn-beijing-6a", excludeSoldOut: true, minCpu: 4})
+	assert.Equal(t, []string{"N3.4B"}, types(available))
+
+	gpu := filterInstanceTypeConfigs(configs, instanceTypeCondition{minGpu: 1, maxMemory: 64})
+	assert.Equal(t, []string{"P3I.8B"}, types(gpu))
+	assert.Equal(t, 8, gpu[0]["cpu"])
+	assert.Equal(t, "1/2", gpu[0]["vgpu"])
+	assert.Equal(t, 10.0, gpu[0]["network_bandwidth"])
+	assert.Equal(t, 200, gpu[0]["network_pps"])
+
+	memory := filterInstanceTypeConfigs(configs, instanceTypeCondition{minMemory: 8, maxMemory: 16, nameRegex: regexp.MustCompile(`^N3\.`)})
+	assert.Equal(t, []string{"N3.4B"}, types(memory))
+}
+
+func TestInstanceTypeConfigConfigure(t *testing.T) {
+	var config kec.InstanceTypeConfig
+	assert.NoError(t, helper.MapstructureFiller(map[string]interface{}{
+		"InstanceType":       "P3I.8B",
+		"CPU":                8,
+		"Memory":             64,
+		"GPU":                1,
+		"VGPU":               "1/2",
+		"NetworkPerformance": map[string]interface{}{"Bandwidth": 10, "Pps": 200},
+	}, &config, ""))
+	assert.Equal(t, kec.InstanceConfigure{VCPU: 8, MemoryGb: 64, GPU: 1, VGPU: "1/2"}, config.Configure())
+	assert.Equal(t, 200, config.NetworkPerformance.Pps)
+}
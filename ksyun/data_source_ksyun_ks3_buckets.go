@@ -24,14 +24,12 @@ package ksyun
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/ks3sdklib/ksyun-ks3-go-sdk/ks3"
 	"log"
-	"os"
 	"regexp"
 	"strings"
 	"time"
@@ -594,12 +592,16 @@ func bucketsDescriptionAttributes(d *schema.ResourceData, buckets []ks3.BucketPr
 		s = append(s, mapping)
 	}
 
+	ids, s, err := filterDataSourceItems(d, ids, s)
+	if err != nil {
+		return WrapError(err)
+	}
 	d.SetId(dataResourceIdHash(ids))
 	if err := d.Set("buckets", s); err != nil {
 		return WrapError(err)
 	}
 	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
-		writeBucketInfoToFile(output.(string), s)
+		return writeToFile(output.(string), s, dataSourceOutputOptions(d))
 	}
 	return nil
 }
@@ -637,22 +639,3 @@ func GetBucketInfo(client *ks3.Client, bucket string) (ks3.GetBucketInfoResult,
 	}
 	return ks3.GetBucketInfoResult{}, nil
 }
-
-func writeBucketInfoToFile(filePath string, data interface{}) error {
-	absPath, err := getAbsPath(filePath)
-	if err != nil {
-		return err
-	}
-	os.Remove(absPath)
-	var bs []byte
-	switch data := data.(type) {
-	case string:
-		bs = []byte(data)
-	default:
-		bs, err = json.MarshalIndent(data, "", "  ")
-		if err != nil {
-			return fmt.Errorf("MarshalIndent data %#v and got an error: %#v", data, err)
-		}
-	}
-	return os.WriteFile(absPath, bs, 0422)
-}
@@ -0,0 +1,36 @@
+/*
+This data source provides the attributes of one load balancer, the read fails unless exactly one load balancer matches the arguments.
+
+# Example Usage
+
+```hcl
+data "ksyun_lb" "default" {
+  vpc_id     = "vpc-xxxx"
+  name_regex = "^public-lb$"
+}
+
+output "public_ip" {
+  value = data.ksyun_lb.default.public_ip
+}
+```
+*/
+
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunLb() *schema.Resource {
+	return newSingularDataSource(singularDataSource{
+		plural:      dataSourceKsyunLbs,
+		targetField: "lbs",
+		idField:     "load_balancer_id",
+		arguments: map[string]string{
+			"load_balancer_id": "ids",
+			"vpc_id":           "vpc_id",
+			"project_id":       "project_id",
+			"state":            "state",
+		},
+	})
+}
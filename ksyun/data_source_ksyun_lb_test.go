@@ -0,0 +1,42 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunLbDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataLbConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ksyun_lb.foo", "id", "ksyun_lb.default", "id"),
+					resource.TestCheckResourceAttr("data.ksyun_lb.foo", "load_balancer_name", "tf-acc-lb-singular"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataLbConfig = `
+resource "ksyun_vpc" "default" {
+  vpc_name   = "tf-acc-lb-singular"
+  cidr_block = "192.168.0.0/16"
+}
+
+resource "ksyun_lb" "default" {
+  vpc_id             = ksyun_vpc.default.id
+  load_balancer_name = "tf-acc-lb-singular"
+  type               = "public"
+}
+
+data "ksyun_lb" "foo" {
+  load_balancer_id = ksyun_lb.default.id
+}
+`
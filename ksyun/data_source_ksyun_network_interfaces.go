@@ -165,6 +165,11 @@ func dataSourceKsyunNetworkInterfaces() *schema.Resource {
 							Computed:    true,
 							Description: "private IP.",
 						},
+						"ipv6_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IPv6 address of the network interface.",
+						},
 						"d_n_s1": {
 							Type:        schema.TypeString,
 							Computed:    true,
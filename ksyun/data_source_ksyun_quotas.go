@@ -0,0 +1,98 @@
+/*
+This data source provides the usage of the quotas of the region: EIPs, VPCs, SLBs, instances per availability zone and rules per security group.
+
+~> **NOTE:** The API does not report the quotas of the account, the limits are the `quota_limits` of the provider.
+
+# Example Usage
+
+```hcl
+
+	provider "ksyun" {
+	  region = "cn-beijing-6"
+	  quota_limits = {
+	    eip      = 50
+	    instance = 200
+	  }
+	  quota_check = true
+	}
+
+	data "ksyun_quotas" "default" {
+	  services    = ["eip", "instance"]
+	  output_file = "output_result"
+	}
+
+```
+*/
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceKsyunQuotas() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunQuotasRead,
+		Schema: map[string]*schema.Schema{
+			"services": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(quotaServices, false),
+				},
+				Set:         schema.HashString,
+				Description: "The services to report, defaults to all of them. Valid values: `eip`, `vpc`, `instance`, `slb`, `security_group_rule`.",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File name where to save data source results (after running `terraform plan`).",
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of quotas that satisfy the condition.",
+			},
+			"quotas": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "An information list of quotas. Each element contains the following attributes:",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The service of the quota.",
+						},
+						"scope": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The availability zone of the `instance` quotas, the security group of the `security_group_rule` quotas, empty for the quotas of the region.",
+						},
+						"used": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of resources in use.",
+						},
+						"limit": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The limit set in the `quota_limits` of the provider, `0` if it is not set.",
+						},
+						"remaining": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of resources which can still be created, `-1` if the limit is not known.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunQuotasRead(d *schema.ResourceData, meta interface{}) error {
+	quotaService := QuotaService{meta.(*KsyunClient)}
+	return quotaService.ReadAndSetQuotas(d, dataSourceKsyunQuotas())
+}
@@ -0,0 +1,116 @@
+package ksyun
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccKsyunQuotasDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataQuotasConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ksyun_quotas.foo"),
+					resource.TestCheckResourceAttr("data.ksyun_quotas.foo", "quotas.0.service", "eip"),
+				),
+			},
+		},
+	})
+}
+
+func TestCountQuotaUsages(t *testing.T) {
+	instances := []interface{}{
+		map[string]interface{}{"InstanceId": "i-1", "AvailabilityZone": "cn-beijing-6b"},
+		map[string]interface{}{"InstanceId": "i-2", "AvailabilityZone": "cn-beijing-6a"},
+		map[string]interface{}{"InstanceId": "i-3", "AvailabilityZone": "cn-beijing-6b"},
+	}
+	if got, want := countQuotaUsages(quotaServiceInstance, instances), []quotaUsage{
+		{service: quotaServiceInstance, scope: "cn-beijing-6a", used: 1},
+		{service: quotaServiceInstance, scope: "cn-beijing-6b", used: 2},
+	}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected instance usages %v, want %v", got, want)
+	}
+
+	groups := []interface{}{
+		map[string]interface{}{"SecurityGroupId": "sg-1", "SecurityGroupEntrySet": []interface{}{
+			map[string]interface{}{"SecurityGroupEntryId": "e-1"},
+			map[string]interface{}{"SecurityGroupEntryId": "e-2"},
+		}},
+		map[string]interface{}{"SecurityGroupId": "sg-2"},
+	}
+	if got, want := countQuotaUsages(quotaServiceSecurityGroupRule, groups), []quotaUsage{
+		{service: quotaServiceSecurityGroupRule, scope: "sg-1", used: 2},
+		{service: quotaServiceSecurityGroupRule, scope: "sg-2", used: 0},
+	}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected security group rule usages %v, want %v", got, want)
+	}
+
+	if got, want := countQuotaUsages(quotaServiceEip, instances), []quotaUsage{
+		{service: quotaServiceEip, scope: "", used: 3},
+	}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected eip usages %v, want %v", got, want)
+	}
+}
+
+func TestValidateQuotaLimits(t *testing.T) {
+	if _, errs := validateQuotaLimits(map[string]interface{}{"eip": 50, "instance": 200}, "quota_limits"); len(errs) != 0 {
+		t.Errorf("unexpected errors %v", errs)
+	}
+	if _, errs := validateQuotaLimits(map[string]interface{}{"ebs": 50, "vpc": -1}, "quota_limits"); len(errs) != 2 {
+		t.Errorf("expected 2 errors, got %v", errs)
+	}
+}
+
+func TestCheckQuotaCountsEachResource(t *testing.T) {
+	client := &KsyunClient{
+		region:       "cn-beijing-6",
+		config:       &Config{QuotaCheck: true, QuotaLimits: map[string]int{quotaServiceEip: 3}},
+		quotaTracker: newQuotaTracker(),
+	}
+	client.quotaTracker.used[quotaServiceEip] = map[string]int{"": 1}
+
+	p := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"ksyun_eip": resourceKsyunEip(),
+		},
+	}
+	p.SetMeta(client)
+	// the eips of a count are planned with the same arguments, each of them is counted
+	diff := func() error {
+		_, err := p.SimpleDiff(&terraform.InstanceInfo{Type: "ksyun_eip"}, nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"band_width":  1,
+			"charge_type": "PostPaidByDay",
+		}))
+		return err
+	}
+	for i := 0; i < 2; i++ {
+		if err := diff(); err != nil {
+			t.Fatalf("planning the eip %d failed: %s", i, err)
+		}
+	}
+	if err := diff(); err == nil {
+		t.Errorf("planning the third eip passed, 1 eip is used and 2 are planned with a quota of 3")
+	}
+
+	// without a limit in quota_limits the check is skipped
+	client.config.QuotaLimits = nil
+	if err := diff(); err != nil {
+		t.Errorf("planning an eip without a limit failed: %s", err)
+	}
+}
+
+const testAccDataQuotasConfig = `
+data "ksyun_quotas" "foo" {
+  services    = ["eip"]
+  output_file = "output_result"
+}
+`
@@ -0,0 +1,82 @@
+/*
+This data source provides a list of the regions.
+
+# Example Usage
+
+```hcl
+
+	data "ksyun_regions" "default" {
+	  output_file = "output_result"
+	}
+
+	data "ksyun_regions" "current" {
+	  current = true
+	}
+
+```
+*/
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceKsyunRegions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunRegionsRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "A regex string to filter results by region name.",
+			},
+			"current": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to only return the region of the provider.",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File name where to save data source results (after running `terraform plan`).",
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of regions that satisfy the condition.",
+			},
+			"regions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "An information list of regions. Each element contains the following attributes:",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The code of the region, such as `cn-beijing-6`.",
+						},
+						"region_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the region.",
+						},
+						"current": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the region is the region of the provider.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunRegionsRead(d *schema.ResourceData, meta interface{}) error {
+	kecService := KecService{meta.(*KsyunClient)}
+	return kecService.ReadAndSetRegions(d, dataSourceKsyunRegions())
+}
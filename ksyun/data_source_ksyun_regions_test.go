@@ -0,0 +1,36 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunRegionsDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataRegionsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ksyun_regions.foo"),
+					resource.TestCheckResourceAttr("data.ksyun_regions.current", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.ksyun_regions.current", "regions.0.current", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataRegionsConfig = `
+data "ksyun_regions" "foo" {
+  output_file="output_result"
+}
+
+data "ksyun_regions" "current" {
+  current = true
+}
+`
@@ -0,0 +1,38 @@
+/*
+This data source provides the attributes of one security group, the read fails unless exactly one security group matches the arguments.
+
+# Example Usage
+
+```hcl
+data "ksyun_security_group" "web" {
+  vpc_id = "vpc-xxxx"
+
+  filter {
+    name   = "security_group_name"
+    values = ["web"]
+  }
+}
+
+output "security_group_id" {
+  value = data.ksyun_security_group.web.security_group_id
+}
+```
+*/
+
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunSecurityGroup() *schema.Resource {
+	return newSingularDataSource(singularDataSource{
+		plural:      dataSourceKsyunSecurityGroups,
+		targetField: "security_groups",
+		idField:     "security_group_id",
+		arguments: map[string]string{
+			"security_group_id": "ids",
+			"vpc_id":            "vpc_id",
+		},
+	})
+}
@@ -0,0 +1,46 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunSecurityGroupDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSecurityGroupConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ksyun_security_group.foo", "id", "ksyun_security_group.default", "id"),
+					resource.TestCheckResourceAttr("data.ksyun_security_group.foo", "security_group_name", "tf-acc-sg-singular"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSecurityGroupConfig = `
+resource "ksyun_vpc" "default" {
+  vpc_name   = "tf-acc-sg-singular"
+  cidr_block = "192.168.0.0/16"
+}
+
+resource "ksyun_security_group" "default" {
+  vpc_id              = ksyun_vpc.default.id
+  security_group_name = "tf-acc-sg-singular"
+}
+
+data "ksyun_security_group" "foo" {
+  vpc_id = ksyun_vpc.default.id
+
+  filter {
+    name   = "security_group_name"
+    values = [ksyun_security_group.default.security_group_name]
+  }
+}
+`
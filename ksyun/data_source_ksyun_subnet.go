@@ -0,0 +1,38 @@
+/*
+This data source provides the attributes of one subnet, the read fails unless exactly one subnet matches the arguments.
+
+# Example Usage
+
+```hcl
+data "ksyun_subnet" "default" {
+  vpc_id     = "vpc-xxxx"
+  name_regex = "^web$"
+}
+
+output "subnet_id" {
+  value = data.ksyun_subnet.default.subnet_id
+}
+```
+*/
+
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunSubnet() *schema.Resource {
+	return newSingularDataSource(singularDataSource{
+		plural:      dataSourceKsyunSubnets,
+		targetField: "subnets",
+		idField:     "subnet_id",
+		arguments: map[string]string{
+			"subnet_id":              "ids",
+			"vpc_id":                 "vpc_ids",
+			"nat_id":                 "nat_ids",
+			"network_acl_id":         "network_acl_ids",
+			"subnet_type":            "subnet_types",
+			"availability_zone_name": "availability_zone_names",
+		},
+	})
+}
@@ -0,0 +1,48 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunSubnetDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSubnetConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ksyun_subnet.foo", "id", "ksyun_subnet.default", "id"),
+					resource.TestCheckResourceAttr("data.ksyun_subnet.foo", "cidr_block", "192.168.1.0/24"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSubnetConfig = `
+data "ksyun_availability_zones" "default" {
+}
+
+resource "ksyun_vpc" "default" {
+  vpc_name   = "tf-acc-subnet-singular"
+  cidr_block = "192.168.0.0/16"
+}
+
+resource "ksyun_subnet" "default" {
+  subnet_name       = "tf-acc-subnet-singular"
+  cidr_block        = "192.168.1.0/24"
+  subnet_type       = "Normal"
+  vpc_id            = ksyun_vpc.default.id
+  availability_zone = data.ksyun_availability_zones.default.availability_zones.0.availability_zone_name
+}
+
+data "ksyun_subnet" "foo" {
+  vpc_id     = ksyun_vpc.default.id
+  subnet_id  = ksyun_subnet.default.id
+}
+`
@@ -15,6 +15,21 @@ This data source provides a list of Subnet resources according to their Subnet I
 	  availability_zone_name=[]
 	}
 
+```
+
+Use generic filters on the results.
+
+```hcl
+
+	data "ksyun_subnets" "web" {
+	  vpc_ids = ["vpc-xxxx"]
+
+	  filter {
+	    name   = "subnet_name"
+	    values = ["web-*"]
+	  }
+	}
+
 ```
 */
 package ksyun
@@ -220,6 +235,11 @@ func dataSourceKsyunSubnets() *schema.Resource {
 							},
 							Description: "An Ipv6 association list of this vpc.",
 						},
+						"ipv6_cidr_block": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IPv6 CIDR block of the subnet.",
+						},
 					},
 				},
 			},
@@ -0,0 +1,32 @@
+/*
+This data source provides the attributes of one VPC, the read fails unless exactly one VPC matches the arguments.
+
+# Example Usage
+
+```hcl
+data "ksyun_vpc" "default" {
+  name_regex = "^prod$"
+}
+
+output "vpc_cidr" {
+  value = data.ksyun_vpc.default.cidr_block
+}
+```
+*/
+
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunVpc() *schema.Resource {
+	return newSingularDataSource(singularDataSource{
+		plural:      dataSourceKsyunVpcs,
+		targetField: "vpcs",
+		idField:     "vpc_id",
+		arguments: map[string]string{
+			"vpc_id": "ids",
+		},
+	})
+}
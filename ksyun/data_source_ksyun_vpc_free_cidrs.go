@@ -0,0 +1,88 @@
+/*
+This data source provides the free IPv4 space of a VPC, which is the space of its CIDR blocks not used by any subnet.
+
+# Example Usage
+
+```hcl
+
+	data "ksyun_vpc_free_cidrs" "default" {
+	  vpc_id             = "a8979fe2-cf1a-47b9-80f6-57445227c541"
+	  cidr_prefix_length = 24
+	  output_file        = "output_result"
+	}
+
+	output "next_cidr_block" {
+	  value = data.ksyun_vpc_free_cidrs.default.next_cidr_block
+	}
+
+```
+*/
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceKsyunVpcFreeCidrs() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunVpcFreeCidrsRead,
+		Schema: map[string]*schema.Schema{
+			"vpc_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the VPC.",
+			},
+			"cidr_prefix_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(16, 29),
+				Description:  "If set, only the free CIDR blocks which can hold a subnet of this prefix length are returned.",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File name where to save data source results (after running `terraform plan`).",
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of free CIDR blocks that satisfy the condition.",
+			},
+			"next_cidr_block": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The CIDR block a `ksyun_subnet` with the same `cidr_prefix_length` would get now, empty if `cidr_prefix_length` is not set or there is no room left.",
+			},
+			"free_cidrs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The free space of the VPC as the largest aligned CIDR blocks, sorted by address. Each element contains the following attributes:",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr_block": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The free CIDR block.",
+						},
+						"prefix_length": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The prefix length of the free CIDR block.",
+						},
+						"ip_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of IP addresses in the free CIDR block.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunVpcFreeCidrsRead(d *schema.ResourceData, meta interface{}) error {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	return vpcService.ReadAndSetVpcFreeCidrs(d, dataSourceKsyunVpcFreeCidrs())
+}
@@ -0,0 +1,152 @@
+package ksyun
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunVpcFreeCidrsDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataVpcFreeCidrsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ksyun_vpc_free_cidrs.foo"),
+					resource.TestCheckResourceAttr("data.ksyun_vpc_free_cidrs.foo", "next_cidr_block", "192.168.1.0/24"),
+					resource.TestCheckResourceAttr("ksyun_subnet.auto", "cidr_block", "192.168.1.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestFreeCidrBlocks(t *testing.T) {
+	cases := []struct {
+		vpcCidrs  []string
+		used      []string
+		want      []string
+		prefix    int
+		wantBlock string
+	}{
+		{
+			vpcCidrs:  []string{"10.0.0.0/16"},
+			want:      []string{"10.0.0.0/16"},
+			prefix:    24,
+			wantBlock: "10.0.0.0/24",
+		},
+		{
+			vpcCidrs:  []string{"10.0.0.0/16"},
+			used:      []string{"10.0.0.0/24", "10.0.2.0/24"},
+			want:      []string{"10.0.1.0/24", "10.0.3.0/24", "10.0.4.0/22", "10.0.8.0/21", "10.0.16.0/20", "10.0.32.0/19", "10.0.64.0/18", "10.0.128.0/17"},
+			prefix:    23,
+			wantBlock: "10.0.4.0/23",
+		},
+		{
+			vpcCidrs:  []string{"172.16.0.0/24", "10.0.0.0/24"},
+			used:      []string{"10.0.0.0/25", "10.0.0.128/25", "172.16.0.64/26"},
+			want:      []string{"172.16.0.0/26", "172.16.0.128/25"},
+			prefix:    25,
+			wantBlock: "172.16.0.128/25",
+		},
+		{
+			vpcCidrs: []string{"10.0.0.0/24"},
+			used:     []string{"10.0.0.0/24"},
+			prefix:   28,
+		},
+	}
+	for _, c := range cases {
+		free, err := freeCidrBlocks(c.vpcCidrs, c.used)
+		if err != nil {
+			t.Fatalf("freeCidrBlocks(%v, %v) error: %s", c.vpcCidrs, c.used, err)
+		}
+		if !reflect.DeepEqual(free, c.want) {
+			t.Errorf("freeCidrBlocks(%v, %v) = %v, want %v", c.vpcCidrs, c.used, free, c.want)
+		}
+		block, err := nextFreeCidrBlock(c.vpcCidrs, c.used, c.prefix)
+		if err != nil {
+			t.Fatalf("nextFreeCidrBlock(%v, %v, %d) error: %s", c.vpcCidrs, c.used, c.prefix, err)
+		}
+		if block != c.wantBlock {
+			t.Errorf("nextFreeCidrBlock(%v, %v, %d) = %q, want %q", c.vpcCidrs, c.used, c.prefix, block, c.wantBlock)
+		}
+	}
+
+	if _, err := freeCidrBlocks([]string{"2400:a480::/56"}, nil); err == nil {
+		t.Errorf("freeCidrBlocks accepts an IPv6 vpc CIDR block")
+	}
+}
+
+const testAccDataVpcFreeCidrsConfig = `
+provider "ksyun" {
+	region = "cn-guangzhou-1"
+}
+
+data "ksyun_availability_zones" "foo" {
+}
+
+resource "ksyun_vpc" "foo" {
+	vpc_name   = "tf-acc-vpc-free-cidrs"
+	cidr_block = "192.168.0.0/16"
+}
+
+resource "ksyun_subnet" "foo" {
+	subnet_name       = "tf-acc-subnet-free-cidrs"
+	cidr_block        = "192.168.0.0/24"
+	subnet_type       = "Normal"
+	vpc_id            = ksyun_vpc.foo.id
+	availability_zone = data.ksyun_availability_zones.foo.availability_zones.0.availability_zone_name
+}
+
+resource "ksyun_subnet" "auto" {
+	subnet_name        = "tf-acc-subnet-auto-cidr"
+	cidr_prefix_length = 24
+	subnet_type        = "Normal"
+	vpc_id             = ksyun_vpc.foo.id
+	availability_zone  = data.ksyun_availability_zones.foo.availability_zones.0.availability_zone_name
+	depends_on         = [data.ksyun_vpc_free_cidrs.foo]
+}
+
+data "ksyun_vpc_free_cidrs" "foo" {
+	vpc_id             = ksyun_vpc.foo.id
+	cidr_prefix_length = 24
+	output_file        = "output_result_vpc_free_cidrs"
+	depends_on         = [ksyun_subnet.foo]
+}
+`
+
+func TestPlanSubnetCidrBlock(t *testing.T) {
+	vpcCidrs := []string{"10.0.0.0/22"}
+	plan := func(subnetCidrs ...string) string {
+		cidr, err := planSubnetCidrBlock("vpc-plan-test", vpcCidrs, subnetCidrs, 24)
+		if err != nil {
+			t.Fatalf("planSubnetCidrBlock() returned error: %s", err)
+		}
+		return cidr
+	}
+
+	// the subnets planned together get different blocks
+	if got := plan("10.0.0.0/24"); got != "10.0.1.0/24" {
+		t.Errorf("the first subnet is planned with %s, want 10.0.1.0/24", got)
+	}
+	if got := plan("10.0.0.0/24"); got != "10.0.2.0/24" {
+		t.Errorf("the second subnet is planned with %s, want 10.0.2.0/24", got)
+	}
+	// a planned block created since is not counted twice
+	if got := plan("10.0.0.0/24", "10.0.1.0/24"); got != "10.0.3.0/24" {
+		t.Errorf("the third subnet is planned with %s, want 10.0.3.0/24", got)
+	}
+	if _, err := planSubnetCidrBlock("vpc-plan-test", vpcCidrs, []string{"10.0.0.0/24"}, 24); err == nil || !strings.Contains(err.Error(), "no free CIDR block") {
+		t.Errorf("a full vpc is planned without error: %v", err)
+	}
+
+	if !cidrOverlaps("10.0.1.0/24", "10.0.0.0/23") || cidrOverlaps("10.0.1.0/24", "10.0.2.0/24") {
+		t.Errorf("cidrOverlaps() does not report the overlapping blocks only")
+	}
+}
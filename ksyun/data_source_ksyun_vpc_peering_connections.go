@@ -0,0 +1,174 @@
+/*
+This data source provides a list of VPC peering connection resources according to their ID, name and the vpc they belong to.
+
+# Example Usage
+
+```hcl
+
+	data "ksyun_vpc_peering_connections" "default" {
+	  output_file = "output_result"
+	  vpc_ids     = ["a8979fe2-cf1a-47b9-80f6-57445227c541"]
+	}
+
+```
+*/
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func vpcPeeringConnectionVpcInfoSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"vpc_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the vpc.",
+				},
+				"vpc_name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the vpc.",
+				},
+				"cidr_block": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The CIDR block of the vpc.",
+				},
+				"region": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The region of the vpc.",
+				},
+				"account_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The account ID of the vpc.",
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunVpcPeeringConnections() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunVpcPeeringConnectionsRead,
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "A list of peering connection IDs.",
+			},
+			"vpc_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "A list of vpc IDs, the peering connections of which are retrieved.",
+			},
+			"project_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "A list of project IDs.",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "A regex string to filter results by peering name.",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File name where to save data source results (after running `terraform plan`).",
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of peering connections that satisfy the condition.",
+			},
+			"vpc_peering_connections": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "An information list of peering connections. Each element contains the following attributes:",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the peering connection.",
+						},
+						"vpc_peering_connection_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the peering connection.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the peering connection.",
+						},
+						"vpc_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the vpc.",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The state of the peering connection.",
+						},
+						"band_width": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The bandwidth of the peering connection in Mbps.",
+						},
+						"vpc_peering_connection_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the peering connection.",
+						},
+						"charge_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The charge type of the peering connection.",
+						},
+						"project_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the project.",
+						},
+						"create_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The creation time of the peering connection.",
+						},
+						"requester_vpc": vpcPeeringConnectionVpcInfoSchema("The requester vpc of the peering connection."),
+						"accepter_vpc":  vpcPeeringConnectionVpcInfoSchema("The accepter vpc of the peering connection."),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunVpcPeeringConnectionsRead(d *schema.ResourceData, meta interface{}) error {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	return vpcService.ReadAndSetVpcPeeringConnections(d, dataSourceKsyunVpcPeeringConnections())
+}
@@ -0,0 +1,31 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunVpcPeeringConnectionsDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataVpcPeeringConnectionsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ksyun_vpc_peering_connections.foo"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataVpcPeeringConnectionsConfig = `
+data "ksyun_vpc_peering_connections" "foo" {
+  name_regex  = "tf-.*"
+  output_file = "output_result_peering"
+}
+`
@@ -0,0 +1,41 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunVpcDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataVpcConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ksyun_vpc.foo", "id", "ksyun_vpc.default", "id"),
+					resource.TestCheckResourceAttr("data.ksyun_vpc.foo", "cidr_block", "192.168.0.0/16"),
+					resource.TestCheckResourceAttr("data.ksyun_vpc.by_name", "vpc_name", "tf-acc-vpc-singular"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataVpcConfig = `
+resource "ksyun_vpc" "default" {
+  vpc_name   = "tf-acc-vpc-singular"
+  cidr_block = "192.168.0.0/16"
+}
+
+data "ksyun_vpc" "foo" {
+  vpc_id = ksyun_vpc.default.id
+}
+
+data "ksyun_vpc" "by_name" {
+  name_regex = "^${ksyun_vpc.default.vpc_name}$"
+}
+`
@@ -101,6 +101,11 @@ func dataSourceKsyunVpcs() *schema.Resource {
 							},
 							Description: "An Ipv6 association list of this vpc.",
 						},
+						"ipv6_cidr_block": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IPv6 CIDR block of the VPC.",
+						},
 
 						"create_time": {
 							Type:        schema.TypeString,
@@ -0,0 +1,110 @@
+/*
+This data source provides a list of the routes learned by BGP over a VPN tunnel.
+
+# Example Usage
+
+```hcl
+
+	data "ksyun_vpn_tunnel_bgp_routes" "default" {
+	  output_file   = "output_result"
+	  vpn_tunnel_id = ksyun_vpn_tunnel.default.id
+	}
+
+```
+*/
+
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunVpnTunnelBgpRoutes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunVpnTunnelBgpRoutesRead,
+
+		Schema: map[string]*schema.Schema{
+			"vpn_tunnel_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the VPN tunnel.",
+			},
+
+			"cidr_blocks": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         schema.HashString,
+				Description: "A list of destination CIDR blocks of the routes.",
+			},
+
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File name where to save data source results (after running `terraform plan`).",
+			},
+
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of routes that satisfy the condition.",
+			},
+			"routes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "An information list of the routes learned over the VPN tunnel. Each element contains the following attributes:",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the route.",
+						},
+						"vpn_gateway_route_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the route.",
+						},
+						"destination_cidr_block": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The destination CIDR block of the route.",
+						},
+						"route_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the route.",
+						},
+						"next_hop_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the next hop.",
+						},
+						"next_hop_instance_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the next hop instance.",
+						},
+						"vpn_gateway_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the VPN gateway.",
+						},
+						"create_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the route is learned.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunVpnTunnelBgpRoutesRead(d *schema.ResourceData, meta interface{}) error {
+	vpnService := VpnSrv{meta.(*KsyunClient)}
+	return vpnService.ReadAndSetVpnTunnelBgpRoutes(d, dataSourceKsyunVpnTunnelBgpRoutes())
+}
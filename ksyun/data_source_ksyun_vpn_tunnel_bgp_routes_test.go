@@ -0,0 +1,26 @@
+package ksyun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVpnTunnelBgpRoutes(t *testing.T) {
+	routes := []interface{}{
+		map[string]interface{}{"VpnGatewayRouteId": "learned", "RouteType": "Bgp", "NextHopInstanceId": "tunnel-1"},
+		map[string]interface{}{"VpnGatewayRouteId": "static", "RouteType": "Static", "NextHopInstanceId": "tunnel-1"},
+		map[string]interface{}{"VpnGatewayRouteId": "other-tunnel", "RouteType": "bgp", "NextHopInstanceId": "tunnel-2"},
+		map[string]interface{}{"VpnGatewayRouteId": "by-name", "RouteType": "BGP", "NextHopInstanceName": "tf-tunnel"},
+		map[string]interface{}{"VpnGatewayRouteId": "other-name", "RouteType": "BGP", "NextHopInstanceName": "other"},
+	}
+	var ids []string
+	for _, route := range vpnTunnelBgpRoutes(routes, "tunnel-1", "tf-tunnel") {
+		ids = append(ids, route.(map[string]interface{})["VpnGatewayRouteId"].(string))
+	}
+	if got := strings.Join(ids, ","); got != "learned,by-name" {
+		t.Errorf("vpnTunnelBgpRoutes() = %s, want learned,by-name", got)
+	}
+	if routes := vpnTunnelBgpRoutes(routes[3:], "tunnel-1", ""); len(routes) != 0 {
+		t.Errorf("vpnTunnelBgpRoutes() matches %d routes by an empty tunnel name, want none", len(routes))
+	}
+}
@@ -181,6 +181,33 @@ func dataSourceKsyunVpnTunnels() *schema.Resource {
 							Computed:    true,
 							Description: "The peer ip of customer.",
 						},
+						"bgp_local_asn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The BGP ASN of kingsoft cloud.",
+						},
+						"bgp_peer_asn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The BGP ASN of customer.",
+						},
+						"bgp_local_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The BGP peer ip of kingsoft cloud.",
+						},
+						"bgp_peer_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The BGP peer ip of customer.",
+						},
+						"bgp_advertised_cidr_blocks": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The CIDR blocks advertised to customer by BGP.",
+						},
+						"tunnel_health": vpnTunnelHealthSchema(),
 
 						"vpn_m_tunnel_create_time": {
 							Type:        schema.TypeString,
@@ -0,0 +1,648 @@
+package ksyun
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-ksyun/logger"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ExportConfig describes the resources `terraform-provider-ksyun export` reads and where the hcl is written
+type ExportConfig struct {
+	Region    string
+	Services  []string
+	Filters   []string
+	OutputDir string
+}
+
+// exportType describes how the items of a list data source become resource blocks
+type exportType struct {
+	service      string
+	resourceType string
+	dataSource   func() *schema.Resource
+	targetField  string
+	idField      string
+	nameFields   []string
+	// tagResourceType is the resource type known by the tag service, empty if the resource has no tags
+	tagResourceType string
+	// rename maps a data source field to the resource argument
+	rename map[string]string
+	// prepare adjusts a data source item before it is mapped to the resource schema
+	prepare func(item map[string]interface{})
+}
+
+// exportTypes is ordered, resources referenced by others are written first
+var exportTypes = []exportType{
+	{
+		service:      "vpc",
+		resourceType: "ksyun_vpc",
+		dataSource:   dataSourceKsyunVpcs,
+		targetField:  "vpcs",
+		idField:      "vpc_id",
+		nameFields:   []string{"vpc_name", "name"},
+	},
+	{
+		service:      "vpc",
+		resourceType: "ksyun_subnet",
+		dataSource:   dataSourceKsyunSubnets,
+		targetField:  "subnets",
+		idField:      "subnet_id",
+		nameFields:   []string{"subnet_name", "name"},
+		rename: map[string]string{
+			"name":                   "subnet_name",
+			"availability_zone_name": "availability_zone",
+		},
+	},
+	{
+		service:      "vpc",
+		resourceType: "ksyun_security_group",
+		dataSource:   dataSourceKsyunSecurityGroups,
+		targetField:  "security_groups",
+		idField:      "security_group_id",
+		nameFields:   []string{"security_group_name", "name"},
+	},
+	{
+		service:         "vpc",
+		resourceType:    "ksyun_nat",
+		dataSource:      dataSourceKsyunNats,
+		targetField:     "nats",
+		idField:         "id",
+		nameFields:      []string{"nat_name"},
+		tagResourceType: "nat",
+	},
+	{
+		service:         "kec",
+		resourceType:    "ksyun_instance",
+		dataSource:      dataSourceKsyunInstances,
+		targetField:     "instances",
+		idField:         "instance_id",
+		nameFields:      []string{"instance_name"},
+		tagResourceType: "instance",
+		prepare:         prepareExportInstance,
+	},
+	{
+		service:         "slb",
+		resourceType:    "ksyun_lb",
+		dataSource:      dataSourceKsyunLbs,
+		targetField:     "lbs",
+		idField:         "load_balancer_id",
+		nameFields:      []string{"load_balancer_name"},
+		tagResourceType: "loadbalancer",
+	},
+	{
+		service:         "eip",
+		resourceType:    "ksyun_eip",
+		dataSource:      dataSourceKsyunEips,
+		targetField:     "eips",
+		idField:         "allocation_id",
+		nameFields:      []string{"public_ip"},
+		tagResourceType: "eip",
+	},
+}
+
+// ExportServices returns the services supported by Export
+func ExportServices() []string {
+	var services []string
+	seen := make(map[string]bool)
+	for _, t := range exportTypes {
+		if !seen[t.service] {
+			seen[t.service] = true
+			services = append(services, t.service)
+		}
+	}
+	return services
+}
+
+type exportFilter struct {
+	tag   bool
+	key   string
+	value string
+}
+
+func parseExportFilters(filters []string) ([]exportFilter, error) {
+	var result []exportFilter
+	for _, f := range filters {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		pos := strings.Index(f, "=")
+		if pos <= 0 {
+			return nil, fmt.Errorf("invalid filter %q, expected tag:KEY=VALUE or ATTRIBUTE=VALUE", f)
+		}
+		filter := exportFilter{
+			key:   f[:pos],
+			value: f[pos+1:],
+		}
+		if strings.HasPrefix(filter.key, "tag:") {
+			filter.tag = true
+			filter.key = strings.TrimPrefix(filter.key, "tag:")
+		}
+		if filter.key == "" {
+			return nil, fmt.Errorf("invalid filter %q, the key is empty", f)
+		}
+		result = append(result, filter)
+	}
+	return result, nil
+}
+
+type exportItem struct {
+	typ     *exportType
+	id      string
+	name    string
+	data    map[string]interface{}
+	keep    bool
+	tagless bool
+}
+
+func (i *exportItem) address() string {
+	return i.typ.resourceType + "." + i.name
+}
+
+// Export reads the existing resources of the selected services through the list data sources and writes
+// one <service>.tf file per service with a resource block and an import block for each of them.
+// Credentials are read from the same environment variables as the provider.
+func Export(c ExportConfig) error {
+	filters, err := parseExportFilters(c.Filters)
+	if err != nil {
+		return err
+	}
+	services := make(map[string]bool)
+	for _, s := range c.Services {
+		// an empty --services exports every service
+		if s = strings.TrimSpace(s); s != "" {
+			services[s] = true
+		}
+	}
+	for s := range services {
+		if !checkValueInSlice(ExportServices(), s) {
+			return fmt.Errorf("service %q is not supported by export, supported services: %s", s, strings.Join(ExportServices(), ","))
+		}
+	}
+
+	region := c.Region
+	if region == "" {
+		region = os.Getenv("KSYUN_REGION")
+	}
+	if region == "" {
+		return fmt.Errorf("region must be set by --region or KSYUN_REGION")
+	}
+	config := Config{
+		AccessKey:     os.Getenv("KSYUN_ACCESS_KEY"),
+		SecretKey:     os.Getenv("KSYUN_SECRET_KEY"),
+		Region:        region,
+		Domain:        os.Getenv("KSYUN_DOMAIN"),
+		Endpoint:      os.Getenv("KSYUN_ENDPOINT"),
+		HttpKeepAlive: true,
+	}
+	client, err := config.Client()
+	if err != nil {
+		return err
+	}
+
+	var items []*exportItem
+	for i := range exportTypes {
+		t := &exportTypes[i]
+		if len(services) > 0 && !services[t.service] {
+			continue
+		}
+		typeItems, err := readExportItems(client, t)
+		if err != nil {
+			return fmt.Errorf("read %s error: %s", t.targetField, err)
+		}
+		items = append(items, typeItems...)
+	}
+
+	if err = filterExportItems(client, items, filters); err != nil {
+		return err
+	}
+	var kept []*exportItem
+	for _, item := range items {
+		if item.keep {
+			kept = append(kept, item)
+		}
+	}
+	assignExportNames(kept)
+	return writeExportFiles(c.OutputDir, region, kept)
+}
+
+func readExportItems(client *KsyunClient, t *exportType) ([]*exportItem, error) {
+	r := t.dataSource()
+	d := r.Data(nil)
+	if err := r.Read(d, client); err != nil {
+		return nil, err
+	}
+	var items []*exportItem
+	for _, v := range d.Get(t.targetField).([]interface{}) {
+		data := normalizeExportValue(v).(map[string]interface{})
+		if t.prepare != nil {
+			t.prepare(data)
+		}
+		for from, to := range t.rename {
+			if value, ok := data[from]; ok {
+				if _, exist := data[to]; !exist {
+					data[to] = value
+				}
+			}
+		}
+		id, _ := data[t.idField].(string)
+		if id == "" {
+			continue
+		}
+		items = append(items, &exportItem{
+			typ:     t,
+			id:      id,
+			data:    data,
+			keep:    true,
+			tagless: t.tagResourceType == "",
+		})
+	}
+	logger.Debug(logger.RespFormat, "Export", t.resourceType, len(items))
+	return items, nil
+}
+
+// normalizeExportValue turns *schema.Set into plain slices, recursively
+func normalizeExportValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case *schema.Set:
+		return normalizeExportValue(value.List())
+	case []interface{}:
+		result := make([]interface{}, 0, len(value))
+		for _, e := range value {
+			result = append(result, normalizeExportValue(e))
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for k, e := range value {
+			result[k] = normalizeExportValue(e)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+func prepareExportInstance(item map[string]interface{}) {
+	nics, _ := item["network_interface_set"].([]interface{})
+	for _, nic := range nics {
+		m, ok := nic.(map[string]interface{})
+		if !ok || m["network_interface_type"] != "primary" {
+			continue
+		}
+		var groups []interface{}
+		sets, _ := m["security_group_set"].([]interface{})
+		for _, set := range sets {
+			if sg, ok := set.(map[string]interface{}); ok && sg["security_group_id"] != "" {
+				groups = append(groups, sg["security_group_id"])
+			}
+		}
+		if len(groups) > 0 {
+			item["security_group_id"] = groups
+		}
+	}
+}
+
+// filterExportItems applies the filters; when a tag filter is given, resources without tags
+// (vpc, subnet, security group) are kept only if a kept resource references them
+func filterExportItems(client *KsyunClient, items []*exportItem, filters []exportFilter) error {
+	applyExportAttributeFilters(items, filters)
+	var hasTag bool
+	for _, f := range filters {
+		hasTag = hasTag || f.tag
+	}
+	if !hasTag {
+		return nil
+	}
+
+	tags, err := readExportTags(client, items)
+	if err != nil {
+		return err
+	}
+	applyExportTagFilters(items, filters, tags)
+	return nil
+}
+
+// applyExportAttributeFilters drops the resources whose attribute differs from the filter value,
+// the resources without the attribute are dropped as well
+func applyExportAttributeFilters(items []*exportItem, filters []exportFilter) {
+	for _, f := range filters {
+		if f.tag {
+			continue
+		}
+		for _, item := range items {
+			v, ok := item.data[f.key]
+			if !ok || fmt.Sprintf("%v", v) != f.value {
+				item.keep = false
+			}
+		}
+	}
+}
+
+func applyExportTagFilters(items []*exportItem, filters []exportFilter, tags map[string]map[string]string) {
+	for _, item := range items {
+		if item.tagless {
+			continue
+		}
+		for _, f := range filters {
+			if f.tag && tags[item.id][f.key] != f.value {
+				item.keep = false
+			}
+		}
+	}
+
+	candidates := make(map[string]*exportItem)
+	for _, item := range items {
+		if item.tagless && item.keep {
+			item.keep = false
+			candidates[item.id] = item
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, item := range items {
+			if !item.keep {
+				continue
+			}
+			for _, id := range collectExportStrings(item.data) {
+				if c, ok := candidates[id]; ok && !c.keep {
+					c.keep = true
+					changed = true
+				}
+			}
+		}
+	}
+}
+
+func readExportTags(client *KsyunClient, items []*exportItem) (map[string]map[string]string, error) {
+	tagService := TagService{client}
+	ids := make(map[string][]string)
+	for _, item := range items {
+		if !item.tagless && item.keep {
+			ids[item.typ.tagResourceType] = append(ids[item.typ.tagResourceType], item.id)
+		}
+	}
+	result := make(map[string]map[string]string)
+	for resourceType, resourceIds := range ids {
+		for start := 0; start < len(resourceIds); start += 50 {
+			end := start + 50
+			if end > len(resourceIds) {
+				end = len(resourceIds)
+			}
+			tags, err := tagService.ReadTagsByResourceIds(map[string]interface{}{
+				"ResourceType":  resourceType,
+				"ResourceUuids": strings.Join(resourceIds[start:end], ","),
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, tag := range tags {
+				m := tag.(map[string]interface{})
+				uuid, _ := m["ResourceUuid"].(string)
+				key, _ := m["TagKey"].(string)
+				value, _ := m["TagValue"].(string)
+				if result[uuid] == nil {
+					result[uuid] = make(map[string]string)
+				}
+				result[uuid][key] = value
+			}
+		}
+	}
+	return result, nil
+}
+
+func collectExportStrings(v interface{}) []string {
+	switch value := v.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var result []string
+		for _, e := range value {
+			result = append(result, collectExportStrings(e)...)
+		}
+		return result
+	case map[string]interface{}:
+		var result []string
+		for _, e := range value {
+			result = append(result, collectExportStrings(e)...)
+		}
+		return result
+	}
+	return nil
+}
+
+var exportNameInvalid = regexp.MustCompile("[^a-z0-9_]+")
+
+func assignExportNames(items []*exportItem) {
+	used := make(map[string]bool)
+	for _, item := range items {
+		var base string
+		for _, field := range item.typ.nameFields {
+			if s, ok := item.data[field].(string); ok && s != "" {
+				base = s
+				break
+			}
+		}
+		if base == "" {
+			base = item.id
+		}
+		base = strings.Trim(exportNameInvalid.ReplaceAllString(strings.ToLower(base), "_"), "_")
+		if base == "" || (base[0] >= '0' && base[0] <= '9') {
+			base = strings.TrimPrefix(item.typ.resourceType, "ksyun_") + "_" + base
+		}
+		name := base
+		for i := 2; used[item.typ.resourceType+"."+name]; i++ {
+			name = base + "_" + strconv.Itoa(i)
+		}
+		used[item.typ.resourceType+"."+name] = true
+		item.name = strings.TrimSuffix(name, "_")
+	}
+}
+
+func writeExportFiles(dir string, region string, items []*exportItem) error {
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	resources := Provider().(*schema.Provider).ResourcesMap
+	addresses := make(map[string]string)
+	for _, item := range items {
+		addresses[item.id] = item.address()
+	}
+
+	files := make(map[string]*strings.Builder)
+	var order []string
+	for _, item := range items {
+		b, ok := files[item.typ.service]
+		if !ok {
+			b = &strings.Builder{}
+			files[item.typ.service] = b
+			order = append(order, item.typ.service)
+		}
+		b.WriteString(renderExportItem(resources[item.typ.resourceType], item, addresses))
+	}
+
+	// import blocks need terraform >= 1.5
+	providerFile := filepath.Join(dir, "provider.tf")
+	if _, err := os.Stat(providerFile); os.IsNotExist(err) {
+		provider := fmt.Sprintf("terraform {\n  required_version = \">= 1.5.0\"\n  required_providers {\n    ksyun = {\n      source = \"kingsoftcloud/ksyun\"\n    }\n  }\n}\n\nprovider \"ksyun\" {\n  region = %q\n}\n", region)
+		if err = os.WriteFile(providerFile, []byte(provider), 0644); err != nil {
+			return err
+		}
+		fmt.Printf("write %s\n", providerFile)
+	}
+
+	for _, service := range order {
+		filename := filepath.Join(dir, service+".tf")
+		if err := os.WriteFile(filename, hclwrite.Format([]byte(files[service].String())), 0644); err != nil {
+			return err
+		}
+		fmt.Printf("write %s\n", filename)
+	}
+	fmt.Printf("exported %d resources\n", len(items))
+	return nil
+}
+
+func renderExportItem(r *schema.Resource, item *exportItem, addresses map[string]string) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("import {\n  to = %s\n  id = %q\n}\n\n", item.address(), item.id))
+	b.WriteString(fmt.Sprintf("resource %q %q {\n", item.typ.resourceType, item.name))
+	renderExportBody(&b, 1, r.Schema, item.data, addresses, item.id)
+	b.WriteString("}\n\n")
+	return b.String()
+}
+
+func renderExportBody(b *strings.Builder, depth int, m map[string]*schema.Schema, data map[string]interface{}, addresses map[string]string, selfId string) {
+	indent := strings.Repeat("  ", depth)
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var blocks []string
+	for _, k := range keys {
+		s := m[k]
+		if !s.Optional && !s.Required {
+			continue
+		}
+		value, ok := data[k]
+		if !ok || isExportZero(value) {
+			if s.Required {
+				b.WriteString(fmt.Sprintf("%s# %s is required but could not be read, please set it\n", indent, k))
+			}
+			continue
+		}
+		if elem, ok := s.Elem.(*schema.Resource); ok && (s.Type == schema.TypeList || s.Type == schema.TypeSet) {
+			list, _ := value.([]interface{})
+			var nested strings.Builder
+			for _, e := range list {
+				em, ok := e.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				nested.WriteString(fmt.Sprintf("%s%s {\n", indent, k))
+				renderExportBody(&nested, depth+1, elem.Schema, em, addresses, selfId)
+				nested.WriteString(fmt.Sprintf("%s}\n", indent))
+			}
+			blocks = append(blocks, nested.String())
+			continue
+		}
+		rendered, ok := renderExportValue(s, value, addresses, selfId)
+		if ok {
+			b.WriteString(fmt.Sprintf("%s%s = %s\n", indent, k, rendered))
+		}
+	}
+	for _, block := range blocks {
+		b.WriteString(block)
+	}
+}
+
+func renderExportValue(s *schema.Schema, value interface{}, addresses map[string]string, selfId string) (string, bool) {
+	switch s.Type {
+	case schema.TypeList, schema.TypeSet:
+		list, ok := value.([]interface{})
+		if !ok {
+			return "", false
+		}
+		elem, ok := s.Elem.(*schema.Schema)
+		if !ok {
+			elem = &schema.Schema{Type: schema.TypeString}
+		}
+		var parts []string
+		for _, e := range list {
+			if p, ok := renderExportValue(elem, e, addresses, selfId); ok {
+				parts = append(parts, p)
+			}
+		}
+		return "[" + strings.Join(parts, ", ") + "]", true
+	case schema.TypeMap:
+		mm, ok := value.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		var keys []string
+		for k := range mm {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var parts []string
+		for _, k := range keys {
+			parts = append(parts, fmt.Sprintf("%s = %s", hclwrite.TokensForValue(cty.StringVal(k)).Bytes(), hclwrite.TokensForValue(cty.StringVal(fmt.Sprintf("%v", mm[k]))).Bytes()))
+		}
+		return "{\n" + strings.Join(parts, "\n") + "\n}", true
+	case schema.TypeString:
+		str := fmt.Sprintf("%v", value)
+		if address, ok := addresses[str]; ok && str != selfId {
+			return address + ".id", true
+		}
+		return string(hclwrite.TokensForValue(cty.StringVal(str)).Bytes()), true
+	case schema.TypeBool:
+		switch v := value.(type) {
+		case bool:
+			return strconv.FormatBool(v), true
+		case string:
+			if bv, err := strconv.ParseBool(v); err == nil {
+				return strconv.FormatBool(bv), true
+			}
+		}
+		return "", false
+	case schema.TypeInt, schema.TypeFloat:
+		switch v := value.(type) {
+		case int:
+			return strconv.Itoa(v), true
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), true
+		case string:
+			if _, err := strconv.ParseFloat(v, 64); err == nil {
+				return v, true
+			}
+		}
+		return "", false
+	}
+	return "", false
+}
+
+func isExportZero(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+	return false
+}
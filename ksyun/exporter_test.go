@@ -0,0 +1,129 @@
+package ksyun
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testExportType(resourceType string) *exportType {
+	for i := range exportTypes {
+		if exportTypes[i].resourceType == resourceType {
+			return &exportTypes[i]
+		}
+	}
+	return nil
+}
+
+func testExportItems() []*exportItem {
+	return []*exportItem{
+		{
+			typ: testExportType("ksyun_vpc"), id: "vpc-1", keep: true, tagless: true,
+			data: map[string]interface{}{"vpc_id": "vpc-1", "vpc_name": "Prod VPC", "cidr_block": "10.0.0.0/16"},
+		},
+		{
+			typ: testExportType("ksyun_vpc"), id: "vpc-2", keep: true, tagless: true,
+			data: map[string]interface{}{"vpc_id": "vpc-2", "vpc_name": "other", "cidr_block": "10.1.0.0/16"},
+		},
+		{
+			typ: testExportType("ksyun_subnet"), id: "subnet-1", keep: true, tagless: true,
+			data: map[string]interface{}{"subnet_id": "subnet-1", "subnet_name": "web", "vpc_id": "vpc-1", "cidr_block": "10.0.1.0/24", "subnet_type": "Normal"},
+		},
+		{
+			typ: testExportType("ksyun_instance"), id: "kec-1", keep: true,
+			data: map[string]interface{}{
+				"instance_id": "kec-1", "instance_name": "web-1", "subnet_id": "subnet-1", "project_id": 0,
+				"key_id":      []interface{}{"key-1"},
+				"system_disk": []interface{}{map[string]interface{}{"disk_type": "SSD3.0", "disk_size": 40}},
+			},
+		},
+		{
+			typ: testExportType("ksyun_instance"), id: "kec-2", keep: true,
+			data: map[string]interface{}{"instance_id": "kec-2", "instance_name": "web-1", "subnet_id": "subnet-9"},
+		},
+	}
+}
+
+func TestExportTagFilterKeepsReferences(t *testing.T) {
+	items := testExportItems()
+	filters, err := parseExportFilters([]string{"tag:team=x"})
+	assert.NoError(t, err)
+
+	applyExportTagFilters(items, filters, map[string]map[string]string{
+		"kec-1": {"team": "x"},
+		"kec-2": {"team": "y"},
+	})
+
+	var kept []string
+	for _, item := range items {
+		if item.keep {
+			kept = append(kept, item.id)
+		}
+	}
+	assert.Equal(t, []string{"vpc-1", "subnet-1", "kec-1"}, kept)
+}
+
+func TestExportRender(t *testing.T) {
+	items := testExportItems()
+	assignExportNames(items)
+	assert.Equal(t, "prod_vpc", items[0].name)
+	assert.Equal(t, "web_1", items[3].name)
+	assert.Equal(t, "web_1_2", items[4].name)
+
+	addresses := make(map[string]string)
+	for _, item := range items {
+		addresses[item.id] = item.address()
+	}
+	resources := Provider().(*schema.Provider).ResourcesMap
+
+	subnet := renderExportItem(resources["ksyun_subnet"], items[2], addresses)
+	assert.Contains(t, subnet, "import {\n  to = ksyun_subnet.web\n  id = \"subnet-1\"\n}")
+	assert.Contains(t, subnet, "vpc_id = ksyun_vpc.prod_vpc.id")
+
+	instance := renderExportItem(resources["ksyun_instance"], items[3], addresses)
+	assert.Contains(t, instance, "subnet_id = ksyun_subnet.web.id")
+	assert.Contains(t, instance, "key_id = [\"key-1\"]")
+	assert.Contains(t, instance, "system_disk {\n    disk_size = 40\n    disk_type = \"SSD3.0\"\n  }")
+
+	// subnet-9 is not exported, the id stays a literal
+	other := renderExportItem(resources["ksyun_instance"], items[4], addresses)
+	assert.Contains(t, other, "subnet_id = \"subnet-9\"")
+
+	// required arguments that could not be read are left for the user
+	vpc := renderExportItem(resources["ksyun_security_group"], &exportItem{
+		typ: testExportType("ksyun_security_group"), id: "sg-1", name: "sg",
+		data: map[string]interface{}{"security_group_name": "sg"},
+	}, addresses)
+	assert.True(t, strings.Contains(vpc, "# vpc_id is required but could not be read, please set it"))
+}
+
+func TestParseExportFilters(t *testing.T) {
+	filters, err := parseExportFilters([]string{"tag:team=x", "vpc_id=vpc-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []exportFilter{{tag: true, key: "team", value: "x"}, {key: "vpc_id", value: "vpc-1"}}, filters)
+
+	_, err = parseExportFilters([]string{"team"})
+	assert.Error(t, err)
+
+	filters, err = parseExportFilters([]string{""})
+	assert.NoError(t, err)
+	assert.Empty(t, filters)
+}
+
+func TestExportAttributeFilterDropsMissing(t *testing.T) {
+	items := testExportItems()
+	filters, err := parseExportFilters([]string{"subnet_id=subnet-1"})
+	assert.NoError(t, err)
+
+	applyExportAttributeFilters(items, filters)
+
+	var kept []string
+	for _, item := range items {
+		if item.keep {
+			kept = append(kept, item.id)
+		}
+	}
+	assert.Equal(t, []string{"subnet-1", "kec-1"}, kept)
+}
@@ -0,0 +1,159 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// readOnlyActionPrefixes are the prefixes of the actions which do not change anything, their responses can be reused
+var readOnlyActionPrefixes = []string{"Describe", "List", "Get"}
+
+// DefaultDescribeCacheTTL is how long a response is reused. It is short so that the state waiters, which repeat the same
+// describe call until a resource changes its state out of band, see the change after a few polls.
+const DefaultDescribeCacheTTL = 5 * time.Second
+
+// DescribeCache memoizes the responses of the read-only actions of a session, it is keyed on the service endpoint,
+// the action and the request parameters. A response expires after the ttl, and any other action invalidates the
+// responses of its service. It is safe for concurrent use.
+type DescribeCache struct {
+	sync.Mutex
+	services map[string]*describeCacheService
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+type describeCacheService struct {
+	// generation is bumped by every mutating call, a response read before the last mutation is not stored
+	generation uint64
+	responses  map[string]*describeCacheResponse
+}
+
+type describeCacheResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+	expires    time.Time
+}
+
+func NewDescribeCache(ttl time.Duration) *DescribeCache {
+	return &DescribeCache{
+		services: make(map[string]*describeCacheService),
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+// Register adds the cache handlers to the handlers of a session, it must be called before the service clients are built.
+func (c *DescribeCache) Register(handlers *request.Handlers) {
+	handlers.Build.PushFrontNamed(request.NamedHandler{Name: "ksyun.DescribeCacheLookup", Fn: c.lookup})
+	handlers.Complete.PushBackNamed(request.NamedHandler{Name: "ksyun.DescribeCacheInvalidate", Fn: c.invalidate})
+}
+
+func isReadOnlyAction(action string) bool {
+	for _, prefix := range readOnlyActionPrefixes {
+		if strings.HasPrefix(action, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func describeCacheServiceKey(r *request.Request) string {
+	return r.ClientInfo.ServiceName + "|" + r.ClientInfo.Endpoint
+}
+
+func describeCacheRequestKey(r *request.Request) (string, bool) {
+	// the maps are marshaled with sorted keys, which normalizes the parameters
+	params, err := json.Marshal(r.Params)
+	if err != nil {
+		return "", false
+	}
+	return r.ClientInfo.APIVersion + "|" + r.HTTPRequest.Method + "|" + r.Operation.Name + "|" + string(params), true
+}
+
+func (c *DescribeCache) service(key string) *describeCacheService {
+	s, ok := c.services[key]
+	if !ok {
+		s = &describeCacheService{responses: make(map[string]*describeCacheResponse)}
+		c.services[key] = s
+	}
+	return s
+}
+
+// lookup serves a read-only request from the cache, or arranges for its response to be stored once it is received
+func (c *DescribeCache) lookup(r *request.Request) {
+	if r.Operation == nil || !isReadOnlyAction(r.Operation.Name) {
+		return
+	}
+	requestKey, ok := describeCacheRequestKey(r)
+	if !ok {
+		return
+	}
+	serviceKey := describeCacheServiceKey(r)
+
+	c.Lock()
+	s := c.service(serviceKey)
+	cached, hit := s.responses[requestKey]
+	if hit && !c.now().Before(cached.expires) {
+		delete(s.responses, requestKey)
+		hit = false
+	}
+	generation := s.generation
+	c.Unlock()
+
+	if hit {
+		r.Handlers.Send.Clear()
+		r.Handlers.Send.PushBack(func(r *request.Request) {
+			r.HTTPResponse = &http.Response{
+				StatusCode: cached.statusCode,
+				Header:     cached.header.Clone(),
+				Body:       ioutil.NopCloser(bytes.NewReader(cached.body)),
+			}
+		})
+		return
+	}
+
+	r.Handlers.Send.PushBack(func(r *request.Request) {
+		if r.Error != nil || r.HTTPResponse == nil || r.HTTPResponse.StatusCode < 200 || r.HTTPResponse.StatusCode >= 300 {
+			return
+		}
+		body, err := ioutil.ReadAll(r.HTTPResponse.Body)
+		r.HTTPResponse.Body.Close()
+		r.HTTPResponse.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return
+		}
+
+		c.Lock()
+		defer c.Unlock()
+		s := c.service(serviceKey)
+		if s.generation != generation {
+			return
+		}
+		s.responses[requestKey] = &describeCacheResponse{
+			statusCode: r.HTTPResponse.StatusCode,
+			header:     r.HTTPResponse.Header.Clone(),
+			body:       body,
+			expires:    c.now().Add(c.ttl),
+		}
+	})
+}
+
+// invalidate drops the responses of a service once one of its mutating actions is done, whatever its result
+func (c *DescribeCache) invalidate(r *request.Request) {
+	if r.Operation == nil || isReadOnlyAction(r.Operation.Name) {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	s := c.service(describeCacheServiceKey(r))
+	s.generation++
+	s.responses = make(map[string]*describeCacheResponse)
+}
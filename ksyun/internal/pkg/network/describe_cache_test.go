@@ -0,0 +1,151 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/corehandlers"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+// testDescribeCacheCaller returns a function sending the action to the server through the handlers of the cache
+func testDescribeCacheCaller(t *testing.T, server *httptest.Server, cache *DescribeCache) func(service, action string, params map[string]interface{}) map[string]interface{} {
+	var handlers request.Handlers
+	handlers.Send.PushBackNamed(corehandlers.SendHandler)
+	handlers.ValidateResponse.PushBackNamed(corehandlers.ValidateResponseHandler)
+	handlers.Unmarshal.PushBack(func(r *request.Request) {
+		defer r.HTTPResponse.Body.Close()
+		body, err := ioutil.ReadAll(r.HTTPResponse.Body)
+		if err == nil {
+			err = json.Unmarshal(body, r.Data)
+		}
+		if err != nil {
+			r.Error = err
+		}
+	})
+	cache.Register(&handlers)
+
+	return func(service, action string, params map[string]interface{}) map[string]interface{} {
+		var data map[string]interface{}
+		r := request.New(aws.Config{HTTPClient: server.Client()}, metadata.ClientInfo{ServiceName: service, Endpoint: server.URL}, handlers, nil,
+			&request.Operation{Name: action, HTTPMethod: "GET", HTTPPath: "/"}, &params, &data)
+		if err := r.Send(); err != nil {
+			t.Error(err)
+		}
+		return data
+	}
+}
+
+func TestDescribeCache(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		fmt.Fprintf(w, `{"Call":%d}`, n)
+	}))
+	defer server.Close()
+
+	send := testDescribeCacheCaller(t, server, NewDescribeCache(time.Minute))
+	call := func(service, action string, params map[string]interface{}) int {
+		n, _ := send(service, action, params)["Call"].(float64)
+		return int(n)
+	}
+
+	first := call("vpc", "DescribeVpcs", map[string]interface{}{"VpcId.1": "vpc-1", "MaxResults": 100})
+	if got := call("vpc", "DescribeVpcs", map[string]interface{}{"MaxResults": 100, "VpcId.1": "vpc-1"}); got != first {
+		t.Errorf("identical describe call not served from the cache, got call %d, want %d", got, first)
+	}
+	if got := call("vpc", "DescribeVpcs", map[string]interface{}{"VpcId.1": "vpc-2"}); got == first {
+		t.Errorf("describe call with other parameters served from the cache")
+	}
+	eips := call("eip", "GetLines", nil)
+
+	// a mutating call drops the responses of its service only
+	call("vpc", "CreateVpc", map[string]interface{}{"CidrBlock": "10.0.0.0/16"})
+	if got := call("vpc", "DescribeVpcs", map[string]interface{}{"VpcId.1": "vpc-1", "MaxResults": 100}); got == first {
+		t.Errorf("describe call served from the cache after a mutating call")
+	}
+	if got := call("eip", "GetLines", nil); got != eips {
+		t.Errorf("mutating call invalidated another service, got call %d, want %d", got, eips)
+	}
+
+	before := atomic.LoadInt32(&calls)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			call("eip", "GetLines", nil)
+		}()
+	}
+	wg.Wait()
+	if atomic.LoadInt32(&calls) != before {
+		t.Errorf("cached call sent again under concurrency")
+	}
+}
+
+func TestDescribeCacheExpires(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"Call":%d}`, atomic.AddInt32(&calls, 1))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	cache := NewDescribeCache(5 * time.Second)
+	cache.now = func() time.Time {
+		return now
+	}
+	call := testDescribeCacheCaller(t, server, cache)
+
+	first := call("kec", "DescribeInstances", nil)["Call"]
+	now = now.Add(4 * time.Second)
+	if got := call("kec", "DescribeInstances", nil)["Call"]; got != first {
+		t.Errorf("describe call within the ttl not served from the cache, got call %v, want %v", got, first)
+	}
+	now = now.Add(time.Second)
+	if got := call("kec", "DescribeInstances", nil)["Call"]; got == first {
+		t.Errorf("describe call served from the cache after the ttl")
+	}
+}
+
+// TestDescribeCacheStateWaiter polls the state of an instance the way the state waiters do while the instance changes
+// its state without any call of the provider, the waiter must see the change
+func TestDescribeCacheStateWaiter(t *testing.T) {
+	var state atomic.Value
+	state.Store("pending")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"State":%q}`, state.Load())
+	}))
+	defer server.Close()
+
+	call := testDescribeCacheCaller(t, server, NewDescribeCache(100*time.Millisecond))
+	// the instance becomes active right after the first poll
+	if got := call("kec", "DescribeInstances", map[string]interface{}{"InstanceId.1": "kec-1"})["State"]; got != "pending" {
+		t.Fatalf("got state %v, want pending", got)
+	}
+	state.Store("active")
+
+	_, err := (&resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"active"},
+		Refresh: func() (interface{}, string, error) {
+			data := call("kec", "DescribeInstances", map[string]interface{}{"InstanceId.1": "kec-1"})
+			return data, data["State"].(string), nil
+		},
+		Timeout:      5 * time.Second,
+		PollInterval: 50 * time.Millisecond,
+	}).WaitForState()
+	if err != nil {
+		t.Errorf("the state waiter does not see the state change: %s", err)
+	}
+}
@@ -126,3 +126,65 @@ type Instance struct {
 	VncSupport            bool                  `json:"VncSupport" mapstructure:"VncSupport"`
 	Platform              string                `json:"Platform" mapstructure:"Platform"`
 }
+
+type DescribeInstanceTypeConfigsResponse struct {
+	RequestId             string               `json:"RequestId" mapstructure:"RequestId"`
+	InstanceTypeConfigSet []InstanceTypeConfig `json:"InstanceTypeConfigSet" mapstructure:"InstanceTypeConfigSet"`
+}
+type AvailabilityZone struct {
+	AzCode    string `json:"AzCode" mapstructure:"AzCode"`
+	IsSellOut bool   `json:"IsSellOut" mapstructure:"IsSellOut"`
+}
+type NetworkInterfaceQuota struct {
+	NetworkInterfaceCount int `json:"NetworkInterfaceCount" mapstructure:"NetworkInterfaceCount"`
+}
+type PrivateIpQuota struct {
+	PrivateIpCount int `json:"PrivateIpCount" mapstructure:"PrivateIpCount"`
+}
+type SystemDiskQuota struct {
+	SystemDiskType string `json:"SystemDiskType" mapstructure:"SystemDiskType"`
+}
+type DataDiskQuota struct {
+	DataDiskType        string             `json:"DataDiskType" mapstructure:"DataDiskType"`
+	DataDiskMinSize     float64            `json:"DataDiskMinSize" mapstructure:"DataDiskMinSize"`
+	DataDiskMaxSize     float64            `json:"DataDiskMaxsize" mapstructure:"DataDiskMaxsize"`
+	DataDiskCount       int                `json:"DataDiskCount" mapstructure:"DataDiskCount"`
+	AvailabilityZoneSet []AvailabilityZone `json:"AvailabilityZoneSet" mapstructure:"AvailabilityZoneSet"`
+}
+type NetworkPerformance struct {
+	// Bandwidth is the intranet bandwidth in Gbps
+	Bandwidth float64 `json:"Bandwidth" mapstructure:"Bandwidth"`
+	// Pps is the packet forwarding rate in ten thousand packets per second
+	Pps int `json:"Pps" mapstructure:"Pps"`
+}
+
+// InstanceTypeConfig is an item of DescribeInstanceTypeConfigs, the vCPU, memory, GPU and vGPU specifications are
+// the ones of InstanceConfigure, use Configure to read them
+type InstanceTypeConfig struct {
+	InstanceConfigure     `mapstructure:",squash"`
+	InstanceType          string                `json:"InstanceType" mapstructure:"InstanceType"`
+	InstanceFamily        string                `json:"InstanceFamily" mapstructure:"InstanceFamily"`
+	InstanceFamilyName    string                `json:"InstanceFamilyName" mapstructure:"InstanceFamilyName"`
+	CPU                   int                   `json:"CPU" mapstructure:"CPU"`
+	GPUSpec               string                `json:"GPUspec" mapstructure:"GPUspec"`
+	Memory                int                   `json:"Memory" mapstructure:"Memory"`
+	NetworkPerformance    NetworkPerformance    `json:"NetworkPerformance" mapstructure:"NetworkPerformance"`
+	NetworkInterfaceQuota NetworkInterfaceQuota `json:"NetworkInterfaceQuota" mapstructure:"NetworkInterfaceQuota"`
+	PrivateIpQuota        PrivateIpQuota        `json:"PrivateIpQuota" mapstructure:"PrivateIpQuota"`
+	AvailabilityZoneSet   []AvailabilityZone    `json:"AvailabilityZoneSet" mapstructure:"AvailabilityZoneSet"`
+	SystemDiskQuotaSet    []SystemDiskQuota     `json:"SystemDiskQuotaSet" mapstructure:"SystemDiskQuotaSet"`
+	DataDiskQuotaSet      []DataDiskQuota       `json:"DataDiskQuotaSet" mapstructure:"DataDiskQuotaSet"`
+}
+
+// Configure returns the InstanceConfigure of the instance type, DescribeInstanceTypeConfigs reports the vCPUs and
+// the memory as CPU and Memory
+func (c InstanceTypeConfig) Configure() InstanceConfigure {
+	configure := c.InstanceConfigure
+	if configure.VCPU == 0 {
+		configure.VCPU = c.CPU
+	}
+	if configure.MemoryGb == 0 {
+		configure.MemoryGb = c.Memory
+	}
+	return configure
+}
@@ -37,6 +37,8 @@ Use the navigation to the left to read about the available resources.
 Provider Data Sources
 
 	ksyun_availability_zones
+	ksyun_regions
+	ksyun_quotas
 
 EIP
 
@@ -48,18 +50,25 @@ EIP
 	Resource
 		ksyun_eip
 		ksyun_eip_associate
+		ksyun_ipv6_public_ip
 		ksyun_bws
 		ksyun_bws_associate
 
 VPC
 
 	Data Source
+		ksyun_vpc
 		ksyun_vpcs
+		ksyun_vpc_free_cidrs
+		ksyun_vpc_peering_connections
+		ksyun_havips
 		ksyun_nats
 		ksyun_network_acls
+		ksyun_subnet
 		ksyun_subnets
 		ksyun_network_interfaces
 		ksyun_routes
+		ksyun_security_group
 		ksyun_security_groups
 		ksyun_subnet_allocated_ip_addresses
 		ksyun_subnet_available_addresses
@@ -67,6 +76,9 @@ VPC
 		ksyun_private_dns_records
 		ksyun_private_dns_zones
 		ksyun_direct_connects
+		ksyun_direct_connect_gateways
+		ksyun_direct_connect_interfaces
+		ksyun_direct_connect_gateway_routes
 
 	Resource
 		ksyun_vpc
@@ -75,13 +87,23 @@ VPC
 		ksyun_nat_associate
 		ksyun_nat_instance_bandwidth_limit
 		ksyun_dnat
+		ksyun_snat_entry
+		ksyun_nat_ip
+		ksyun_dnat_rules
 		ksyun_network_acl
 		ksyun_network_acl_entry
 		ksyun_network_acl_associate
+		ksyun_network_acl_rules
 		ksyun_route
+		ksyun_vpc_peering_connection
+		ksyun_vpc_peering_connection_accepter
+		ksyun_vpc_flow_log
+		ksyun_havip
+		ksyun_havip_attachment
 		ksyun_security_group
 		ksyun_security_group_entry
 		ksyun_security_group_entry_lite
+		ksyun_security_group_rules
 		ksyun_kec_network_interface
 		ksyun_private_dns_zone
 		ksyun_private_dns_record
@@ -99,6 +121,7 @@ VPN
 		ksyun_vpn_customer_gateways
 		ksyun_vpn_tunnels
 		ksyun_vpn_gateway_routes
+		ksyun_vpn_tunnel_bgp_routes
 
 	Resource
 		ksyun_vpn_gateway
@@ -115,6 +138,7 @@ SLB
 		ksyun_lb_host_headers
 		ksyun_lb_listener_servers
 		ksyun_lb_rules
+		ksyun_lb
 		ksyun_lbs
 		ksyun_listeners
 		ksyun_lb_register_backend_servers
@@ -154,9 +178,12 @@ CEN
 
 	Data Source
 		ksyun_cens
+		ksyun_cen_routes
 
 	Resource
 		ksyun_cen
+		ksyun_cen_instance_attachment
+		ksyun_cen_bandwidth_package
 
 SSH key
 
@@ -169,8 +196,11 @@ SSH key
 Instance(KEC)
 
 	Data Source
+		ksyun_image
 		ksyun_images
+		ksyun_instance
 		ksyun_instances
+		ksyun_instance_types
 		ksyun_local_volumes
 		ksyun_local_snapshots
 		ksyun_auto_snapshot_policy
@@ -348,6 +378,9 @@ IAM
 		ksyun_iam_users
 		ksyun_iam_roles
 		ksyun_iam_groups
+		ksyun_caller_identity
+		ksyun_iam_policy_document
+		ksyun_iam_projects
 
 	Resource
 		ksyun_iam_user
@@ -355,6 +388,8 @@ IAM
 		ksyun_iam_group
 		ksyun_iam_policy
 		ksyun_iam_relation_policy
+		ksyun_iam_project
+		ksyun_iam_project_member
 KPFS
 
 	Data Source
@@ -410,7 +445,7 @@ import (
 
 // Provider returns a terraform.ResourceProvider.
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"access_key": {
 				Type:        schema.TypeString,
@@ -491,6 +526,25 @@ func Provider() terraform.ResourceProvider {
 					return
 				},
 			},
+			"describe_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to reuse the responses of the identical read-only calls within one run for 5 seconds, the responses of a service are dropped once a call changing that service is made.",
+			},
+			"quota_limits": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeInt},
+				ValidateFunc: validateQuotaLimits,
+				Description:  "The limits of the quotas of the account, keyed by `eip`, `vpc`, `instance` (per availability zone), `slb` and `security_group_rule` (per security group). They are reported by `ksyun_quotas` and enforced by `quota_check`. The API does not report the quotas of the account, so only the services with a limit here are checked.",
+			},
+			"quota_check": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to fail the plan when creating `ksyun_eip`, `ksyun_instance` or `ksyun_lb` resources exceeds the `quota_limits`. Each planned resource is counted, including the instances of a `count`.",
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"ksyun_albs":                     dataSourceKsyunAlbs(),
@@ -498,8 +552,9 @@ func Provider() terraform.ResourceProvider {
 			"ksyun_alb_rule_groups":          dataSourceKsyunAlbRuleGroups(),
 			"ksyun_alb_listener_cert_groups": dataSourceKsyunAlbListenerCertGroups(),
 			"ksyun_lines":                    dataSourceKsyunLines(),
-			"ksyun_eips":                     dataSourceKsyunEips(),
+			"ksyun_eips":                     regionalDataSource(dataSourceKsyunEips()),
 			"ksyun_slbs":                     dataSourceKsyunLbs(),
+			"ksyun_lb":                       dataSourceKsyunLb(),
 			"ksyun_lbs":                      dataSourceKsyunLbs(),
 			"ksyun_listeners":                dataSourceKsyunListeners(),
 			"ksyun_health_checks":            dataSourceKsyunHealthChecks(),
@@ -507,22 +562,33 @@ func Provider() terraform.ResourceProvider {
 			"ksyun_listener_servers":                 dataSourceKsyunLbListenerServers(),
 			"ksyun_lb_listener_servers":              dataSourceKsyunLbListenerServers(),
 			"ksyun_lb_acls":                          dataSourceKsyunSlbAcls(),
-			"ksyun_availability_zones":               dataSourceKsyunAvailabilityZones(),
-			"ksyun_network_interfaces":               dataSourceKsyunNetworkInterfaces(),
-			"ksyun_network_acls":                     dataSourceKsyunNetworkAcls(),
-			"ksyun_vpcs":                             dataSourceKsyunVpcs(),
-			"ksyun_subnets":                          dataSourceKsyunSubnets(),
+			"ksyun_availability_zones":               regionalDataSource(dataSourceKsyunAvailabilityZones()),
+			"ksyun_network_interfaces":               regionalDataSource(dataSourceKsyunNetworkInterfaces()),
+			"ksyun_network_acls":                     regionalDataSource(dataSourceKsyunNetworkAcls()),
+			"ksyun_vpc":                              regionalDataSource(dataSourceKsyunVpc()),
+			"ksyun_vpcs":                             regionalDataSource(dataSourceKsyunVpcs()),
+			"ksyun_vpc_free_cidrs":                   regionalDataSource(dataSourceKsyunVpcFreeCidrs()),
+			"ksyun_vpc_peering_connections":          regionalDataSource(dataSourceKsyunVpcPeeringConnections()),
+			"ksyun_havips":                           regionalDataSource(dataSourceKsyunHaVips()),
+			"ksyun_subnet":                           regionalDataSource(dataSourceKsyunSubnet()),
+			"ksyun_subnets":                          regionalDataSource(dataSourceKsyunSubnets()),
 			"ksyun_subnet_available_addresses":       dataSourceKsyunSubnetAvailableAddresses(),
 			"ksyun_subnet_allocated_ip_addresses":    dataSourceKsyunSubnetAllocatedIpAddresses(),
-			"ksyun_security_groups":                  dataSourceKsyunSecurityGroups(),
-			"ksyun_instances":                        dataSourceKsyunInstances(),
+			"ksyun_security_group":                   regionalDataSource(dataSourceKsyunSecurityGroup()),
+			"ksyun_security_groups":                  regionalDataSource(dataSourceKsyunSecurityGroups()),
+			"ksyun_instance":                         regionalDataSource(dataSourceKsyunInstance()),
+			"ksyun_instances":                        regionalDataSource(dataSourceKsyunInstances()),
 			"ksyun_local_volumes":                    dataSourceKsyunLocalVolumes(),
 			"ksyun_local_snapshots":                  dataSourceKsyunLocalSnapshots(),
-			"ksyun_images":                           dataSourceKsyunImages(),
+			"ksyun_image":                            regionalDataSource(dataSourceKsyunImage()),
+			"ksyun_images":                           regionalDataSource(dataSourceKsyunImages()),
+			"ksyun_instance_types":                   regionalDataSource(dataSourceKsyunInstanceTypes()),
+			"ksyun_regions":                          dataSourceKsyunRegions(),
+			"ksyun_quotas":                           regionalDataSource(dataSourceKsyunQuotas()),
 			"ksyun_sqlservers":                       dataSourceKsyunSqlServer(),
 			"ksyun_krds":                             dataSourceKsyunKrds(),
 			"ksyun_krds_security_groups":             dataSourceKsyunKrdsSecurityGroup(),
-			"ksyun_ks3_buckets":                      dataSourceKsyunKs3Buckets(),
+			"ksyun_ks3_buckets":                      regionalDataSource(dataSourceKsyunKs3Buckets()),
 			"ksyun_certificates":                     dataSourceKsyunCertificates(),
 			"ksyun_ssh_keys":                         dataSourceKsyunSSHKeys(),
 			"ksyun_redis_instances":                  dataSourceRedisInstances(),
@@ -534,8 +600,8 @@ func Provider() terraform.ResourceProvider {
 			"ksyun_lb_rules":                         dataSourceKsyunSlbRules(),
 			"ksyun_lb_backend_server_groups":         dataSourceKsyunBackendServerGroups(),
 			"ksyun_lb_register_backend_servers":      dataSourceKsyunRegisterBackendServers(),
-			"ksyun_routes":                           dataSourceKsyunRoutes(),
-			"ksyun_nats":                             dataSourceKsyunNats(),
+			"ksyun_routes":                           regionalDataSource(dataSourceKsyunRoutes()),
+			"ksyun_nats":                             regionalDataSource(dataSourceKsyunNats()),
 			"ksyun_scaling_configurations":           dataSourceKsyunScalingConfigurations(),
 			"ksyun_scaling_groups":                   dataSourceKsyunScalingGroups(),
 			"ksyun_scaling_activities":               dataSourceKsyunScalingActivities(),
@@ -560,9 +626,10 @@ func Provider() terraform.ResourceProvider {
 			"ksyun_auto_snapshot_volume_association": dataSourceKsyunAutoSnapshotVolumeAssociation(),
 			"ksyun_knads":                            dataSourceKsyunKnads(),
 			"ksyun_perknads":                         dataSourceKsyunPerKnads(),
-			"ksyun_dnats":                            dataSourceKsyunDnats(),
+			"ksyun_dnats":                            regionalDataSource(dataSourceKsyunDnats()),
 			"ksyun_alb_backend_server_groups":        dataSourceKsyunAlbBackendServerGroups(),
 			"ksyun_vpn_gateway_routes":               dataSourceKsyunVpnGatewayRoutes(),
+			"ksyun_vpn_tunnel_bgp_routes":            dataSourceKsyunVpnTunnelBgpRoutes(),
 			"ksyun_kmr_clusters":                     dataSourceKsyunKmrClusters(),
 
 			// private_dns
@@ -582,9 +649,12 @@ func Provider() terraform.ResourceProvider {
 			"ksyun_kfw_service_groups": dataSourceKsyunKfwServiceGroups(),
 
 			// iam
-			"ksyun_iam_users":  dataSourceKsyunIamUsers(),
-			"ksyun_iam_roles":  dataSourceKsyunIamRoles(),
-			"ksyun_iam_groups": dataSourceKsyunIamGroups(),
+			"ksyun_iam_users":           dataSourceKsyunIamUsers(),
+			"ksyun_iam_roles":           dataSourceKsyunIamRoles(),
+			"ksyun_iam_groups":          dataSourceKsyunIamGroups(),
+			"ksyun_caller_identity":     dataSourceKsyunCallerIdentity(),
+			"ksyun_iam_policy_document": dataSourceKsyunIamPolicyDocument(),
+			"ksyun_iam_projects":        dataSourceKsyunIamProjects(),
 
 			//kpfs
 			"ksyun_kpfs_file_systems":   dataSourceKsyunKpfsFileSystems(),
@@ -593,20 +663,25 @@ func Provider() terraform.ResourceProvider {
 			// klog
 			"ksyun_klog_projects": dataSourceKsyunKlogProjects(),
 			// direct connect
-			"ksyun_direct_connects": dataSourceKsyunDirectConnects(),
+			"ksyun_direct_connects":               dataSourceKsyunDirectConnects(),
+			"ksyun_direct_connect_gateways":       dataSourceKsyunDirectConnectGateways(),
+			"ksyun_direct_connect_interfaces":     dataSourceKsyunDirectConnectInterfaces(),
+			"ksyun_direct_connect_gateway_routes": dataSourceKsyunDirectConnectGatewayRoutes(),
 
 			// clickhouse
 			"ksyun_clickhouse": dataSourceKsyunClickhouse(),
 			// cen
-			"ksyun_cens": dataSourceKsyunCens(),
+			"ksyun_cens":       dataSourceKsyunCens(),
+			"ksyun_cen_routes": dataSourceKsyunCenRoutes(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"ksyun_alb":                              resourceKsyunAlb(),
 			"ksyun_alb_listener":                     resourceKsyunAlbListener(),
 			"ksyun_alb_rule_group":                   resourceKsyunAlbRuleGroup(),
 			"ksyun_alb_listener_cert_group":          resourceKsyunAlbListenerCertGroup(),
-			"ksyun_eip":                              resourceKsyunEip(),
-			"ksyun_eip_associate":                    resourceKsyunEipAssociation(),
+			"ksyun_eip":                              regionalResource(resourceKsyunEip()),
+			"ksyun_eip_associate":                    regionalResource(resourceKsyunEipAssociation()),
+			"ksyun_ipv6_public_ip":                   regionalResource(resourceKsyunIpv6PublicIp()),
 			"ksyun_lb":                               resourceKsyunLb(),
 			"ksyun_healthcheck":                      resourceKsyunHealthCheck(),
 			"ksyun_lb_listener":                      resourceKsyunListener(),
@@ -614,13 +689,13 @@ func Provider() terraform.ResourceProvider {
 			"ksyun_lb_acl":                           resourceKsyunLoadBalancerAcl(),
 			"ksyun_lb_acl_entry":                     resourceKsyunLoadBalancerAclEntry(),
 			"ksyun_lb_listener_associate_acl":        resourceKsyunListenerAssociateAcl(),
-			"ksyun_vpc":                              resourceKsyunVpc(),
-			"ksyun_subnet":                           resourceKsyunSubnet(),
-			"ksyun_instance":                         resourceKsyunInstance(),
+			"ksyun_vpc":                              regionalResource(resourceKsyunVpc()),
+			"ksyun_subnet":                           regionalResource(resourceKsyunSubnet()),
+			"ksyun_instance":                         regionalResource(resourceKsyunInstance()),
 			"ksyun_instance_model":                   resourceKsyunInstanceModel(),
 			"ksyun_sqlserver":                        resourceKsyunSqlServer(),
-			"ksyun_kec_network_interface":            resourceKsyunKecNetworkInterface(),
-			"ksyun_kec_network_interface_attachment": resourceKsyunKecNetworkInterfaceAttachment(),
+			"ksyun_kec_network_interface":            regionalResource(resourceKsyunKecNetworkInterface()),
+			"ksyun_kec_network_interface_attachment": regionalResource(resourceKsyunKecNetworkInterfaceAttachment()),
 			"ksyun_krds":                             resourceKsyunKrds(),
 			"ksyun_krds_rr":                          resourceKsyunKrdsRr(),
 			"ksyun_krds_security_group":              resourceKsyunKrdsSecurityGroup(),
@@ -643,9 +718,14 @@ func Provider() terraform.ResourceProvider {
 			"ksyun_lb_host_header":                   resourceKsyunListenerHostHeader(),
 			"ksyun_lb_backend_server_group":          resourceKsyunBackendServerGroup(),
 			"ksyun_lb_register_backend_server":       resourceKsyunRegisterBackendServer(),
-			"ksyun_route":                            resourceKsyunRoute(),
-			"ksyun_nat":                              resourceKsyunNat(),
-			"ksyun_nat_associate":                    resourceKsyunNatAssociation(),
+			"ksyun_route":                            regionalResource(resourceKsyunRoute()),
+			"ksyun_vpc_peering_connection":           regionalResource(resourceKsyunVpcPeeringConnection()),
+			"ksyun_vpc_peering_connection_accepter":  regionalResource(resourceKsyunVpcPeeringConnectionAccepter()),
+			"ksyun_vpc_flow_log":                     regionalResource(resourceKsyunVpcFlowLog()),
+			"ksyun_havip":                            regionalResource(resourceKsyunHaVip()),
+			"ksyun_havip_attachment":                 regionalResource(resourceKsyunHaVipAttachment()),
+			"ksyun_nat":                              regionalResource(resourceKsyunNat()),
+			"ksyun_nat_associate":                    regionalResource(resourceKsyunNatAssociation()),
 			"ksyun_scaling_configuration":            resourceKsyunScalingConfiguration(),
 			"ksyun_scaling_group":                    resourceKsyunScalingGroup(),
 			"ksyun_scaling_instance":                 resourceKsyunScalingInstance(),
@@ -654,9 +734,10 @@ func Provider() terraform.ResourceProvider {
 			"ksyun_scaling_notification":             resourceKsyunScalingNotification(),
 			"ksyun_rabbitmq_instance":                resourceKsyunRabbitmq(),
 			"ksyun_rabbitmq_security_rule":           resourceKsyunRabbitmqSecurityRule(),
-			"ksyun_network_acl":                      resourceKsyunNetworkAcl(),
-			"ksyun_network_acl_entry":                resourceKsyunNetworkAclEntry(),
-			"ksyun_network_acl_associate":            resourceKsyunNetworkAclAssociate(),
+			"ksyun_network_acl":                      regionalResource(resourceKsyunNetworkAcl()),
+			"ksyun_network_acl_entry":                regionalResource(resourceKsyunNetworkAclEntry()),
+			"ksyun_network_acl_associate":            regionalResource(resourceKsyunNetworkAclAssociate()),
+			"ksyun_network_acl_rules":                regionalResource(resourceKsyunNetworkAclRules()),
 			"ksyun_vpn_gateway":                      resourceKsyunVpnGateway(),
 			"ksyun_vpn_customer_gateway":             resourceKsyunVpnCustomerGateway(),
 			"ksyun_vpn_tunnel":                       resourceKsyunVpnTunnel(),
@@ -665,7 +746,7 @@ func Provider() terraform.ResourceProvider {
 			"ksyun_bare_metal":                       resourceKsyunBareMetal(),
 			"ksyun_tag":                              resourceKsyunTag(),
 
-			"ksyun_ks3_bucket":                       resourceKsyunKs3Bucket(),
+			"ksyun_ks3_bucket":                       regionalResource(resourceKsyunKs3Bucket()),
 			"ksyun_auto_snapshot_policy":             resourceKsyunAutoSnapshotPolicy(),
 			"ksyun_auto_snapshot_volume_association": resourceKsyunAutoSnapshotVolumeAssociation(),
 			"ksyun_data_guard_group":                 resourceKsyunDataGuardGroup(),
@@ -674,7 +755,10 @@ func Provider() terraform.ResourceProvider {
 			"ksyun_perknad":                          resourceKsyunPerKnad(),
 			"ksyun_knad_associate":                   resourceKsyunKnadAssociate(),
 			"ksyun_nat_instance_bandwidth_limit":     resourceKsyunNatInstanceBandwidthLimit(),
-			"ksyun_dnat":                             resourceKsyunDnat(),
+			"ksyun_dnat":                             regionalResource(resourceKsyunDnat()),
+			"ksyun_snat_entry":                       regionalResource(resourceKsyunSnatEntry()),
+			"ksyun_nat_ip":                           regionalResource(resourceKsyunNatIp()),
+			"ksyun_dnat_rules":                       regionalResource(resourceKsyunDnatRules()),
 			"ksyun_alb_backend_server_group":         resourceKsyunAlbBackendServerGroup(),
 			"ksyun_alb_register_backend_server":      resourceKsyunRegisterAlbBackendServer(),
 			"ksyun_alb_listener_associate_acl":       resourceKsyunAlbListenerAssociateAcl(),
@@ -708,12 +792,14 @@ func Provider() terraform.ResourceProvider {
 			"ksyun_iam_group":           resourceKsyunIamGroup(),
 			"ksyun_iam_policy":          resourceKsyunIamPolicy(),
 			"ksyun_iam_relation_policy": resourceKsyunIamRelationPolicy(),
+			"ksyun_iam_project":         resourceKsyunIamProject(),
+			"ksyun_iam_project_member":  resourceKsyunIamProjectMember(),
 
 			// security group
-			"ksyun_security_group":            resourceKsyunSecurityGroup(),
-			"ksyun_security_group_entry":      resourceKsyunSecurityGroupEntry(),
-			"ksyun_security_group_entry_lite": resourceKsyunSecurityGroupEntryLite(),
-			// "ksyun_security_group_entry_set":  resourceKsyunSecurityGroupEntrySet(),
+			"ksyun_security_group":            regionalResource(resourceKsyunSecurityGroup()),
+			"ksyun_security_group_entry":      regionalResource(resourceKsyunSecurityGroupEntry()),
+			"ksyun_security_group_entry_lite": regionalResource(resourceKsyunSecurityGroupEntryLite()),
+			"ksyun_security_group_rules":      regionalResource(resourceKsyunSecurityGroupRules()),
 
 			"ksyun_bare_metal_hot_standby_action": resourceKsyunBareMetalHotStandbyAction(),
 			// lb
@@ -738,10 +824,19 @@ func Provider() terraform.ResourceProvider {
 			// monitor
 			"ksyun_monitor_alarm_policy": resourceKsyunMonitorAlarmPolicy(),
 			// cen
-			"ksyun_cen": resourceKsyunCen(),
+			"ksyun_cen":                     resourceKsyunCen(),
+			"ksyun_cen_instance_attachment": resourceKsyunCenInstanceAttachment(),
+			"ksyun_cen_bandwidth_package":   resourceKsyunCenBandwidthPackage(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
+
+	// every list data source accepts the generic filter blocks and the output file formats
+	for _, r := range provider.DataSourcesMap {
+		addDataSourceFilters(r)
+		addDataSourceOutputFormat(r)
+	}
+	return provider
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
@@ -762,6 +857,14 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		MaxRetries:    retryNum,
 		HttpProxy:     d.Get("http_proxy").(string),
 		UseSSL:        d.Get("force_https").(bool),
+		DescribeCache: d.Get("describe_cache").(bool),
+		QuotaCheck:    d.Get("quota_check").(bool),
+	}
+	if v, ok := d.GetOk("quota_limits"); ok {
+		config.QuotaLimits = make(map[string]int)
+		for service, limit := range v.(map[string]interface{}) {
+			config.QuotaLimits[service] = limit.(int)
+		}
 	}
 	client, err := config.Client()
 	return client, err
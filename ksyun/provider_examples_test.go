@@ -0,0 +1,437 @@
+package ksyun
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+const examplesDir = "../example"
+
+// meta arguments terraform handles itself, they never appear in a provider schema
+var exampleMetaArguments = map[string]bool{
+	"count":      true,
+	"for_each":   true,
+	"provider":   true,
+	"depends_on": true,
+}
+
+var exampleMetaBlocks = map[string]bool{
+	"lifecycle":   true,
+	"connection":  true,
+	"provisioner": true,
+	"timeouts":    true,
+}
+
+// TestProviderExamples validates every example/*/*.tf against the provider schema without
+// calling any api: unknown arguments, missing required arguments, type mismatches and
+// ValidateFunc failures are reported per example directory.
+func TestProviderExamples(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+
+	dirs, err := ioutil.ReadDir(examplesDir)
+	if err != nil {
+		t.Fatalf("read examples: %s", err)
+	}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		dir := dir
+		t.Run(dir.Name(), func(t *testing.T) {
+			problems, err := validateExampleDir(provider, filepath.Join(examplesDir, dir.Name()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range problems {
+				t.Error(p)
+			}
+		})
+	}
+}
+
+type exampleValidator struct {
+	provider *schema.Provider
+	ctx      *hcl.EvalContext
+	problems []string
+}
+
+func validateExampleDir(provider *schema.Provider, dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+
+	parser := hclparse.NewParser()
+	var bodies []*hclsyntax.Body
+	for _, file := range files {
+		f, diags := parser.ParseHCLFile(file)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("parse %s: %s", file, diags.Error())
+		}
+		bodies = append(bodies, f.Body.(*hclsyntax.Body))
+	}
+
+	v := &exampleValidator{
+		provider: provider,
+		ctx:      exampleEvalContext(bodies),
+	}
+	for _, body := range bodies {
+		for _, block := range body.Blocks {
+			v.validateTopBlock(block)
+		}
+	}
+	sort.Strings(v.problems)
+	return v.problems, nil
+}
+
+// exampleEvalContext resolves var.* from variable defaults and local.* from locals,
+// anything else (resource references, functions) stays unknown and is not type checked
+func exampleEvalContext(bodies []*hclsyntax.Body) *hcl.EvalContext {
+	vars := make(map[string]cty.Value)
+	for _, body := range bodies {
+		for _, block := range body.Blocks {
+			if block.Type != "variable" || len(block.Labels) != 1 {
+				continue
+			}
+			value := cty.DynamicVal
+			if attr, ok := block.Body.Attributes["default"]; ok {
+				if v, diags := attr.Expr.Value(nil); !diags.HasErrors() {
+					value = v
+				}
+			}
+			vars[block.Labels[0]] = value
+		}
+	}
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var": cty.ObjectVal(vars),
+		},
+	}
+
+	locals := make(map[string]cty.Value)
+	for _, body := range bodies {
+		for _, block := range body.Blocks {
+			if block.Type != "locals" {
+				continue
+			}
+			for name, attr := range block.Body.Attributes {
+				value := cty.DynamicVal
+				if v, diags := attr.Expr.Value(ctx); !diags.HasErrors() {
+					value = v
+				}
+				locals[name] = value
+			}
+		}
+	}
+	ctx.Variables["local"] = cty.ObjectVal(locals)
+	return ctx
+}
+
+func (v *exampleValidator) report(block *hclsyntax.Block, path string, format string, args ...interface{}) {
+	pos := block.DefRange()
+	name := strings.Join(append([]string{block.Type}, block.Labels...), ".")
+	if path != "" {
+		name = name + ": " + path
+	}
+	v.problems = append(v.problems, fmt.Sprintf("%s:%d: %s: %s", filepath.Base(pos.Filename), pos.Start.Line, name, fmt.Sprintf(format, args...)))
+}
+
+func (v *exampleValidator) validateTopBlock(block *hclsyntax.Block) {
+	switch block.Type {
+	case "provider":
+		if len(block.Labels) == 1 && block.Labels[0] == "ksyun" {
+			v.validateBody(block, block.Body, "", v.provider.Schema)
+		}
+	case "resource", "data":
+		if len(block.Labels) != 2 || !strings.HasPrefix(block.Labels[0], "ksyun_") {
+			return
+		}
+		var (
+			r  *schema.Resource
+			ok bool
+		)
+		if block.Type == "resource" {
+			r, ok = v.provider.ResourcesMap[block.Labels[0]]
+		} else {
+			r, ok = v.provider.DataSourcesMap[block.Labels[0]]
+		}
+		if !ok {
+			v.report(block, "", "%s type %s does not exist", block.Type, block.Labels[0])
+			return
+		}
+		v.validateBody(block, block.Body, "", r.Schema)
+	}
+}
+
+func (v *exampleValidator) validateBody(top *hclsyntax.Block, body *hclsyntax.Body, prefix string, m map[string]*schema.Schema) {
+	present := make(map[string]bool)
+	isTop := prefix == ""
+
+	for name, attr := range body.Attributes {
+		path := prefix + name
+		if isTop && exampleMetaArguments[name] {
+			continue
+		}
+		s, ok := m[name]
+		if !ok {
+			v.report(top, path, "unknown argument")
+			continue
+		}
+		present[name] = true
+		if !s.Optional && !s.Required {
+			v.report(top, path, "argument is computed and cannot be set")
+			continue
+		}
+		if s.Removed != "" {
+			v.report(top, path, "argument has been removed: %s", s.Removed)
+			continue
+		}
+		value, diags := attr.Expr.Value(v.ctx)
+		if diags.HasErrors() {
+			// references to other resources or function calls, only known at apply time
+			continue
+		}
+		v.validateValue(top, path, s, value)
+	}
+
+	blocksCount := make(map[string]int)
+	for _, block := range body.Blocks {
+		if isTop && exampleMetaBlocks[block.Type] {
+			continue
+		}
+		name := block.Type
+		if name == "dynamic" && len(block.Labels) == 1 {
+			// the content of dynamic blocks depends on for_each, only check the block exists
+			name = block.Labels[0]
+			if s, ok := m[name]; !ok || !isNestedBlock(s) {
+				v.report(top, prefix+name, "unknown block type")
+			}
+			present[name] = true
+			continue
+		}
+		path := prefix + name
+		s, ok := m[name]
+		if !ok {
+			v.report(top, path, "unknown block type")
+			continue
+		}
+		if !isNestedBlock(s) {
+			v.report(top, path, "argument must be set with '=' instead of as a block")
+			continue
+		}
+		if !s.Optional && !s.Required {
+			v.report(top, path, "block is computed and cannot be set")
+			continue
+		}
+		present[name] = true
+		blocksCount[name]++
+		v.validateBody(top, block.Body, path+".", s.Elem.(*schema.Resource).Schema)
+	}
+	for name, count := range blocksCount {
+		s := m[name]
+		if s.MaxItems > 0 && count > s.MaxItems {
+			v.report(top, prefix+name, "at most %d blocks are allowed, got %d", s.MaxItems, count)
+		}
+		if s.MinItems > 0 && count < s.MinItems {
+			v.report(top, prefix+name, "at least %d blocks are required, got %d", s.MinItems, count)
+		}
+	}
+
+	var missing []string
+	for name, s := range m {
+		if s.Required && !present[name] {
+			missing = append(missing, prefix+name)
+		}
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		v.report(top, name, "missing required argument")
+	}
+}
+
+func isNestedBlock(s *schema.Schema) bool {
+	if s.Type != schema.TypeList && s.Type != schema.TypeSet {
+		return false
+	}
+	_, ok := s.Elem.(*schema.Resource)
+	return ok
+}
+
+func (v *exampleValidator) validateValue(top *hclsyntax.Block, path string, s *schema.Schema, value cty.Value) {
+	if value.IsNull() || !value.IsWhollyKnown() {
+		return
+	}
+	switch s.Type {
+	case schema.TypeString, schema.TypeInt, schema.TypeFloat, schema.TypeBool:
+		goValue, err := examplePrimitive(s.Type, value)
+		if err != nil {
+			v.report(top, path, "%s", err)
+			return
+		}
+		if s.ValidateFunc != nil {
+			_, errs := s.ValidateFunc(goValue, path)
+			for _, err := range errs {
+				v.report(top, path, "invalid value: %s", err)
+			}
+		}
+	case schema.TypeList, schema.TypeSet:
+		ty := value.Type()
+		if !ty.IsListType() && !ty.IsTupleType() && !ty.IsSetType() {
+			v.report(top, path, "expected a list, got %s", ty.FriendlyName())
+			return
+		}
+		length := value.LengthInt()
+		if s.MaxItems > 0 && length > s.MaxItems {
+			v.report(top, path, "at most %d items are allowed, got %d", s.MaxItems, length)
+		}
+		if s.MinItems > 0 && length < s.MinItems {
+			v.report(top, path, "at least %d items are required, got %d", s.MinItems, length)
+		}
+		i := 0
+		for it := value.ElementIterator(); it.Next(); i++ {
+			_, elem := it.Element()
+			v.validateElem(top, fmt.Sprintf("%s.%d", path, i), s.Elem, elem)
+		}
+	case schema.TypeMap:
+		ty := value.Type()
+		if !ty.IsMapType() && !ty.IsObjectType() {
+			v.report(top, path, "expected a map, got %s", ty.FriendlyName())
+			return
+		}
+		for it := value.ElementIterator(); it.Next(); {
+			key, elem := it.Element()
+			v.validateElem(top, path+"."+key.AsString(), s.Elem, elem)
+		}
+	}
+}
+
+func (v *exampleValidator) validateElem(top *hclsyntax.Block, path string, elem interface{}, value cty.Value) {
+	switch e := elem.(type) {
+	case *schema.Schema:
+		v.validateValue(top, path, e, value)
+	case *schema.Resource:
+		if value.IsNull() || !value.IsWhollyKnown() {
+			return
+		}
+		if !value.Type().IsObjectType() && !value.Type().IsMapType() {
+			v.report(top, path, "expected an object, got %s", value.Type().FriendlyName())
+			return
+		}
+		for it := value.ElementIterator(); it.Next(); {
+			key, attr := it.Element()
+			name := key.AsString()
+			s, ok := e.Schema[name]
+			if !ok {
+				v.report(top, path+"."+name, "unknown argument")
+				continue
+			}
+			v.validateValue(top, path+"."+name, s, attr)
+		}
+	case schema.ValueType:
+		v.validateValue(top, path, &schema.Schema{Type: e}, value)
+	default:
+		// maps without Elem are maps of strings
+		v.validateValue(top, path, &schema.Schema{Type: schema.TypeString}, value)
+	}
+}
+
+// examplePrimitive converts a cty value the same way terraform does before handing it to the provider
+func examplePrimitive(t schema.ValueType, value cty.Value) (interface{}, error) {
+	var target cty.Type
+	switch t {
+	case schema.TypeString:
+		target = cty.String
+	case schema.TypeBool:
+		target = cty.Bool
+	default:
+		target = cty.Number
+	}
+	converted, err := convert.Convert(value, target)
+	if err != nil {
+		return nil, fmt.Errorf("expected %s, got %s", strings.TrimPrefix(t.String(), "Type"), value.Type().FriendlyName())
+	}
+	switch t {
+	case schema.TypeString:
+		return converted.AsString(), nil
+	case schema.TypeBool:
+		return converted.True(), nil
+	case schema.TypeInt:
+		var i int
+		if err = gocty.FromCtyValue(converted, &i); err != nil {
+			return nil, fmt.Errorf("expected Int, got %s", converted.AsBigFloat().String())
+		}
+		return i, nil
+	default:
+		var f float64
+		err = gocty.FromCtyValue(converted, &f)
+		return f, err
+	}
+}
+
+func TestExampleValidator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ksyun-example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := `
+variable "cidr" {
+  default = "10.0.0.0/16"
+}
+resource "ksyun_vpc" "default" {
+  vpc_name    = "tf-example"
+  cidr_block  = var.cidr
+  unknown_arg = true
+}
+resource "ksyun_subnet" "default" {
+  subnet_name = "tf-example"
+  cidr_block  = "10.0.5.0/24"
+  subnet_type = "Normal"
+  vpc_id      = ksyun_vpc.default.id
+  dns1        = "198.18.254.30"
+  availability_zone = ["cn-beijing-6a"]
+}
+resource "ksyun_security_group_entry" "default" {
+  security_group_id = "sg"
+  cidr_block        = "not-a-cidr"
+  direction         = "in"
+  protocol          = "ip"
+}
+data "ksyun_not_exist" "default" {
+}
+`
+	if err = ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	problems, err := validateExampleDir(Provider().(*schema.Provider), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"data.ksyun_not_exist.default: data type ksyun_not_exist does not exist",
+		"resource.ksyun_security_group_entry.default: cidr_block: invalid value",
+		"resource.ksyun_subnet.default: availability_zone: expected String, got tuple",
+		"resource.ksyun_vpc.default: unknown_arg: unknown argument",
+	}
+	all := strings.Join(problems, "\n")
+	for _, e := range expected {
+		if !strings.Contains(all, e) {
+			t.Errorf("expected problem %q in %v", e, problems)
+		}
+	}
+}
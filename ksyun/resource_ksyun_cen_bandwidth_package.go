@@ -0,0 +1,185 @@
+/*
+Provides a Cen bandwidth package resource, the bandwidth between two areas shared by the cross region traffic of a cen.
+
+The bandwidth between two regions of the areas is limited by the `region_bandwidths`, the limits of all the region pairs
+can not exceed `package_band_width` together, it is checked on plan.
+
+# Example Usage
+
+```hcl
+
+	resource "ksyun_cen" "default" {
+	  cen_name = "tf-cen"
+	}
+
+	resource "ksyun_cen_bandwidth_package" "default" {
+	  cen_id                      = ksyun_cen.default.id
+	  cen_band_width_package_name = "tf-cen-bwp"
+	  local_area_id               = "3b0f8a2e-6a1c-4f7e-9d3b-1c2e4f5a6b7c"
+	  remote_area_id              = "3b0f8a2e-6a1c-4f7e-9d3b-1c2e4f5a6b7c"
+	  package_band_width          = 20
+	  charge_type                 = "PrePaidByMonth"
+	  purchase_time               = 1
+
+	  region_bandwidths {
+	    local_region     = "cn-beijing-6"
+	    remote_region    = "cn-shanghai-2"
+	    inter_band_width = 10
+	  }
+	  region_bandwidths {
+	    local_region     = "cn-beijing-6"
+	    remote_region    = "cn-guangzhou-1"
+	    inter_band_width = 5
+	  }
+	}
+
+```
+
+# Import
+
+Cen bandwidth package can be imported using the `id`, e.g.
+
+```
+$ terraform import ksyun_cen_bandwidth_package.default 7385c8ea-79f7-4e9c-b99f-517fc3726256
+```
+*/
+package ksyun
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceKsyunCenBandwidthPackage() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunCenBandwidthPackageCreate,
+		Read:   resourceKsyunCenBandwidthPackageRead,
+		Update: resourceKsyunCenBandwidthPackageUpdate,
+		Delete: resourceKsyunCenBandwidthPackageDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: cenBandwidthPackageCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"cen_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the cen.",
+			},
+			"cen_band_width_package_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the bandwidth package.",
+			},
+			"local_area_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the local area of the bandwidth package, such as the area of the mainland of China.",
+			},
+			"remote_area_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the remote area of the bandwidth package.",
+			},
+			"package_band_width": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The bandwidth of the package in Mbps.",
+			},
+			"charge_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The charge type of the bandwidth package, such as `PrePaidByMonth`.",
+			},
+			"purchase_time": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: purchaseTimeDiffSuppressFunc,
+				ValidateFunc:     validation.IntBetween(0, 36),
+				Description:      "Purchase time. If charge_type is Monthly or PrePaidByMonth, this is Required.",
+			},
+			"project_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the project.",
+			},
+			"region_bandwidths": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"local_region": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The region of the local area.",
+						},
+						"remote_region": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The region of the remote area.",
+						},
+						"inter_band_width": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "The bandwidth limit between the regions in Mbps.",
+						},
+					},
+				},
+				Description: "The bandwidth limits between the region pairs, the traffic between two regions is not limited if they are not paired.",
+			},
+			"create_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The creation time of the bandwidth package.",
+			},
+		},
+	}
+}
+
+func resourceKsyunCenBandwidthPackageCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	cenService := CenService{meta.(*KsyunClient)}
+	err = cenService.CreateCenBandwidthPackage(d, resourceKsyunCenBandwidthPackage())
+	if err != nil {
+		return fmt.Errorf("error on creating cen bandwidth package %q, %s", d.Id(), err)
+	}
+	return resourceKsyunCenBandwidthPackageRead(d, meta)
+}
+
+func resourceKsyunCenBandwidthPackageRead(d *schema.ResourceData, meta interface{}) (err error) {
+	cenService := CenService{meta.(*KsyunClient)}
+	err = cenService.ReadAndSetCenBandwidthPackage(d, resourceKsyunCenBandwidthPackage())
+	if err != nil {
+		return fmt.Errorf("error on reading cen bandwidth package %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunCenBandwidthPackageUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+	cenService := CenService{meta.(*KsyunClient)}
+	err = cenService.ModifyCenBandwidthPackage(d, resourceKsyunCenBandwidthPackage())
+	if err != nil {
+		return fmt.Errorf("error on updating cen bandwidth package %q, %s", d.Id(), err)
+	}
+	return resourceKsyunCenBandwidthPackageRead(d, meta)
+}
+
+func resourceKsyunCenBandwidthPackageDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	cenService := CenService{meta.(*KsyunClient)}
+	err = cenService.RemoveCenBandwidthPackage(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting cen bandwidth package %q, %s", d.Id(), err)
+	}
+	return err
+}
@@ -0,0 +1,145 @@
+package ksyun
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunCenBandwidthPackage_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "ksyun_cen_bandwidth_package.foo",
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCenBandwidthPackageConfig, 10, 5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("ksyun_cen_bandwidth_package.foo"),
+					resource.TestCheckResourceAttr("ksyun_cen_bandwidth_package.foo", "package_band_width", "10"),
+					resource.TestCheckResourceAttr("ksyun_cen_bandwidth_package.foo", "region_bandwidths.#", "1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCenBandwidthPackageConfig, 4, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_cen_bandwidth_package.foo", "package_band_width", "4"),
+				),
+			},
+			{
+				ResourceName:            "ksyun_cen_bandwidth_package.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"charge_type", "purchase_time"},
+			},
+		},
+	})
+}
+
+func TestAccKsyunCenBandwidthPackage_exceeded(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccCenBandwidthPackageConfig, 4, 5),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("more than the package_band_width"),
+			},
+		},
+	})
+}
+
+func TestValidateCenRegionBandwidths(t *testing.T) {
+	pair := func(local string, remote string, bandwidth int) interface{} {
+		return map[string]interface{}{
+			"local_region":     local,
+			"remote_region":    remote,
+			"inter_band_width": bandwidth,
+		}
+	}
+	cases := []struct {
+		name    string
+		entries []interface{}
+		err     string
+	}{
+		{
+			name:    "within the package",
+			entries: []interface{}{pair("cn-beijing-6", "cn-shanghai-2", 6), pair("cn-beijing-6", "cn-guangzhou-1", 4)},
+		},
+		{
+			name:    "exceeding the package",
+			entries: []interface{}{pair("cn-beijing-6", "cn-shanghai-2", 6), pair("cn-beijing-6", "cn-guangzhou-1", 5)},
+			err:     "more than the package_band_width",
+		},
+		{
+			name:    "reversed pair",
+			entries: []interface{}{pair("cn-beijing-6", "cn-shanghai-2", 2), pair("cn-shanghai-2", "cn-beijing-6", 2)},
+			err:     "limited more than once",
+		},
+		{
+			name:    "same region",
+			entries: []interface{}{pair("cn-beijing-6", "cn-beijing-6", 2)},
+			err:     "are both cn-beijing-6",
+		},
+	}
+	for _, c := range cases {
+		err := validateCenRegionBandwidths(10, c.entries)
+		if c.err == "" && err != nil || c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+			t.Errorf("%s: validateCenRegionBandwidths() = %v, want %q", c.name, err, c.err)
+		}
+	}
+}
+
+func TestCenRegionBandwidthsDeltaCalls(t *testing.T) {
+	current := []interface{}{
+		map[string]interface{}{"CenRegionBandwidthId": "rb-1", "LocalRegion": "cn-beijing-6", "RemoteRegion": "cn-shanghai-2", "InterBandWidth": float64(5)},
+		map[string]interface{}{"CenRegionBandwidthId": "rb-2", "LocalRegion": "cn-beijing-6", "RemoteRegion": "cn-guangzhou-1", "InterBandWidth": float64(5)},
+		map[string]interface{}{"CenRegionBandwidthId": "rb-3", "LocalRegion": "cn-shanghai-2", "RemoteRegion": "cn-guangzhou-1", "InterBandWidth": float64(2)},
+	}
+	desired := []interface{}{
+		map[string]interface{}{"local_region": "cn-beijing-6", "remote_region": "cn-shanghai-2", "inter_band_width": 5},
+		map[string]interface{}{"local_region": "cn-beijing-6", "remote_region": "cn-guangzhou-1", "inter_band_width": 8},
+		map[string]interface{}{"local_region": "cn-beijing-6", "remote_region": "cn-hongkong-2", "inter_band_width": 1},
+	}
+	calls := (&CenService{}).cenRegionBandwidthsDeltaCalls("bwp", current, desired)
+	var actions []string
+	for _, call := range calls {
+		actions = append(actions, call.action)
+	}
+	if got := strings.Join(actions, ","); got != "DeleteCenRegionBandwidth,ModifyCenRegionBandwidth,CreateCenRegionBandwidth" {
+		t.Errorf("cenRegionBandwidthsDeltaCalls() = %s, want the removed pair deleted before the others", got)
+	}
+	if id := (*calls[0].param)["CenRegionBandwidthId"]; id != "rb-3" {
+		t.Errorf("cenRegionBandwidthsDeltaCalls() deletes %v, want rb-3", id)
+	}
+}
+
+const testAccCenBandwidthPackageConfig = `
+resource "ksyun_cen" "default" {
+  cen_name = "tf-acc-cen-bwp"
+}
+
+resource "ksyun_cen_bandwidth_package" "foo" {
+  cen_id                      = "${ksyun_cen.default.id}"
+  cen_band_width_package_name = "tf-acc-cen-bwp"
+  local_area_id               = "3b0f8a2e-6a1c-4f7e-9d3b-1c2e4f5a6b7c"
+  remote_area_id              = "3b0f8a2e-6a1c-4f7e-9d3b-1c2e4f5a6b7c"
+  package_band_width          = %d
+  charge_type                 = "PrePaidByMonth"
+  purchase_time               = 1
+
+  region_bandwidths {
+    local_region     = "cn-beijing-6"
+    remote_region    = "cn-shanghai-2"
+    inter_band_width = %d
+  }
+}
+`
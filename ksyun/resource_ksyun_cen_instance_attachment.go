@@ -0,0 +1,132 @@
+/*
+Provides a Cen instance attachment resource, which attaches a vpc or a direct connect gateway of any region to a cen.
+
+The routes of the attached network instances are learned by the cen, see `ksyun_cen_routes`. The network instance of
+another account is attached after its owner grants it to the cen.
+
+# Example Usage
+
+```hcl
+
+	resource "ksyun_cen" "default" {
+	  cen_name = "tf-cen"
+	}
+
+	resource "ksyun_cen_instance_attachment" "beijing" {
+	  cen_id          = ksyun_cen.default.id
+	  instance_type   = "Vpc"
+	  instance_id     = "a8979fe2-cf1a-47b9-80f6-57445227c541"
+	  instance_region = "cn-beijing-6"
+	}
+
+	resource "ksyun_cen_instance_attachment" "shanghai" {
+	  cen_id          = ksyun_cen.default.id
+	  instance_type   = "DirectConnectGateway"
+	  instance_id     = "f2b1e6a4-5d2c-4a8b-9c3d-2e1f0a9b8c7d"
+	  instance_region = "cn-shanghai-2"
+	}
+
+```
+
+# Import
+
+Cen instance attachment can be imported using the `id`, the id format is `cen_id:instance_id`, e.g.
+
+```
+$ terraform import ksyun_cen_instance_attachment.default 7385c8ea-79f7-4e9c-b99f-517fc3726256:a8979fe2-cf1a-47b9-80f6-57445227c541
+```
+*/
+package ksyun
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceKsyunCenInstanceAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunCenInstanceAttachmentCreate,
+		Read:   resourceKsyunCenInstanceAttachmentRead,
+		Delete: resourceKsyunCenInstanceAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: importCenInstanceAttachment,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"cen_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the cen.",
+			},
+			"instance_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"Vpc",
+					"DirectConnectGateway",
+				}, false),
+				Description: "The type of the network instance. Valid Values: 'Vpc', 'DirectConnectGateway'.",
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the vpc or the direct connect gateway.",
+			},
+			"instance_region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The region of the network instance, the region of the provider by default.",
+			},
+			"instance_account_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The account ID of the network instance, the account of the cen by default.",
+			},
+			"create_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the network instance is attached.",
+			},
+		},
+	}
+}
+
+func resourceKsyunCenInstanceAttachmentCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	cenService := CenService{meta.(*KsyunClient)}
+	err = cenService.CreateCenInstanceAttachment(d, resourceKsyunCenInstanceAttachment())
+	if err != nil {
+		return fmt.Errorf("error on creating cen instance attachment %q, %s", d.Id(), err)
+	}
+	return resourceKsyunCenInstanceAttachmentRead(d, meta)
+}
+
+func resourceKsyunCenInstanceAttachmentRead(d *schema.ResourceData, meta interface{}) (err error) {
+	cenService := CenService{meta.(*KsyunClient)}
+	err = cenService.ReadAndSetCenInstanceAttachment(d, resourceKsyunCenInstanceAttachment())
+	if err != nil {
+		return fmt.Errorf("error on reading cen instance attachment %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunCenInstanceAttachmentDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	cenService := CenService{meta.(*KsyunClient)}
+	err = cenService.RemoveCenInstanceAttachment(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting cen instance attachment %q, %s", d.Id(), err)
+	}
+	return err
+}
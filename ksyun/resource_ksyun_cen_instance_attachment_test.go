@@ -0,0 +1,53 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunCenInstanceAttachment_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "ksyun_cen_instance_attachment.foo",
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCenInstanceAttachmentConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("ksyun_cen_instance_attachment.foo"),
+					resource.TestCheckResourceAttr("ksyun_cen_instance_attachment.foo", "instance_region", "cn-beijing-6"),
+					resource.TestCheckResourceAttrSet("ksyun_cen_instance_attachment.foo", "create_time"),
+				),
+			},
+			{
+				ResourceName:      "ksyun_cen_instance_attachment.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccCenInstanceAttachmentConfig = `
+provider "ksyun" {
+  region = "cn-beijing-6"
+}
+
+resource "ksyun_cen" "default" {
+  cen_name = "tf-acc-cen-attachment"
+}
+
+resource "ksyun_vpc" "default" {
+  vpc_name   = "tf-acc-cen-attachment"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_cen_instance_attachment" "foo" {
+  cen_id        = "${ksyun_cen.default.id}"
+  instance_type = "Vpc"
+  instance_id   = "${ksyun_vpc.default.id}"
+}
+`
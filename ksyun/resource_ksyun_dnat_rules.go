@@ -0,0 +1,178 @@
+/*
+Provides the dnat rules of a nat ip.
+
+The dnats of the public ports of the `rule` mappings are exactly the mappings, the dnats of the other ports of the nat
+ip, such as the ones added in the console or by `ksyun_dnat`, are kept. If `exclusive` is true, the dnats of the nat ip
+are exactly the `rule` mappings, the dnats added out of band are deleted on the next apply and the deleted ones are
+created again. CreateDnat maps a single port, so a mapping of a port range is created as one dnat per port and a range
+has at most 100 ports. The mappings that map the same public port twice are rejected on plan. The dnats are read with
+one DescribeDnats per 200 ports and only the changed ports are created, modified or deleted, several at a time, so
+hundreds of ports are applied in one resource. If some of them fail, the applied ones are kept in the state and the
+next apply retries the others.
+
+# Example Usage
+
+```hcl
+
+	resource "ksyun_dnat_rules" "game" {
+	  nat_id = ksyun_nat.foo.id
+	  nat_ip = ksyun_nat.foo.nat_ip_set[0].nat_ip
+
+	  rule {
+	    ip_protocol        = "UDP"
+	    private_ip_address = "10.0.5.10"
+	    public_port_from   = 27015
+	    public_port_to     = 27030
+	    description        = "game server 1"
+	  }
+	  rule {
+	    ip_protocol        = "TCP"
+	    private_ip_address = "10.0.5.11"
+	    public_port_from   = 8022
+	    private_port_from  = 22
+	  }
+	}
+
+```
+
+# Import
+
+The dnat rules of a nat ip can be imported using the id of the nat and the nat ip, or the id of the nat when all of
+its dnats use one nat ip, e.g.
+
+```
+$ terraform import ksyun_dnat_rules.game $nat_id:$nat_ip
+```
+*/
+package ksyun
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dnatRuleResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"ip_protocol": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Any", "TCP", "UDP"}, false),
+				Description:  "The protocol of the mapping, Valid Options: `Any`, `TCP` and `UDP`. A mapping of `Any` maps every port of the nat ip, its ports must not be set and it must be the only mapping.",
+			},
+			"private_ip_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsIPAddress,
+				Description:  "The private ip of the instance in the vpc of the nat.",
+			},
+			"public_port_from": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 65535),
+				Description:  "The first public port of the mapping. Required when `ip_protocol` is `TCP` or `UDP`.",
+			},
+			"public_port_to": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 65535),
+				Description:  "The last public port of the mapping, `public_port_from` if not set. The range has at most 100 ports.",
+			},
+			"private_port_from": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 65535),
+				Description:  "The first private port of the mapping, `public_port_from` if not set.",
+			},
+			"private_port_to": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 65535),
+				Description:  "The last private port of the mapping. The private port range must have the length of the public one, it is computed from `private_port_from` if not set.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the dnats of the mapping.",
+			},
+		},
+	}
+}
+
+func resourceKsyunDnatRules() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunDnatRulesCreate,
+		Read:   resourceKsyunDnatRulesRead,
+		Update: resourceKsyunDnatRulesUpdate,
+		Delete: resourceKsyunDnatRulesDelete,
+		Importer: &schema.ResourceImporter{
+			State: importDnatRules,
+		},
+		CustomizeDiff: dnatRulesCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"nat_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the nat.",
+			},
+			"nat_ip": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPAddress,
+				Description:  "The nat ip of the nat that the rules map.",
+			},
+			"rule": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        dnatRuleResource(),
+				Description: "The port mappings of the nat ip, an empty set deletes every dnat of the nat ip if `exclusive` is true.",
+			},
+			"exclusive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the resource manages every dnat of the nat ip. If true, the dnats not in `rule`, such as the ones added in the console or by `ksyun_dnat`, are deleted. If false, only the dnats of the public ports of `rule` are managed.",
+			},
+		},
+	}
+}
+
+func resourceKsyunDnatRulesCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ApplyDnatRules(d)
+	if err != nil {
+		return fmt.Errorf("error on creating dnat rules %q, %s", d.Id(), err)
+	}
+	return resourceKsyunDnatRulesRead(d, meta)
+}
+
+func resourceKsyunDnatRulesRead(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ReadAndSetDnatRules(d)
+	if err != nil {
+		return fmt.Errorf("error on reading dnat rules %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunDnatRulesUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ApplyDnatRules(d)
+	if err != nil {
+		return fmt.Errorf("error on updating dnat rules %q, %s", d.Id(), err)
+	}
+	return resourceKsyunDnatRulesRead(d, meta)
+}
+
+func resourceKsyunDnatRulesDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.RemoveDnatRules(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting dnat rules %q, %s", d.Id(), err)
+	}
+	return err
+}
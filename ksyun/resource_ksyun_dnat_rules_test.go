@@ -0,0 +1,361 @@
+package ksyun
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestAccKsyunDnatRules_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "ksyun_dnat_rules.foo",
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDnatRulesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("ksyun_dnat_rules.foo"),
+					resource.TestCheckResourceAttr("ksyun_dnat_rules.foo", "rule.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "ksyun_dnat_rules.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccDnatRulesUpdateConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_dnat_rules.foo", "rule.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testDnatRule(protocol string, ip string, publicFrom int, publicTo int, privateFrom int) map[string]interface{} {
+	return map[string]interface{}{
+		"ip_protocol":        protocol,
+		"private_ip_address": ip,
+		"public_port_from":   publicFrom,
+		"public_port_to":     publicTo,
+		"private_port_from":  privateFrom,
+		"private_port_to":    0,
+		"description":        "",
+	}
+}
+
+func TestExpandDnatRule(t *testing.T) {
+	rules, err := expandDnatRule(testDnatRule("UDP", "10.0.5.10", 27015, 27017, 7015))
+	if err != nil {
+		t.Fatalf("expandDnatRule() returned error: %s", err)
+	}
+	var got []string
+	for _, rule := range rules {
+		got = append(got, rule.publicPort+">"+rule.privatePort)
+	}
+	if strings.Join(got, ",") != "27015>7015,27016>7016,27017>7017" {
+		t.Errorf("expandDnatRule() = %v", got)
+	}
+
+	rules, _ = expandDnatRule(testDnatRule("TCP", "10.0.5.10", 22, 0, 0))
+	if len(rules) != 1 || rules[0].privatePort != "22" {
+		t.Errorf("a single port is expanded to %v, want 22 mapped to 22", rules)
+	}
+
+	rules, _ = expandDnatRule(testDnatRule("Any", "10.0.5.10", 0, 0, 0))
+	if len(rules) != 1 || rules[0].publicPort != AnyPortType || rules[0].privatePort != AnyPortType {
+		t.Errorf("a rule of Any is expanded to %v, want Any mapped to Any", rules)
+	}
+
+	if rules, err = expandDnatRule(testDnatRule("TCP", "10.0.5.10", 1000, 1099, 0)); err != nil || len(rules) != 100 {
+		t.Errorf("a range of 100 ports is expanded to %d rules, %v", len(rules), err)
+	}
+
+	uneven := testDnatRule("TCP", "10.0.5.10", 8000, 8009, 9000)
+	uneven["private_port_to"] = 9004
+	for name, m := range map[string]map[string]interface{}{
+		"ports of Any":       testDnatRule("Any", "10.0.5.10", 80, 0, 0),
+		"no public port":     testDnatRule("TCP", "10.0.5.10", 0, 0, 0),
+		"reversed range":     testDnatRule("TCP", "10.0.5.10", 90, 80, 0),
+		"different lengths":  uneven,
+		"private overflowed": testDnatRule("TCP", "10.0.5.10", 100, 150, 65500),
+		"too wide range":     testDnatRule("TCP", "10.0.5.10", 1000, 1100, 0),
+	} {
+		if _, err = expandDnatRule(m); err == nil {
+			t.Errorf("%s: expandDnatRule() accepted %v", name, m)
+		}
+	}
+}
+
+func TestValidateDnatRules(t *testing.T) {
+	cases := []struct {
+		name     string
+		elements []map[string]interface{}
+		invalid  bool
+	}{
+		{
+			name: "adjacent ranges",
+			elements: []map[string]interface{}{
+				testDnatRule("TCP", "10.0.5.10", 8000, 8009, 0),
+				testDnatRule("TCP", "10.0.5.11", 8010, 8019, 0),
+			},
+		},
+		{
+			name: "same port of other protocols",
+			elements: []map[string]interface{}{
+				testDnatRule("TCP", "10.0.5.10", 53, 0, 0),
+				testDnatRule("UDP", "10.0.5.10", 53, 0, 0),
+			},
+		},
+		{
+			name: "overlapped ranges",
+			elements: []map[string]interface{}{
+				testDnatRule("UDP", "10.0.5.10", 27015, 27030, 0),
+				testDnatRule("UDP", "10.0.5.11", 27030, 27045, 0),
+			},
+			invalid: true,
+		},
+		{
+			name: "Any with other rules",
+			elements: []map[string]interface{}{
+				testDnatRule("Any", "10.0.5.10", 0, 0, 0),
+				testDnatRule("TCP", "10.0.5.11", 22, 0, 0),
+			},
+			invalid: true,
+		},
+	}
+	for _, c := range cases {
+		var rules []dnatRule
+		for _, m := range c.elements {
+			expanded, err := expandDnatRule(m)
+			if err != nil {
+				t.Fatalf("%s: expandDnatRule() returned error: %s", c.name, err)
+			}
+			rules = append(rules, expanded...)
+		}
+		err := validateDnatRules(rules)
+		if c.invalid != (err != nil) {
+			t.Errorf("%s: validateDnatRules() = %v, want invalid %t", c.name, err, c.invalid)
+		}
+	}
+}
+
+func testDnatSetItem(id string, protocol string, publicPort string, ip string, privatePort string) map[string]interface{} {
+	return map[string]interface{}{
+		"DnatId":           id,
+		"NatIp":            "120.92.1.1",
+		"IpProtocol":       protocol,
+		"PublicPort":       publicPort,
+		"PrivateIpAddress": ip,
+		"PrivatePort":      privatePort,
+	}
+}
+
+func TestFlattenDnatRules(t *testing.T) {
+	other := testDnatSetItem("dnat-5", "TCP", "80", "10.0.5.12", "80")
+	other["NatIp"] = "120.92.1.2"
+	current := dnatRulesFromDnatSet([]interface{}{
+		testDnatSetItem("dnat-3", "UDP", "27017", "10.0.5.10", "27017"),
+		testDnatSetItem("dnat-1", "UDP", "27015", "10.0.5.10", "27015"),
+		testDnatSetItem("dnat-2", "UDP", "27016", "10.0.5.10", "27016"),
+		testDnatSetItem("dnat-4", "TCP", "8022", "10.0.5.11", "22"),
+		other,
+	}, "120.92.1.1")
+	if len(current) != 4 {
+		t.Fatalf("dnatRulesFromDnatSet() returned %d rules, want the 4 rules of the nat ip", len(current))
+	}
+
+	elements := flattenDnatRules(current, nil)
+	if len(elements) != 2 {
+		t.Fatalf("flattenDnatRules() returned %v, want 2 ranges", elements)
+	}
+	tcp := elements[0].(map[string]interface{})
+	udp := elements[1].(map[string]interface{})
+	if tcp["public_port_from"] != 8022 || tcp["public_port_to"] != 0 || tcp["private_port_from"] != 22 {
+		t.Errorf("the TCP rule is flattened to %v, want 8022 mapped to 22", tcp)
+	}
+	if udp["public_port_from"] != 27015 || udp["public_port_to"] != 27017 || udp["private_port_from"] != 0 {
+		t.Errorf("the UDP rules are flattened to %v, want 27015-27017 mapped to the same ports", udp)
+	}
+
+	// the imported ranges are split at the most ports of a mapping
+	var wide []interface{}
+	for port := 20000; port < 20150; port++ {
+		wide = append(wide, testDnatSetItem("dnat-"+strconv.Itoa(port), "UDP", strconv.Itoa(port), "10.0.5.10", strconv.Itoa(port)))
+	}
+	wideElements := flattenDnatRules(dnatRulesFromDnatSet(wide, "120.92.1.1"), nil)
+	if len(wideElements) != 2 || wideElements[0].(map[string]interface{})["public_port_to"] != 20099 ||
+		wideElements[1].(map[string]interface{})["public_port_from"] != 20100 {
+		t.Errorf("150 ports are flattened to %v, want 20000-20099 and 20100-20149", wideElements)
+	}
+
+	// the ranges split in the configuration are kept as configured
+	split := []interface{}{
+		testDnatRule("UDP", "10.0.5.10", 27015, 0, 0),
+		testDnatRule("UDP", "10.0.5.10", 27016, 27017, 0),
+		testDnatRule("TCP", "10.0.5.11", 8022, 8022, 22),
+	}
+	if elements = flattenDnatRules(current, split); len(elements) != 3 {
+		t.Errorf("flattenDnatRules() returned %v, want the 3 configured elements", elements)
+	}
+
+	// the flattened rules map as the remote ones, so nothing changes
+	var desired []dnatRule
+	for _, item := range elements {
+		expanded, _ := expandDnatRule(item.(map[string]interface{}))
+		desired = append(desired, expanded...)
+	}
+	calls, err := (&VpcService{}).dnatRulesDeltaCalls("nat", "120.92.1.1", current, desired)
+	if err != nil || len(calls) != 0 {
+		t.Errorf("dnatRulesDeltaCalls() returned %d calls and error %v, want none", len(calls), err)
+	}
+
+	// moving a port to another instance modifies it, a removed port is deleted and a new one created
+	desired, _ = expandDnatRule(testDnatRule("UDP", "10.0.5.10", 27016, 27018, 0))
+	desired[0].privateIpAddress = "10.0.5.13"
+	calls, _ = (&VpcService{}).dnatRulesDeltaCalls("nat", "120.92.1.1", current, desired)
+	var actions []string
+	for _, call := range calls {
+		actions = append(actions, call.action)
+	}
+	if got := strings.Join(actions, ","); got != "DeleteDnat,DeleteDnat,ModifyDnat,CreateDnat" {
+		t.Errorf("dnatRulesDeltaCalls() = %s, want 8022 and 27015 deleted, 27016 modified and 27018 created", got)
+	}
+}
+
+func TestFilterDnatRules(t *testing.T) {
+	current := dnatRulesFromDnatSet([]interface{}{
+		testDnatSetItem("dnat-1", "UDP", "27015", "10.0.5.10", "27015"),
+		testDnatSetItem("dnat-2", "UDP", "27016", "10.0.5.10", "27016"),
+		testDnatSetItem("dnat-3", "TCP", "80", "10.0.5.12", "80"),
+	}, "120.92.1.1")
+	old := schema.NewSet(schema.HashResource(dnatRuleResource()), []interface{}{
+		testDnatRule("UDP", "10.0.5.10", 27015, 0, 0),
+	})
+	desired := schema.NewSet(schema.HashResource(dnatRuleResource()), []interface{}{
+		testDnatRule("UDP", "10.0.5.10", 27016, 27017, 0),
+	})
+
+	// the dnat of TCP 80 is not managed, so it is not deleted
+	var got []string
+	for _, rule := range filterDnatRules(current, dnatRuleKeys(old, desired)) {
+		got = append(got, rule.dnatId)
+	}
+	if strings.Join(got, ",") != "dnat-1,dnat-2" {
+		t.Errorf("filterDnatRules() = %v, want dnat-1 and dnat-2", got)
+	}
+	if rules := filterDnatRules(current, nil); len(rules) != 3 {
+		t.Errorf("filterDnatRules() returned %d rules without the keys, want every rule", len(rules))
+	}
+}
+
+func TestRunDnatRulesCalls(t *testing.T) {
+	var (
+		mutex sync.Mutex
+		run   []string
+	)
+	testCall := func(action string, port string, fail bool) ApiCall {
+		return ApiCall{
+			param:  &map[string]interface{}{"IpProtocol": "UDP", "PublicPort": port},
+			action: action,
+			executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (*map[string]interface{}, error) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				run = append(run, call.action+"-"+port)
+				if fail {
+					return nil, errors.New("port in use")
+				}
+				return &map[string]interface{}{}, nil
+			},
+		}
+	}
+	var calls []ApiCall
+	for i := 0; i < 20; i++ {
+		calls = append(calls, testCall("CreateDnat", strconv.Itoa(27000+i), i == 5))
+	}
+	calls = append(calls, testCall("ModifyDnat", "28000", false))
+
+	err := (&VpcService{client: &KsyunClient{}}).runDnatRulesCalls(nil, calls)
+	if err == nil || !strings.Contains(err.Error(), "1 of 20 CreateDnat calls failed") ||
+		!strings.Contains(err.Error(), "UDP public port 27005") {
+		t.Errorf("runDnatRulesCalls() = %v, want the failed port 27005 reported", err)
+	}
+	// every create is run despite the failure, and the calls after them are not
+	if len(run) != 20 {
+		t.Errorf("runDnatRulesCalls() ran %d calls, want the 20 creates", len(run))
+	}
+}
+
+const testAccDnatRulesConfig = `
+resource "ksyun_vpc" "foo" {
+  vpc_name   = "tf-acc-dnat-rules"
+  cidr_block = "10.0.5.0/24"
+}
+
+resource "ksyun_nat" "foo" {
+  nat_name      = "tf-acc-dnat-rules"
+  nat_mode      = "Subnet"
+  nat_type      = "public"
+  band_width    = 1
+  charge_type   = "DailyPaidByTransfer"
+  vpc_id        = "${ksyun_vpc.foo.id}"
+  nat_ip_number = 1
+}
+
+resource "ksyun_dnat_rules" "foo" {
+  nat_id = "${ksyun_nat.foo.id}"
+  nat_ip = "${ksyun_nat.foo.nat_ip_set.0.nat_ip}"
+
+  rule {
+    ip_protocol        = "UDP"
+    private_ip_address = "10.0.5.10"
+    public_port_from   = 27015
+    public_port_to     = 27030
+  }
+  rule {
+    ip_protocol        = "TCP"
+    private_ip_address = "10.0.5.11"
+    public_port_from   = 8022
+    private_port_from  = 22
+    description        = "ssh"
+  }
+}
+`
+
+const testAccDnatRulesUpdateConfig = `
+resource "ksyun_vpc" "foo" {
+  vpc_name   = "tf-acc-dnat-rules"
+  cidr_block = "10.0.5.0/24"
+}
+
+resource "ksyun_nat" "foo" {
+  nat_name      = "tf-acc-dnat-rules"
+  nat_mode      = "Subnet"
+  nat_type      = "public"
+  band_width    = 1
+  charge_type   = "DailyPaidByTransfer"
+  vpc_id        = "${ksyun_vpc.foo.id}"
+  nat_ip_number = 1
+}
+
+resource "ksyun_dnat_rules" "foo" {
+  nat_id = "${ksyun_nat.foo.id}"
+  nat_ip = "${ksyun_nat.foo.nat_ip_set.0.nat_ip}"
+
+  rule {
+    ip_protocol        = "UDP"
+    private_ip_address = "10.0.5.12"
+    public_port_from   = 27015
+    public_port_to     = 27020
+  }
+}
+`
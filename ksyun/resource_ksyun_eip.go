@@ -45,6 +45,7 @@ func resourceKsyunEip() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: eipQuotaCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"line_id": {
 				Type:        schema.TypeString,
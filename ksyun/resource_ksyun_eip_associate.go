@@ -1,5 +1,5 @@
 /*
-Provides an EIP Association resource for associating Elastic IP to UHost Instance, Load Balancer, etc.
+Provides an EIP Association resource for associating Elastic IP to UHost Instance, Load Balancer, HaVip, etc.
 
 Example Usage
 
@@ -15,6 +15,11 @@ resource "ksyun_eip_associate" "server" {
   instance_id="566567677-6766-4743-afb7-7c7081214092"
   network_interface_id="87945980-59659-04548-759045803"
 }
+resource "ksyun_eip_associate" "havip" {
+  allocation_id="419782b7-6766-4743-afb7-7c7081214092"
+  instance_type="HaVip"
+  instance_id="7385c8ea-79f7-4e9c-b99f-517fc3726256"
+}
 ```
 
 Import
@@ -57,21 +62,22 @@ func resourceKsyunEipAssociation() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{
 					"Ipfwd",
 					"Slb",
+					"HaVip",
 				}, false),
-				Description: "The type of the instance.Valid Values:'Ipfwd', 'Slb'.",
+				Description: "The type of the instance.Valid Values:'Ipfwd', 'Slb', 'HaVip'.",
 			},
 			"instance_id": {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
-				Description: "The id of the instance.",
+				Description: "The id of the instance, the id of the HaVip if instance_type is HaVip.",
 			},
 			"network_interface_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				ForceNew:    true,
 				Computed:    true,
-				Description: "The id of the network interface.",
+				Description: "The id of the network interface. It is the master network interface of the HaVip if instance_type is HaVip, which changes on a failover, so leave it unset for HaVip.",
 			},
 			"ip_version": {
 				Type:        schema.TypeString,
@@ -0,0 +1,114 @@
+/*
+Provides a high-availability virtual IP (HaVip) resource, a private IP of a subnet that floats between the network interfaces attached to it.
+
+The HaVip is announced by the master network interface, such as the one of the keepalived master, and moves to a slave
+one on failover. Attach the network interfaces with `ksyun_havip_attachment` and associate an EIP with it with
+`ksyun_eip_associate`.
+
+# Example Usage
+
+```hcl
+
+	resource "ksyun_havip" "default" {
+	  subnet_id  = "a8979fe2-cf1a-47b9-80f6-57445227c541"
+	  ip_address = "10.0.1.100"
+	}
+
+```
+
+# Import
+
+HaVip can be imported using the `id`, e.g.
+
+```
+$ terraform import ksyun_havip.default 7385c8ea-79f7-4e9c-b99f-517fc3726256
+```
+*/
+package ksyun
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceKsyunHaVip() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunHaVipCreate,
+		Read:   resourceKsyunHaVipRead,
+		Delete: resourceKsyunHaVipDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"subnet_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the subnet the HaVip is allocated in.",
+			},
+			"ip_address": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPAddress,
+				Description:  "The private IP address of the HaVip, a free IP address of the subnet is allocated if not set.",
+			},
+			"vpc_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the vpc.",
+			},
+			"master_network_interface_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the master network interface which the HaVip is announced by.",
+			},
+			"slave_network_interface_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the slave network interfaces.",
+			},
+			"allocation_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the EIP associated with the HaVip.",
+			},
+			"create_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The creation time of the HaVip.",
+			},
+		},
+	}
+}
+
+func resourceKsyunHaVipCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.CreateHaVip(d, resourceKsyunHaVip())
+	if err != nil {
+		return fmt.Errorf("error on creating havip %q, %s", d.Id(), err)
+	}
+	return resourceKsyunHaVipRead(d, meta)
+}
+
+func resourceKsyunHaVipRead(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ReadAndSetHaVip(d, resourceKsyunHaVip())
+	if err != nil {
+		return fmt.Errorf("error on reading havip %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunHaVipDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.RemoveHaVip(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting havip %q, %s", d.Id(), err)
+	}
+	return err
+}
@@ -0,0 +1,114 @@
+/*
+Provides a HaVip attachment resource, which attaches a network interface to a HaVip.
+
+Set `instance_id` to attach the primary network interface of a KEC instance or `network_interface_id` to attach a
+network interface directly. The first network interface attached is the master which announces the HaVip, the others
+are the slaves.
+
+# Example Usage
+
+```hcl
+
+	resource "ksyun_havip" "default" {
+	  subnet_id = "a8979fe2-cf1a-47b9-80f6-57445227c541"
+	}
+
+	resource "ksyun_havip_attachment" "master" {
+	  havip_id    = ksyun_havip.default.id
+	  instance_id = "566567677-6766-4743-afb7-7c7081214092"
+	}
+
+	resource "ksyun_havip_attachment" "backup" {
+	  havip_id             = ksyun_havip.default.id
+	  network_interface_id = "87945980-59659-04548-759045803"
+	}
+
+```
+
+# Import
+
+HaVip attachment can be imported using the `id`, the id format is `havip_id:network_interface_id`, e.g.
+
+```
+$ terraform import ksyun_havip_attachment.default 7385c8ea-79f7-4e9c-b99f-517fc3726256:87945980-59659-04548-759045803
+```
+*/
+package ksyun
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceKsyunHaVipAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunHaVipAttachmentCreate,
+		Read:   resourceKsyunHaVipAttachmentRead,
+		Delete: resourceKsyunHaVipAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: importHaVipAttachment,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"havip_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the HaVip.",
+			},
+			"instance_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"instance_id", "network_interface_id"},
+				Description:  "The ID of the KEC instance, the primary network interface of which is attached. Exactly one of `instance_id` and `network_interface_id` must be set.",
+			},
+			"network_interface_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"instance_id", "network_interface_id"},
+				Description:  "The ID of the network interface to attach. Exactly one of `instance_id` and `network_interface_id` must be set.",
+			},
+			"role": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The role of the network interface in the HaVip, `master` or `slave`.",
+			},
+		},
+	}
+}
+
+func resourceKsyunHaVipAttachmentCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.CreateHaVipAttachment(d)
+	if err != nil {
+		return fmt.Errorf("error on creating havip attachment %q, %s", d.Id(), err)
+	}
+	return resourceKsyunHaVipAttachmentRead(d, meta)
+}
+
+func resourceKsyunHaVipAttachmentRead(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ReadAndSetHaVipAttachment(d, resourceKsyunHaVipAttachment())
+	if err != nil {
+		return fmt.Errorf("error on reading havip attachment %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunHaVipAttachmentDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.RemoveHaVipAttachment(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting havip attachment %q, %s", d.Id(), err)
+	}
+	return err
+}
@@ -0,0 +1,101 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunHaVipAttachment_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "ksyun_havip_attachment.master",
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHaVipAttachmentConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("ksyun_havip_attachment.master"),
+					resource.TestCheckResourceAttr("ksyun_havip_attachment.master", "role", "master"),
+					resource.TestCheckResourceAttr("ksyun_havip_attachment.slave", "role", "slave"),
+				),
+			},
+			{
+				ResourceName:      "ksyun_havip_attachment.slave",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestHaVipNetworkInterfaceRole(t *testing.T) {
+	data := map[string]interface{}{
+		"HaVipId":                    "havip",
+		"MasterNetworkInterfaceId":   "nic-1",
+		"SlaveNetworkInterfaceIdSet": []interface{}{"nic-2", "nic-3"},
+	}
+	for nic, want := range map[string]string{
+		"nic-1": "master",
+		"nic-3": "slave",
+		"nic-4": "",
+		"":      "",
+	} {
+		if got := haVipNetworkInterfaceRole(data, nic); got != want {
+			t.Errorf("haVipNetworkInterfaceRole(%q) = %q, want %q", nic, got, want)
+		}
+	}
+}
+
+const testAccHaVipAttachmentConfig = `
+variable "available_zone" {
+  default = "cn-beijing-6a"
+}
+
+resource "ksyun_vpc" "default" {
+  vpc_name   = "tf-acc-havip-vpc"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_subnet" "default" {
+  subnet_name       = "tf-acc-havip-subnet"
+  cidr_block        = "10.7.0.0/21"
+  subnet_type       = "Normal"
+  vpc_id            = "${ksyun_vpc.default.id}"
+  availability_zone = "${var.available_zone}"
+}
+
+resource "ksyun_security_group" "default" {
+  vpc_id              = "${ksyun_vpc.default.id}"
+  security_group_name = "tf-acc-havip-sg"
+}
+
+resource "ksyun_kec_network_interface" "master" {
+  network_interface_name = "tf-acc-havip-master"
+  subnet_id              = "${ksyun_subnet.default.id}"
+  security_group_ids     = ["${ksyun_security_group.default.id}"]
+}
+
+resource "ksyun_kec_network_interface" "slave" {
+  network_interface_name = "tf-acc-havip-slave"
+  subnet_id              = "${ksyun_subnet.default.id}"
+  security_group_ids     = ["${ksyun_security_group.default.id}"]
+}
+
+resource "ksyun_havip" "default" {
+  subnet_id = "${ksyun_subnet.default.id}"
+}
+
+resource "ksyun_havip_attachment" "master" {
+  havip_id             = "${ksyun_havip.default.id}"
+  network_interface_id = "${ksyun_kec_network_interface.master.id}"
+}
+
+resource "ksyun_havip_attachment" "slave" {
+  havip_id             = "${ksyun_havip.default.id}"
+  network_interface_id = "${ksyun_kec_network_interface.slave.id}"
+  depends_on           = ["ksyun_havip_attachment.master"]
+}
+`
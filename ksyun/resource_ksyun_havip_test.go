@@ -0,0 +1,70 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunHaVip_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "ksyun_havip.foo",
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHaVipConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("ksyun_havip.foo"),
+					resource.TestCheckResourceAttr("ksyun_havip.foo", "ip_address", "10.7.0.100"),
+					resource.TestCheckResourceAttrSet("ksyun_havip.foo", "vpc_id"),
+					resource.TestCheckResourceAttrPair("ksyun_eip_associate.foo", "instance_id", "ksyun_havip.foo", "id"),
+				),
+			},
+			{
+				ResourceName:      "ksyun_havip.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the associated eip is read on refresh
+				ImportStateVerifyIgnore: []string{"allocation_id"},
+			},
+		},
+	})
+}
+
+const testAccHaVipConfig = `
+variable "available_zone" {
+  default = "cn-beijing-6a"
+}
+
+resource "ksyun_vpc" "default" {
+  vpc_name   = "tf-acc-havip-vpc"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_subnet" "default" {
+  subnet_name       = "tf-acc-havip-subnet"
+  cidr_block        = "10.7.0.0/21"
+  subnet_type       = "Normal"
+  vpc_id            = "${ksyun_vpc.default.id}"
+  availability_zone = "${var.available_zone}"
+}
+
+resource "ksyun_havip" "foo" {
+  subnet_id  = "${ksyun_subnet.default.id}"
+  ip_address = "10.7.0.100"
+}
+
+resource "ksyun_eip" "default" {
+  band_width  = 1
+  charge_type = "TrafficMonthly"
+}
+
+resource "ksyun_eip_associate" "foo" {
+  allocation_id = "${ksyun_eip.default.id}"
+  instance_type = "HaVip"
+  instance_id   = "${ksyun_havip.foo.id}"
+}
+`
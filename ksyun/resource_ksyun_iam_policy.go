@@ -43,9 +43,10 @@ func resourceKsyunIamPolicy() *schema.Resource {
 				Description: "IAM PolicyName.",
 			},
 			"policy_document": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "IAM PolicyDocument.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: iamPolicyDocumentDiffSuppressFunc,
+				Description:      "IAM PolicyDocument.",
 			},
 			"policy_krn": {
 				Type:        schema.TypeString,
@@ -0,0 +1,119 @@
+/*
+Provides an IAM project resource.
+
+~> **NOTE:** The IAM projects can not be deleted, destroying the resource disables the project.
+
+# Example Usage
+
+```hcl
+
+resource "ksyun_iam_project" "project" {
+  project_name = "ProjectNameTest"
+  project_desc = "desc"
+}
+
+resource "ksyun_eip" "default" {
+  line_id     = "cf0b3c08-a3b8-4a69-8c6b-0bf0a1b3b6f8"
+  band_width  = 1
+  charge_type = "PostPaidByDay"
+  project_id  = ksyun_iam_project.project.id
+}
+
+```
+
+# Import
+
+IAM project can be imported using the `project_id`, e.g.
+
+```
+$ terraform import ksyun_iam_project.project 103800
+```
+*/
+
+package ksyun
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceKsyunIamProject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunIamProjectCreate,
+		Read:   resourceKsyunIamProjectRead,
+		Update: resourceKsyunIamProjectUpdate,
+		Delete: resourceKsyunIamProjectDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the project.",
+			},
+			"project_desc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the project.",
+			},
+			"status": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The status of the project, `1` means enabled.",
+			},
+			"account_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the account owning the project.",
+			},
+			"krn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The KRN of the project.",
+			},
+			"create_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time when the project was created.",
+			},
+		},
+	}
+}
+
+func resourceKsyunIamProjectCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	iamProjectService := IamProjectService{meta.(*KsyunClient)}
+	err = iamProjectService.CreateIamProject(d, resourceKsyunIamProject())
+	if err != nil {
+		return fmt.Errorf("error on creating IAM project %q, %s", d.Get("project_name"), err)
+	}
+	return resourceKsyunIamProjectRead(d, meta)
+}
+
+func resourceKsyunIamProjectRead(d *schema.ResourceData, meta interface{}) (err error) {
+	iamProjectService := IamProjectService{meta.(*KsyunClient)}
+	err = iamProjectService.ReadAndSetIamProject(d, resourceKsyunIamProject())
+	if err != nil {
+		return fmt.Errorf("error on reading IAM project %q, %s", d.Id(), err)
+	}
+	return
+}
+
+func resourceKsyunIamProjectUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+	iamProjectService := IamProjectService{meta.(*KsyunClient)}
+	err = iamProjectService.ModifyIamProject(d, resourceKsyunIamProject())
+	if err != nil {
+		return fmt.Errorf("error on updating IAM project %q, %s", d.Id(), err)
+	}
+	return resourceKsyunIamProjectRead(d, meta)
+}
+
+func resourceKsyunIamProjectDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	iamProjectService := IamProjectService{meta.(*KsyunClient)}
+	err = iamProjectService.DeleteIamProject(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting IAM project %q, %s", d.Id(), err)
+	}
+	return
+}
@@ -0,0 +1,119 @@
+/*
+Provides an IAM project member resource, which grants an IAM user or role access to a project.
+
+# Example Usage
+
+```hcl
+
+resource "ksyun_iam_project" "project" {
+  project_name = "ProjectNameTest"
+}
+
+resource "ksyun_iam_project_member" "member" {
+  project_id    = ksyun_iam_project.project.id
+  identity_type = "user"
+  # the user_id of the IAM user
+  identity_id   = "2000084212"
+}
+
+```
+
+# Import
+
+IAM project member can be imported using the `project_id` and the `member_id` separated by a colon, e.g.
+
+```
+$ terraform import ksyun_iam_project_member.member 103800:2001
+```
+*/
+
+package ksyun
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceKsyunIamProjectMember() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunIamProjectMemberCreate,
+		Read:   resourceKsyunIamProjectMemberRead,
+		Delete: resourceKsyunIamProjectMemberDelete,
+		Importer: &schema.ResourceImporter{
+			State: importIamProjectMember,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the project.",
+			},
+			"identity_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"user",
+					"role",
+				}, false),
+				Description: "The type of the member. Valid values: `user`, `role`.",
+			},
+			"identity_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the IAM user or role.",
+			},
+			"member_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the member in the project.",
+			},
+			"identity_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the IAM user or role.",
+			},
+			"krn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The KRN of the IAM user or role.",
+			},
+			"create_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time when the member was added.",
+			},
+		},
+	}
+}
+
+func resourceKsyunIamProjectMemberCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	iamProjectService := IamProjectService{meta.(*KsyunClient)}
+	err = iamProjectService.CreateIamProjectMember(d, resourceKsyunIamProjectMember())
+	if err != nil {
+		return fmt.Errorf("error on adding member %q to IAM project %q, %s", d.Get("identity_id"), d.Get("project_id"), err)
+	}
+	return resourceKsyunIamProjectMemberRead(d, meta)
+}
+
+func resourceKsyunIamProjectMemberRead(d *schema.ResourceData, meta interface{}) (err error) {
+	iamProjectService := IamProjectService{meta.(*KsyunClient)}
+	err = iamProjectService.ReadAndSetIamProjectMember(d, resourceKsyunIamProjectMember())
+	if err != nil {
+		return fmt.Errorf("error on reading IAM project member %q, %s", d.Id(), err)
+	}
+	return
+}
+
+func resourceKsyunIamProjectMemberDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	iamProjectService := IamProjectService{meta.(*KsyunClient)}
+	err = iamProjectService.DeleteIamProjectMember(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting IAM project member %q, %s", d.Id(), err)
+	}
+	return
+}
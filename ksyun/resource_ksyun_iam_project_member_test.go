@@ -0,0 +1,51 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunIamProjectMember_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIAMProjectMemberConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("ksyun_iam_project_member.member"),
+					resource.TestCheckResourceAttr("ksyun_iam_project_member.member", "identity_type", "user"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseIamProjectMemberId(t *testing.T) {
+	projectId, memberId, err := parseIamProjectMemberId("103800:2001")
+	if err != nil || projectId != "103800" || memberId != "2001" {
+		t.Errorf("unexpected result %q, %q, %v", projectId, memberId, err)
+	}
+	for _, id := range []string{"", "103800", "103800:", ":2001", "1:2:3"} {
+		if _, _, err := parseIamProjectMemberId(id); err == nil {
+			t.Errorf("expected an error for the id %q", id)
+		}
+	}
+}
+
+const testAccIAMProjectMemberConfig = `
+data "ksyun_iam_users" "users" {
+}
+
+resource "ksyun_iam_project" "project" {
+  project_name = "ProjectMemberTest"
+}
+
+resource "ksyun_iam_project_member" "member" {
+  project_id    = ksyun_iam_project.project.id
+  identity_type = "user"
+  identity_id   = data.ksyun_iam_users.users.users.0.user_id
+}`
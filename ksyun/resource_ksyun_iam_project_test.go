@@ -0,0 +1,43 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunIamProject_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIAMProjectConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("ksyun_iam_project.project"),
+					resource.TestCheckResourceAttr("ksyun_iam_project.project", "status", "1"),
+				),
+			},
+			{
+				Config: testAccIAMProjectUpdateConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_iam_project.project", "project_name", "ProjectNameTestUpdate"),
+				),
+			},
+		},
+	})
+}
+
+const testAccIAMProjectConfig = `
+resource "ksyun_iam_project" "project" {
+  project_name = "ProjectNameTest"
+  project_desc = "desc"
+}`
+
+const testAccIAMProjectUpdateConfig = `
+resource "ksyun_iam_project" "project" {
+  project_name = "ProjectNameTestUpdate"
+  project_desc = "desc update"
+}`
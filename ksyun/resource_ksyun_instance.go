@@ -359,6 +359,18 @@ func instanceConfig() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "DNS2 of the primary network interface.",
 		},
+		"is_distribute_ipv6": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     false,
+			Description: "Whether to assign an IPv6 address to the primary network interface, the subnet must have an IPv6 CIDR block.",
+		},
+		"ipv6_address": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The IPv6 address of the primary network interface.",
+		},
 		"tags": tagsSchema(),
 		// "has_init_info": {
 		//	Type:     schema.TypeBool,
@@ -450,6 +462,7 @@ func resourceKsyunInstance() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
+		CustomizeDiff: instanceQuotaCustomizeDiff,
 		Schema: func() map[string]*schema.Schema {
 			s := instanceConfig()
 			s["image_id"].ConflictsWith = []string{"model_id"}
@@ -0,0 +1,117 @@
+/*
+Provides an IPv6 public IP resource, which opens the IPv6 address of a network interface to the internet with a public bandwidth.
+
+# Example Usage
+
+```hcl
+
+	resource "ksyun_instance" "default" {
+	  # ...
+	  subnet_id          = ksyun_subnet.default.id
+	  is_distribute_ipv6 = true
+	}
+
+	resource "ksyun_ipv6_public_ip" "default" {
+	  network_interface_id   = ksyun_instance.default.network_interface_id
+	  ipv6_public_ip_address = ksyun_instance.default.ipv6_address
+	  band_width             = 1
+	  charge_type            = "Daily"
+	}
+
+```
+*/
+package ksyun
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceKsyunIpv6PublicIp() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunIpv6PublicIpCreate,
+		Read:   resourceKsyunIpv6PublicIpRead,
+		Update: resourceKsyunIpv6PublicIpUpdate,
+		Delete: resourceKsyunIpv6PublicIpDelete,
+		Schema: map[string]*schema.Schema{
+			"network_interface_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the network interface which owns the IPv6 address.",
+			},
+			"ipv6_public_ip_address": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPv6Address,
+				Description:  "The IPv6 address of the network interface to open to the internet, the IPv6 address of the network interface by default.",
+			},
+			"band_width": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The public bandwidth of the IPv6 address in Mbps.",
+			},
+			"charge_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: chargeSchemaDiffSuppressFunc,
+				Description:      "The charge type of the IPv6 public bandwidth, such as `Daily`, `Peak` or `Monthly`.",
+			},
+			"purchase_time": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: purchaseTimeDiffSuppressFunc,
+				ValidateFunc:     validation.IntBetween(0, 36),
+				Description:      "Purchase time. If charge_type is Monthly or PrePaidByMonth, this is Required.",
+			},
+			"create_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The creation time of the IPv6 public IP.",
+			},
+		},
+	}
+}
+
+func resourceKsyunIpv6PublicIpCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.CreateIpv6PublicIp(d, resourceKsyunIpv6PublicIp())
+	if err != nil {
+		return fmt.Errorf("error on creating ipv6 public ip %q, %s", d.Id(), err)
+	}
+	return resourceKsyunIpv6PublicIpRead(d, meta)
+}
+
+func resourceKsyunIpv6PublicIpRead(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ReadAndSetIpv6PublicIp(d, resourceKsyunIpv6PublicIp())
+	if err != nil {
+		return fmt.Errorf("error on reading ipv6 public ip %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunIpv6PublicIpUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ModifyIpv6PublicIp(d, resourceKsyunIpv6PublicIp())
+	if err != nil {
+		return fmt.Errorf("error on updating ipv6 public ip %q, %s", d.Id(), err)
+	}
+	return resourceKsyunIpv6PublicIpRead(d, meta)
+}
+
+func resourceKsyunIpv6PublicIpDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.RemoveIpv6PublicIp(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting ipv6 public ip %q, %s", d.Id(), err)
+	}
+	return err
+}
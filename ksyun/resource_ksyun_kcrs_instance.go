@@ -63,7 +63,7 @@ func resourceKsyunKcrsInstance() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     0,
-				Description: "The id of the project.",
+				Description: "The id of the project, changing it moves the instance to the project.",
 			},
 			"instance_name": {
 				Type:        schema.TypeString,
@@ -184,15 +184,20 @@ func resourceKsyunKcrsInstanceRead(d *schema.ResourceData, meta interface{}) (er
 func resourceKsyunKcrsInstanceUpdate(d *schema.ResourceData, meta interface{}) (err error) {
 	kcrsInstanceService := KcrsService{meta.(*KsyunClient)}
 
+	if d.HasChange("project_id") {
+		err = kcrsInstanceService.ModifyKcrsInstanceProject(d, resourceKsyunKcrsInstance())
+		if err != nil {
+			return fmt.Errorf("an error caused when moving instance %q to another project, %s", d.Id(), err)
+		}
+	}
+
 	if d.HasChanges("open_public_operation", "external_policy") {
-		err := kcrsInstanceService.ModifyKcrsInstanceEoIEndpoint(d, resourceKsyunKcrsInstance())
+		err = kcrsInstanceService.ModifyKcrsInstanceEoIEndpoint(d, resourceKsyunKcrsInstance())
 		if err != nil {
 			return fmt.Errorf("an error caused when changing instance external endpoint status or policy %q, %s", d.Id(), err)
 		}
-		return resourceKsyunKcrsInstanceRead(d, meta)
 	}
-
-	return fmt.Errorf("the attributes, 'project_id', are not supported to modify")
+	return resourceKsyunKcrsInstanceRead(d, meta)
 }
 
 func resourceKsyunKcrsInstanceDelete(d *schema.ResourceData, meta interface{}) (err error) {
@@ -96,6 +96,20 @@ func resourceKsyunKecNetworkInterface() *schema.Resource {
 				Computed:    true,
 				Description: "The instance id to bind with the network interface.",
 			},
+
+			"is_distribute_ipv6": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether to assign an IPv6 address to the network interface, the subnet must have an IPv6 CIDR block.",
+			},
+
+			"ipv6_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IPv6 address of the network interface, it is assigned when `is_distribute_ipv6` is true.",
+			},
 		},
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	_ "github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
@@ -168,3 +169,22 @@ resource "ksyun_kec_network_interface" "foo" {
   }
 }
 `
+
+func TestNetworkInterfaceCreateRequest(t *testing.T) {
+	r := resourceKsyunKecNetworkInterface()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"subnet_id":          "subnet-1",
+		"security_group_ids": []interface{}{"sg-1"},
+		"is_distribute_ipv6": true,
+	})
+	req, err := networkInterfaceCreateRequest(d, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req["DistributeIpv6"] != true || req["SubnetId"] != "subnet-1" || req["SecurityGroupId.1"] != "sg-1" {
+		t.Errorf("unexpected request %v", req)
+	}
+	if _, ok := req["IsDistributeIpv6"]; ok {
+		t.Errorf("is_distribute_ipv6 is not mapped to DistributeIpv6, %v", req)
+	}
+}
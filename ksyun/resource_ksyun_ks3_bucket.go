@@ -468,7 +468,9 @@ func resourceKsyunKs3BucketRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("bucket", d.Id())
 	d.Set("acl", object.BucketInfo.ACL)
 	d.Set("creation_date", object.BucketInfo.CreationDate.Format("2006-01-02"))
-	d.Set("region", object.BucketInfo.Region)
+	if region, ok := ks3BucketRegion(object.BucketInfo); ok {
+		d.Set("region", region)
+	}
 	d.Set("owner", object.BucketInfo.Owner.ID)
 	d.Set("storage_class", object.BucketInfo.StorageClass)
 
@@ -1139,3 +1141,12 @@ func abortMulHash(v interface{}) int {
 	}
 	return hashcode.String(buf.String())
 }
+
+// ks3BucketRegion returns the region of the bucket when it is one of ks3RegionEndpoints, either
+// the region of the bucket itself or the one served by its extranet endpoint
+func ks3BucketRegion(info ks3.BucketInfo) (string, bool) {
+	if _, ok := ks3RegionEndpoints[info.Region]; ok {
+		return info.Region, true
+	}
+	return ks3EndpointRegion(info.ExtranetEndpoint)
+}
@@ -39,6 +39,7 @@ func resourceKsyunLb() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: lbQuotaCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"vpc_id": {
 				Type:        schema.TypeString,
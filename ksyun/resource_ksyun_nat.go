@@ -101,7 +101,7 @@ func resourceKsyunNat() *schema.Resource {
 				Optional:     true,
 				Default:      1,
 				ValidateFunc: validation.IntBetween(1, 20),
-				Description:  "The Counts of Nat Ip, value range:[1, 20], Default is 1.",
+				Description:  "The Counts of Nat Ip, value range:[1, 20], Default is 1. It counts the IPs added by `ksyun_nat_ip`, ignore its changes if `ksyun_nat_ip` is used.",
 			},
 
 			"band_width": {
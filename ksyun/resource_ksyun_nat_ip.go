@@ -0,0 +1,106 @@
+/*
+Provides a NAT IP resource, which adds a public IP to a NAT.
+
+The `nat_ip_number` of `ksyun_nat` counts the IPs added by this resource, so ignore its changes as in the example.
+
+# Example Usage
+
+```hcl
+
+	resource "ksyun_nat" "default" {
+	  nat_name    = "tf-nat"
+	  nat_mode    = "Subnet"
+	  nat_type    = "public"
+	  band_width  = 1
+	  charge_type = "DailyPaidByTransfer"
+	  vpc_id      = "a8979fe2-cf1a-47b9-80f6-57445227c541"
+
+	  lifecycle {
+	    ignore_changes = [nat_ip_number]
+	  }
+	}
+
+	resource "ksyun_nat_ip" "default" {
+	  nat_id = ksyun_nat.default.id
+	}
+
+```
+
+# Import
+
+NAT IP can be imported using the `id`, the id format must be `{nat_id}:{nat_ip_id}`, e.g.
+
+```
+$ terraform import ksyun_nat_ip.default $nat_id:$nat_ip_id
+```
+*/
+
+package ksyun
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceKsyunNatIp() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunNatIpCreate,
+		Read:   resourceKsyunNatIpRead,
+		Delete: resourceKsyunNatIpDelete,
+		Importer: &schema.ResourceImporter{
+			State: importNatIp,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"nat_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the NAT.",
+			},
+			"nat_ip_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the NAT IP.",
+			},
+			"nat_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The public IP address.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the NAT IP is enabled.",
+			},
+		},
+	}
+}
+
+func resourceKsyunNatIpCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.CreateNatIp(d, resourceKsyunNatIp())
+	if err != nil {
+		return fmt.Errorf("error on adding nat ip to nat %q, %s", d.Get("nat_id"), err)
+	}
+	return resourceKsyunNatIpRead(d, meta)
+}
+
+func resourceKsyunNatIpRead(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ReadAndSetNatIp(d, resourceKsyunNatIp())
+	if err != nil {
+		return fmt.Errorf("error on reading nat ip %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunNatIpDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.RemoveNatIp(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting nat ip %q, %s", d.Id(), err)
+	}
+	return err
+}
@@ -0,0 +1,82 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunNatIp_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "ksyun_nat_ip.foo",
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNatIpConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("ksyun_nat_ip.foo"),
+					testAccCheckIDExists("ksyun_nat_ip.bar"),
+					resource.TestCheckResourceAttrSet("ksyun_nat_ip.foo", "nat_ip"),
+					resource.TestCheckResourceAttr("ksyun_nat.foo", "nat_ip_set.#", "3"),
+				),
+			},
+			{
+				ResourceName:      "ksyun_nat_ip.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestNatIpAddedId(t *testing.T) {
+	natIpSet := []interface{}{
+		map[string]interface{}{"NatIpId": "ip-1", "NatIp": "1.1.1.1"},
+		map[string]interface{}{"NatIpId": "ip-2", "NatIp": "1.1.1.2"},
+	}
+	existing := natIpIds(map[string]interface{}{"NatIpSet": natIpSet[:1]})
+	if len(existing) != 1 || existing[0] != "ip-1" {
+		t.Fatalf("natIpIds() = %v, want ip-1", existing)
+	}
+	id, err := natIpAddedId(existing, natIpSet)
+	if err != nil || id != "ip-2" {
+		t.Errorf("natIpAddedId() = %q, %v, want ip-2", id, err)
+	}
+	if _, err = natIpAddedId([]string{"ip-1", "ip-2"}, natIpSet); err == nil {
+		t.Errorf("natIpAddedId() returns no error when no nat ip is added")
+	}
+	if _, err = natIpAddedId(nil, natIpSet); err == nil {
+		t.Errorf("natIpAddedId() returns no error when more than one nat ip is added")
+	}
+}
+
+const testAccNatIpConfig = `
+resource "ksyun_vpc" "foo" {
+  vpc_name   = "tf-acc-nat-ip-vpc"
+  cidr_block = "10.0.5.0/24"
+}
+
+resource "ksyun_nat" "foo" {
+  nat_name    = "tf-acc-nat-ip"
+  nat_mode    = "Subnet"
+  nat_type    = "public"
+  band_width  = 1
+  charge_type = "DailyPaidByTransfer"
+  vpc_id      = "${ksyun_vpc.foo.id}"
+
+  lifecycle {
+    ignore_changes = [nat_ip_number]
+  }
+}
+
+resource "ksyun_nat_ip" "foo" {
+  nat_id = "${ksyun_nat.foo.id}"
+}
+
+resource "ksyun_nat_ip" "bar" {
+  nat_id = "${ksyun_nat.foo.id}"
+}
+`
@@ -66,7 +66,7 @@ func resourceKsyunNetworkAcl() *schema.Resource {
 					Schema: entry,
 				},
 				Set:         networkAclEntryHash,
-				Description: "Network ACL Entries. this parameter will be deprecated, use `ksyun_network_acl_entry` instead.",
+				Description: "Network ACL Entries. this parameter will be deprecated, use `ksyun_network_acl_entry` or `ksyun_network_acl_rules` instead.",
 			},
 			"network_acl_id": {
 				Type:        schema.TypeString,
@@ -55,7 +55,8 @@ func resourceKsyunNetworkAclEntry() *schema.Resource {
 					validation.StringIsEmpty,
 					validation.IsCIDR,
 				),
-				Description: "The cidr_block of the network acl entry.",
+				DiffSuppressFunc: cidrBlockDiffSuppressFunc,
+				Description:      "The cidr_block of the network acl entry, an IPv4 or IPv6 CIDR block such as `::/0`.",
 			},
 			"rule_number": {
 				Type:         schema.TypeInt,
@@ -92,23 +93,24 @@ func resourceKsyunNetworkAclEntry() *schema.Resource {
 					"tcp",
 					"udp",
 					"icmp",
+					"icmpv6",
 				}, false),
 				ForceNew:    true,
-				Description: "The protocol of the network acl entry.Valid Values: 'ip','icmp','tcp','udp'.",
+				Description: "The protocol of the network acl entry.Valid Values: 'ip','icmp','icmpv6','tcp','udp'. 'icmpv6' is for the entries of an ipv6 cidr_block.",
 			},
 			"icmp_type": {
 				Type:             schema.TypeInt,
 				Optional:         true,
 				ForceNew:         true,
 				DiffSuppressFunc: networkAclEntryDiffSuppressFunc,
-				Description:      "The icmp_type of the network acl entry.If protocol is icmp, Required.",
+				Description:      "The icmp_type of the network acl entry.If protocol is icmp or icmpv6, Required.",
 			},
 			"icmp_code": {
 				Type:             schema.TypeInt,
 				Optional:         true,
 				ForceNew:         true,
 				DiffSuppressFunc: networkAclEntryDiffSuppressFunc,
-				Description:      "The icmp_code of the network acl entry.If protocol is icmp, Required.",
+				Description:      "The icmp_code of the network acl entry.If protocol is icmp or icmpv6, Required.",
 			},
 			"port_range_from": {
 				Type:             schema.TypeInt,
@@ -0,0 +1,221 @@
+/*
+Provides an authoritative set of the entries of a network acl.
+
+The entries of the network acl are exactly the `ingress` and `egress` entries, the entries added out of band, such as in
+the console, are deleted on the next apply and the deleted ones are created again. The entries are listed in priority
+order. An entry without `rule_number` keeps the rule number of the existing entry with the same content if it still
+fits between the entries around it, so inserting an entry does not renumber the entries after it. The other entries
+without `rule_number` are numbered after the entry before it by `rule_number_step`, or between the entries around them
+when the gap is narrower. The entries that duplicate or are shadowed by an entry with a higher priority are rejected on
+plan. The new entries are created before the stale ones are deleted, an entry taking the rule number of a stale entry
+replaces it. Do not use this resource with
+`ksyun_network_acl_entry` or the `network_acl_entries` of `ksyun_network_acl` on the same network acl.
+
+# Example Usage
+
+```hcl
+
+	resource "ksyun_network_acl" "default" {
+	  vpc_id           = "a8979fe2-cf1a-47b9-80f6-57445227c541"
+	  network_acl_name = "tf-network-acl"
+	}
+
+	resource "ksyun_network_acl_rules" "default" {
+	  network_acl_id = ksyun_network_acl.default.id
+
+	  ingress {
+	    cidr_block      = "10.0.1.0/24"
+	    rule_action     = "deny"
+	    protocol        = "tcp"
+	    port_range_from = 22
+	    port_range_to   = 22
+	  }
+	  ingress {
+	    cidr_block  = "10.0.0.0/16"
+	    rule_action = "allow"
+	    protocol    = "ip"
+	    description = "vpc"
+	  }
+	  ingress {
+	    rule_number = 1000
+	    cidr_block  = "0.0.0.0/0"
+	    rule_action = "allow"
+	    protocol    = "icmp"
+	    icmp_type   = 8
+	    icmp_code   = 0
+	  }
+
+	  egress {
+	    cidr_block  = "0.0.0.0/0"
+	    rule_action = "allow"
+	    protocol    = "ip"
+	  }
+	}
+
+```
+
+# Import
+
+The entries of a network acl can be imported using the id of the network acl, e.g.
+
+```
+$ terraform import ksyun_network_acl_rules.default 7385c8ea-79f7-4e9c-b99f-517fc3726256
+```
+*/
+package ksyun
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func networkAclRuleResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"rule_number": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateFunc:     validation.IntBetween(1, 32766),
+				DiffSuppressFunc: networkAclRuleNumberDiffSuppressFunc,
+				Description:      "The rule number of the entry, value range:[1,32766]. It must be greater than the rule number of the entry before it, the rule number of the existing entry with the same content or the rule number of the entry before it plus `rule_number_step` if not set.",
+			},
+			"cidr_block": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.IsCIDR,
+				DiffSuppressFunc: cidrBlockDiffSuppressFunc,
+				Description:      "The cidr_block of the entry, an IPv4 or IPv6 CIDR block such as `::/0`.",
+			},
+			"rule_action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"allow",
+					"deny",
+				}, false),
+				Description: "The rule_action of the entry. Valid Values: 'allow','deny'.",
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"ip",
+					"tcp",
+					"udp",
+					"icmp",
+					"icmpv6",
+				}, false),
+				Description: "The protocol of the entry. Valid Values: 'ip','icmp','icmpv6','tcp','udp'. 'icmpv6' is for the entries of an ipv6 cidr_block.",
+			},
+			"icmp_type": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DiffSuppressFunc: networkAclRuleDiffSuppressFunc,
+				Description:      "The icmp_type of the entry. If protocol is icmp or icmpv6, Required.",
+			},
+			"icmp_code": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DiffSuppressFunc: networkAclRuleDiffSuppressFunc,
+				Description:      "The icmp_code of the entry. If protocol is icmp or icmpv6, Required.",
+			},
+			"port_range_from": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateFunc:     validation.IntBetween(1, 65535),
+				DiffSuppressFunc: networkAclRuleDiffSuppressFunc,
+				Description:      "The port_range_from of the entry. If protocol is tcp or udp, Required.",
+			},
+			"port_range_to": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateFunc:     validation.IntBetween(1, 65535),
+				DiffSuppressFunc: networkAclRuleDiffSuppressFunc,
+				Description:      "The port_range_to of the entry. If protocol is tcp or udp, Required.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the entry.",
+			},
+		},
+	}
+}
+
+func resourceKsyunNetworkAclRules() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunNetworkAclRulesCreate,
+		Read:   resourceKsyunNetworkAclRulesRead,
+		Update: resourceKsyunNetworkAclRulesUpdate,
+		Delete: resourceKsyunNetworkAclRulesDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: networkAclRulesCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"network_acl_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the network acl.",
+			},
+			"rule_number_step": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(1, 1000),
+				Description:  "The step between the rule numbers of the entries without `rule_number`, the first of them is numbered with the step. Default is 10.",
+			},
+			"ingress": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        networkAclRuleResource(),
+				Description: "The inbound entries of the network acl in priority order, an empty list deletes every inbound entry.",
+			},
+			"egress": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        networkAclRuleResource(),
+				Description: "The outbound entries of the network acl in priority order, an empty list deletes every outbound entry.",
+			},
+		},
+	}
+}
+
+func resourceKsyunNetworkAclRulesCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ApplyNetworkAclRules(d)
+	if err != nil {
+		return fmt.Errorf("error on creating network acl rules %q, %s", d.Id(), err)
+	}
+	return resourceKsyunNetworkAclRulesRead(d, meta)
+}
+
+func resourceKsyunNetworkAclRulesRead(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ReadAndSetNetworkAclRules(d)
+	if err != nil {
+		return fmt.Errorf("error on reading network acl rules %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunNetworkAclRulesUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ApplyNetworkAclRules(d)
+	if err != nil {
+		return fmt.Errorf("error on updating network acl rules %q, %s", d.Id(), err)
+	}
+	return resourceKsyunNetworkAclRulesRead(d, meta)
+}
+
+func resourceKsyunNetworkAclRulesDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.RemoveNetworkAclRules(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting network acl rules %q, %s", d.Id(), err)
+	}
+	return err
+}
@@ -0,0 +1,470 @@
+package ksyun
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccKsyunNetworkAclRules_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "ksyun_network_acl_rules.foo",
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkAclRulesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("ksyun_network_acl_rules.foo"),
+					resource.TestCheckResourceAttr("ksyun_network_acl_rules.foo", "ingress.#", "2"),
+					resource.TestCheckResourceAttr("ksyun_network_acl_rules.foo", "ingress.1.rule_number", "0"),
+					resource.TestCheckResourceAttr("ksyun_network_acl_rules.foo", "egress.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "ksyun_network_acl_rules.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccKsyunNetworkAclRules_update(t *testing.T) {
+	var networkAclId string
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "ksyun_network_acl_rules.foo",
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkAclRulesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccGetSecurityGroupRulesId("ksyun_network_acl_rules.foo", &networkAclId),
+					resource.TestCheckResourceAttr("ksyun_network_acl_rules.foo", "ingress.#", "2"),
+				),
+			},
+			{
+				// an entry added out of band is deleted
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*KsyunClient)
+					_, err := client.vpcconn.CreateNetworkAclEntry(&map[string]interface{}{
+						"NetworkAclId": networkAclId,
+						"CidrBlock":    "10.7.112.0/24",
+						"RuleNumber":   5,
+						"Direction":    "in",
+						"RuleAction":   "allow",
+						"Protocol":     "ip",
+					})
+					if err != nil {
+						t.Fatalf("error on adding an out of band entry: %s", err)
+					}
+				},
+				Config: testAccNetworkAclRulesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_network_acl_rules.foo", "ingress.#", "2"),
+				),
+			},
+			{
+				Config: testAccNetworkAclRulesUpdateConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_network_acl_rules.foo", "ingress.#", "3"),
+					resource.TestCheckResourceAttr("ksyun_network_acl_rules.foo", "egress.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testNetworkAclRule(cidr string, action string, protocol string, from int, to int) map[string]interface{} {
+	return map[string]interface{}{
+		"rule_number":     0,
+		"cidr_block":      cidr,
+		"rule_action":     action,
+		"protocol":        protocol,
+		"port_range_from": from,
+		"port_range_to":   to,
+		"icmp_type":       0,
+		"icmp_code":       0,
+		"description":     "",
+	}
+}
+
+func TestExpandNetworkAclRules(t *testing.T) {
+	explicit := testNetworkAclRule("10.0.0.0/16", "allow", "ip", 0, 0)
+	explicit["rule_number"] = 100
+	rules, err := expandNetworkAclRules("ingress", "in", []interface{}{
+		testNetworkAclRule("10.0.1.0/24", "deny", "tcp", 22, 22),
+		explicit,
+		testNetworkAclRule("0.0.0.0/0", "allow", "udp", 53, 53),
+	}, 10, nil)
+	if err != nil {
+		t.Fatalf("expandNetworkAclRules() returned error: %s", err)
+	}
+	for i, want := range []int{10, 100, 110} {
+		if rules[i].ruleNumber != want {
+			t.Errorf("ingress.%d is numbered %d, want %d", i, rules[i].ruleNumber, want)
+		}
+	}
+
+	explicit["rule_number"] = 5
+	_, err = expandNetworkAclRules("ingress", "in", []interface{}{
+		testNetworkAclRule("10.0.1.0/24", "deny", "tcp", 22, 22),
+		explicit,
+	}, 10, nil)
+	if err == nil || !strings.Contains(err.Error(), "priority order") {
+		t.Errorf("an entry numbered before the entry above it is accepted, error: %v", err)
+	}
+}
+
+func TestValidateNetworkAclRules(t *testing.T) {
+	echoRequest := testNetworkAclRule("::/0", "allow", "icmpv6", 0, 0)
+	echoRequest["icmp_type"] = 128
+	echoReply := testNetworkAclRule("2001:db8::/64", "deny", "icmpv6", 0, 0)
+	echoReply["icmp_type"] = 129
+
+	cases := []struct {
+		name     string
+		entries  []interface{}
+		shadowed bool
+	}{
+		{
+			name: "narrow deny before broad allow",
+			entries: []interface{}{
+				testNetworkAclRule("10.0.1.0/24", "deny", "tcp", 22, 22),
+				testNetworkAclRule("10.0.0.0/16", "allow", "ip", 0, 0),
+			},
+		},
+		{
+			name: "broad allow before narrow deny",
+			entries: []interface{}{
+				testNetworkAclRule("10.0.0.0/16", "allow", "ip", 0, 0),
+				testNetworkAclRule("10.0.1.0/24", "deny", "tcp", 22, 22),
+			},
+			shadowed: true,
+		},
+		{
+			name: "duplicated entry",
+			entries: []interface{}{
+				testNetworkAclRule("10.0.1.0/24", "allow", "tcp", 80, 443),
+				testNetworkAclRule("10.0.1.0/24", "allow", "tcp", 80, 443),
+			},
+			shadowed: true,
+		},
+		{
+			name: "port range partly covered",
+			entries: []interface{}{
+				testNetworkAclRule("10.0.0.0/16", "allow", "tcp", 80, 443),
+				testNetworkAclRule("10.0.1.0/24", "deny", "tcp", 22, 443),
+			},
+		},
+		{
+			name: "other protocol",
+			entries: []interface{}{
+				testNetworkAclRule("0.0.0.0/0", "allow", "tcp", 1, 65535),
+				testNetworkAclRule("10.0.1.0/24", "deny", "udp", 53, 53),
+			},
+		},
+		{
+			name: "other address family",
+			entries: []interface{}{
+				testNetworkAclRule("0.0.0.0/0", "allow", "ip", 0, 0),
+				testNetworkAclRule("::/0", "allow", "ip", 0, 0),
+			},
+		},
+		{
+			name:    "other icmpv6 type",
+			entries: []interface{}{echoRequest, echoReply},
+		},
+		{
+			name:     "same icmpv6 type",
+			entries:  []interface{}{echoRequest, echoRequest},
+			shadowed: true,
+		},
+	}
+	for _, c := range cases {
+		rules, err := expandNetworkAclRules("ingress", "in", c.entries, 10, nil)
+		if err != nil {
+			t.Fatalf("%s: expandNetworkAclRules() returned error: %s", c.name, err)
+		}
+		err = validateNetworkAclRules("ingress", rules)
+		if c.shadowed != (err != nil) {
+			t.Errorf("%s: validateNetworkAclRules() = %v, want shadowed %t", c.name, err, c.shadowed)
+		}
+	}
+}
+
+func TestNetworkAclRuleIcmpv6(t *testing.T) {
+	protocol := networkAclRuleResource().Schema["protocol"]
+	if _, errs := protocol.ValidateFunc("icmpv6", "protocol"); len(errs) != 0 {
+		t.Errorf("the protocol icmpv6 is rejected: %v", errs)
+	}
+	rule := networkAclRule{protocol: "icmpv6", cidrBlock: "2001:db8::/64", icmpType: 128, portRangeFrom: 80}
+	rule.normalize()
+	if rule.icmpType != 128 || rule.portRangeFrom != 0 {
+		t.Errorf("an icmpv6 rule is normalized to %+v, want the icmp type kept and the ports cleared", rule)
+	}
+}
+
+func TestFlattenNetworkAclRules(t *testing.T) {
+	ingress, egress := networkAclRulesFromEntrySet([]interface{}{
+		map[string]interface{}{
+			"NetworkAclEntryId": "entry-3",
+			"RuleNumber":        float64(100),
+			"CidrBlock":         "10.0.0.0/16",
+			"Direction":         "in",
+			"RuleAction":        "allow",
+			"Protocol":          "ip",
+		},
+		map[string]interface{}{
+			"NetworkAclEntryId": "entry-1",
+			"RuleNumber":        float64(10),
+			"CidrBlock":         "10.0.1.0/24",
+			"Direction":         "in",
+			"RuleAction":        "deny",
+			"Protocol":          "tcp",
+			"PortRangeFrom":     float64(22),
+			"PortRangeTo":       float64(22),
+		},
+		map[string]interface{}{
+			"NetworkAclEntryId": "entry-2",
+			"RuleNumber":        float64(110),
+			"CidrBlock":         "0.0.0.0/0",
+			"Direction":         "in",
+			"RuleAction":        "allow",
+			"Protocol":          "udp",
+			"PortRangeFrom":     float64(53),
+			"PortRangeTo":       float64(53),
+		},
+		map[string]interface{}{
+			"NetworkAclEntryId": "default",
+			"RuleNumber":        float64(32767),
+			"CidrBlock":         "0.0.0.0/0",
+			"Direction":         "out",
+			"RuleAction":        "deny",
+			"Protocol":          "ip",
+		},
+	})
+	if len(ingress) != 3 || len(egress) != 0 {
+		t.Fatalf("networkAclRulesFromEntrySet() returned %d ingress and %d egress entries, want 3 and 0", len(ingress), len(egress))
+	}
+	entries := flattenNetworkAclRules(ingress, 10)
+	for i, want := range []int{0, 100, 0} {
+		if got := entries[i].(map[string]interface{})["rule_number"]; got != want {
+			t.Errorf("ingress.%d.rule_number = %v, want %d", i, got, want)
+		}
+	}
+
+	// the flattened entries are numbered as the remote ones, so nothing changes
+	desired, err := expandNetworkAclRules("ingress", "in", entries, 10, ingress)
+	if err != nil {
+		t.Fatalf("expandNetworkAclRules() returned error: %s", err)
+	}
+	calls, err := (&VpcService{}).networkAclRulesDeltaCalls("acl", ingress, desired)
+	if err != nil || len(calls) != 0 {
+		t.Errorf("networkAclRulesDeltaCalls() returned %d calls and error %v, want none", len(calls), err)
+	}
+
+	// inserting an entry first numbers it below the entry numbered 10, which keeps its number
+	desired, _ = expandNetworkAclRules("ingress", "in", append([]interface{}{
+		testNetworkAclRule("10.0.2.0/24", "deny", "ip", 0, 0),
+	}, entries...), 10, ingress)
+	calls, _ = (&VpcService{}).networkAclRulesDeltaCalls("acl", ingress, desired)
+	var actions []string
+	for _, call := range calls {
+		actions = append(actions, call.action)
+	}
+	if got := strings.Join(actions, ","); got != "CreateNetworkAclEntry" || desired[0].ruleNumber != 5 {
+		t.Errorf("networkAclRulesDeltaCalls() = %s with the new entry numbered %d, want only the new entry created as 5", got, desired[0].ruleNumber)
+	}
+
+	// a removed entry is deleted after the new ones are created
+	ntp := testNetworkAclRule("0.0.0.0/0", "allow", "udp", 123, 123)
+	ntp["rule_number"] = 120
+	desired, _ = expandNetworkAclRules("ingress", "in", []interface{}{entries[0], entries[1], ntp}, 10, ingress)
+	calls, _ = (&VpcService{}).networkAclRulesDeltaCalls("acl", ingress, desired)
+	actions = nil
+	for _, call := range calls {
+		actions = append(actions, call.action)
+	}
+	if got := strings.Join(actions, ","); got != "CreateNetworkAclEntry,DeleteNetworkAclEntry" {
+		t.Errorf("networkAclRulesDeltaCalls() = %s, want the entry of udp 123 created before the one of udp 53 is deleted", got)
+	}
+}
+
+func TestNetworkAclRulesInsertInMiddle(t *testing.T) {
+	ssh := testNetworkAclRule("10.0.1.0/24", "deny", "tcp", 22, 22)
+	dns := testNetworkAclRule("0.0.0.0/0", "allow", "udp", 53, 53)
+	vpc := testNetworkAclRule("10.0.0.0/16", "allow", "ip", 0, 0)
+	ntp := testNetworkAclRule("0.0.0.0/0", "allow", "udp", 123, 123)
+	current, err := expandNetworkAclRules("ingress", "in", []interface{}{ssh, dns, vpc}, 10, nil)
+	if err != nil {
+		t.Fatalf("expandNetworkAclRules() returned error: %s", err)
+	}
+
+	actions := func(entries ...interface{}) ([]networkAclRule, string) {
+		desired, err := expandNetworkAclRules("ingress", "in", entries, 10, current)
+		if err != nil {
+			t.Fatalf("expandNetworkAclRules() returned error: %s", err)
+		}
+		calls, err := (&VpcService{}).networkAclRulesDeltaCalls("acl", current, desired)
+		if err != nil {
+			t.Fatalf("networkAclRulesDeltaCalls() returned error: %s", err)
+		}
+		var actions []string
+		for _, call := range calls {
+			actions = append(actions, call.action)
+		}
+		return desired, strings.Join(actions, ",")
+	}
+
+	desired, got := actions(ssh, ntp, dns, vpc)
+	if got != "CreateNetworkAclEntry" {
+		t.Errorf("inserting an entry in the middle calls %s, want only CreateNetworkAclEntry", got)
+	}
+	for i, want := range []int{10, 15, 20, 30} {
+		if desired[i].ruleNumber != want {
+			t.Errorf("ingress.%d is numbered %d, want %d", i, desired[i].ruleNumber, want)
+		}
+	}
+
+	// the entries after the inserted ones are renumbered only when there is no room between the current numbers
+	current[1].ruleNumber = 11
+	desired, got = actions(ssh, ntp, dns, vpc)
+	if got != "CreateNetworkAclEntry,CreateNetworkAclEntry,DeleteNetworkAclEntry" {
+		t.Errorf("inserting an entry between 10 and 11 calls %s, want the new entry and the entry numbered 11 created below 30 and the latter deleted", got)
+	}
+	for i, want := range []int{10, 16, 23, 30} {
+		if desired[i].ruleNumber != want {
+			t.Errorf("ingress.%d is numbered %d, want %d", i, desired[i].ruleNumber, want)
+		}
+	}
+}
+
+func TestNetworkAclRuleNumberDiffSuppressFunc(t *testing.T) {
+	r := resourceKsyunNetworkAclRules()
+	d := r.Data(nil)
+	d.SetId("acl")
+	_ = d.Set("network_acl_id", "acl")
+	_ = d.Set("rule_number_step", 10)
+	_ = d.Set("ingress", []interface{}{
+		testNetworkAclRule("10.0.1.0/24", "deny", "tcp", 22, 22),
+		testNetworkAclRule("10.0.0.0/16", "allow", "ip", 0, 0),
+	})
+	state := d.State()
+
+	ruleNumberDiff := func(numbers ...int) map[string]*terraform.ResourceAttrDiff {
+		var ingress []interface{}
+		for i, entry := range []map[string]interface{}{
+			{"cidr_block": "10.0.1.0/24", "rule_action": "deny", "protocol": "tcp", "port_range_from": 22, "port_range_to": 22},
+			{"cidr_block": "10.0.0.0/16", "rule_action": "allow", "protocol": "ip"},
+		} {
+			if numbers[i] != 0 {
+				entry["rule_number"] = numbers[i]
+			}
+			ingress = append(ingress, entry)
+		}
+		diff, err := r.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"network_acl_id": "acl",
+			"ingress":        ingress,
+		}), nil)
+		if err != nil {
+			t.Fatalf("Diff() returned error: %s", err)
+		}
+		attributes := make(map[string]*terraform.ResourceAttrDiff)
+		if diff != nil {
+			for k, v := range diff.Attributes {
+				if strings.HasSuffix(k, "rule_number") {
+					attributes[k] = v
+				}
+			}
+		}
+		return attributes
+	}
+	if got := ruleNumberDiff(10, 20); len(got) != 0 {
+		t.Errorf("setting the automatic rule numbers explicitly changes %v", got)
+	}
+	if got := ruleNumberDiff(0, 20); len(got) != 0 {
+		t.Errorf("setting the automatic rule number of ingress.1 explicitly changes %v", got)
+	}
+	if got := ruleNumberDiff(0, 30); len(got) != 1 || got["ingress.1.rule_number"] == nil {
+		t.Errorf("renumbering ingress.1 changes %v, want ingress.1.rule_number", got)
+	}
+}
+
+const testAccNetworkAclRulesConfig = `
+resource "ksyun_vpc" "default" {
+  vpc_name   = "ksyun-vpc-tf"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_network_acl" "default" {
+  vpc_id = "${ksyun_vpc.default.id}"
+  network_acl_name = "ksyun-network-acl"
+}
+
+resource "ksyun_network_acl_rules" "foo" {
+  network_acl_id = "${ksyun_network_acl.default.id}"
+
+  ingress {
+    description = "ssh"
+    cidr_block = "10.7.110.0/24"
+    rule_action = "deny"
+    protocol = "tcp"
+    port_range_from = 22
+    port_range_to = 22
+  }
+  ingress {
+    cidr_block = "10.7.0.0/21"
+    rule_action = "allow"
+    protocol = "ip"
+  }
+
+  egress {
+    cidr_block = "0.0.0.0/0"
+    rule_action = "allow"
+    protocol = "ip"
+  }
+}`
+
+const testAccNetworkAclRulesUpdateConfig = `
+resource "ksyun_vpc" "default" {
+  vpc_name   = "ksyun-vpc-tf"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_network_acl" "default" {
+  vpc_id = "${ksyun_vpc.default.id}"
+  network_acl_name = "ksyun-network-acl"
+}
+
+resource "ksyun_network_acl_rules" "foo" {
+  network_acl_id = "${ksyun_network_acl.default.id}"
+
+  ingress {
+    cidr_block = "10.7.111.0/24"
+    rule_action = "deny"
+    protocol = "ip"
+  }
+  ingress {
+    description = "ssh"
+    cidr_block = "10.7.110.0/24"
+    rule_action = "deny"
+    protocol = "tcp"
+    port_range_from = 22
+    port_range_to = 22
+  }
+  ingress {
+    rule_number = 100
+    cidr_block = "10.7.0.0/21"
+    rule_action = "allow"
+    protocol = "ip"
+  }
+}
+`
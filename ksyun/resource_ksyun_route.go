@@ -16,6 +16,13 @@ Provides a route resource under VPC resource.
 	  vpc_id = "${ksyun_vpc.example.id}"
 	}
 
+	# the IPv6 egress route of a vpc with provided_ipv6_cidr_block
+	resource "ksyun_route" "ipv6" {
+	  destination_cidr_block = "::/0"
+	  route_type = "InternetGateway"
+	  vpc_id = "${ksyun_vpc.example.id}"
+	}
+
 ```
 
 # Import
@@ -55,7 +62,7 @@ func resourceKsyunRoute() *schema.Resource {
 				ForceNew:     true,
 				Required:     true,
 				ValidateFunc: validateCIDRNetworkAddress,
-				Description:  "The CIDR block assigned to the route.",
+				Description:  "The CIDR block assigned to the route, an IPv4 or IPv6 CIDR block such as `::/0`.",
 			},
 
 			"route_type": {
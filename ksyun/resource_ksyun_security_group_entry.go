@@ -12,6 +12,15 @@ Provides a Security Group Entry resource.
 	  protocol="ip"
 	}
 
+	resource "ksyun_security_group_entry" "ipv6" {
+	  security_group_id="7385c8ea-79f7-4e9c-b99f-517fc3726256"
+	  cidr_block="::/0"
+	  direction="in"
+	  protocol="tcp"
+	  port_range_from=443
+	  port_range_to=443
+	}
+
 ```
 
 # Import
@@ -61,7 +70,8 @@ func resourceKsyunSecurityGroupEntry() *schema.Resource {
 					validation.StringIsEmpty,
 					validation.IsCIDR,
 				),
-				Description: "The cidr block of security group rule.",
+				DiffSuppressFunc: cidrBlockDiffSuppressFunc,
+				Description:      "The cidr block of security group rule, an IPv4 or IPv6 CIDR block such as `::/0`.",
 			},
 			"direction": {
 				Type:     schema.TypeString,
@@ -82,22 +92,23 @@ func resourceKsyunSecurityGroupEntry() *schema.Resource {
 					"tcp",
 					"udp",
 					"icmp",
+					"icmpv6",
 				}, false),
-				Description: "The protocol of the entry, valid values: 'ip', 'tcp', 'udp', 'icmp'.",
+				Description: "The protocol of the entry, valid values: 'ip', 'tcp', 'udp', 'icmp', 'icmpv6'. 'icmpv6' is for the rules of an ipv6 cidr_block.",
 			},
 			"icmp_type": {
 				Type:             schema.TypeInt,
 				Optional:         true,
 				ForceNew:         true,
 				DiffSuppressFunc: securityGroupEntryDiffSuppressFunc,
-				Description:      "ICMP type.The required if protocol type is 'icmp'.",
+				Description:      "ICMP type.The required if protocol type is 'icmp' or 'icmpv6'.",
 			},
 			"icmp_code": {
 				Type:             schema.TypeInt,
 				Optional:         true,
 				ForceNew:         true,
 				DiffSuppressFunc: securityGroupEntryDiffSuppressFunc,
-				Description:      "ICMP code.The required if protocol type is 'icmp'.",
+				Description:      "ICMP code.The required if protocol type is 'icmp' or 'icmpv6'.",
 			},
 			"port_range_from": {
 				Type:             schema.TypeInt,
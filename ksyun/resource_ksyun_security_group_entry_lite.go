@@ -124,7 +124,7 @@ func buildSecurityGroupEntryLiteId(d *schema.ResourceData) string {
 	// groupId-direction-protocol-port_range_from(icmp_type)-port_range_to(icmp_code)
 	protocol := d.Get("protocol").(string)
 	switch protocol {
-	case "icmp":
+	case "icmp", "icmpv6":
 		varSuffix = fmt.Sprintf("%d-%d", d.Get("icmp_type").(int), d.Get("icmp_code").(int))
 	default:
 		varSuffix = fmt.Sprintf("%d-%d", d.Get("port_range_from").(int), d.Get("port_range_to").(int))
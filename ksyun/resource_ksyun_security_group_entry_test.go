@@ -3,6 +3,7 @@ package ksyun
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/pkg/errors"
 	"log"
@@ -58,6 +59,24 @@ func TestAccKsyunSecurityGroupEntry_update(t *testing.T) {
 	})
 }
 
+func TestIcmpv6Protocol(t *testing.T) {
+	for name, protocol := range map[string]*schema.Schema{
+		"ksyun_security_group_entry": resourceKsyunSecurityGroupEntry().Schema["protocol"],
+		"ksyun_network_acl_entry":    resourceKsyunNetworkAclEntry().Schema["protocol"],
+	} {
+		if _, errs := protocol.ValidateFunc("icmpv6", "protocol"); len(errs) != 0 {
+			t.Errorf("%s rejects the protocol icmpv6: %v", name, errs)
+		}
+		if _, errs := protocol.ValidateFunc("icmp6", "protocol"); len(errs) == 0 {
+			t.Errorf("%s accepts the protocol icmp6", name)
+		}
+	}
+
+	if fields := generateEntryField("icmpv6"); len(fields) != 2 || fields[0] != "icmp_type" {
+		t.Errorf("the fields of an icmpv6 entry are %v, want the icmp type and code", fields)
+	}
+}
+
 func testAccCheckSecurityGroupEntryExists(n string, val *map[string]interface{}) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
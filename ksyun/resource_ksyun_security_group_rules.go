@@ -0,0 +1,124 @@
+/*
+Provides an authoritative set of the rules of a security group.
+
+The rules of the security group are exactly the `security_group_entries`, the rules added out of band, such as in the console,
+are revoked on the next apply and the default egress rule of a new security group is revoked unless it is declared. Do not use
+this resource with `ksyun_security_group_entry`, `ksyun_security_group_entry_lite` or the `security_group_entries` of
+`ksyun_security_group` on the same security group.
+
+# Example Usage
+
+```hcl
+
+	resource "ksyun_security_group" "default" {
+	  vpc_id              = "a8979fe2-cf1a-47b9-80f6-57445227c541"
+	  security_group_name = "tf-security-group"
+	}
+
+	resource "ksyun_security_group_rules" "default" {
+	  security_group_id = ksyun_security_group.default.id
+	  security_group_entries {
+	    cidr_block      = "10.0.0.0/16"
+	    direction       = "in"
+	    protocol        = "tcp"
+	    port_range_from = 22
+	    port_range_to   = 22
+	    description     = "ssh"
+	  }
+	  security_group_entries {
+	    cidr_block = "0.0.0.0/0"
+	    direction  = "out"
+	    protocol   = "ip"
+	  }
+	}
+
+```
+
+# Import
+
+The rules of a security group can be imported using the id of the security group, e.g.
+
+```
+$ terraform import ksyun_security_group_rules.default 7385c8ea-79f7-4e9c-b99f-517fc3726256
+```
+*/
+package ksyun
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceKsyunSecurityGroupRules() *schema.Resource {
+	entry := resourceKsyunSecurityGroupEntry().Schema
+	for k, v := range entry {
+		if k == "security_group_id" {
+			delete(entry, k)
+		} else {
+			v.ForceNew = false
+		}
+	}
+	return &schema.Resource{
+		Create: resourceKsyunSecurityGroupRulesCreate,
+		Read:   resourceKsyunSecurityGroupRulesRead,
+		Update: resourceKsyunSecurityGroupRulesUpdate,
+		Delete: resourceKsyunSecurityGroupRulesDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"security_group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the security group.",
+			},
+			"security_group_entries": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      securityGroupEntryHash,
+				Elem: &schema.Resource{
+					Schema: entry,
+				},
+				Description: "All the rules of the security group, an empty set revokes every rule.",
+			},
+		},
+	}
+}
+
+func resourceKsyunSecurityGroupRulesCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.CreateSecurityGroupRules(d, resourceKsyunSecurityGroupRules())
+	if err != nil {
+		return fmt.Errorf("error on creating security group rules %q, %s", d.Id(), err)
+	}
+	return resourceKsyunSecurityGroupRulesRead(d, meta)
+}
+
+func resourceKsyunSecurityGroupRulesRead(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ReadAndSetSecurityGroup(d, resourceKsyunSecurityGroupRules())
+	if err != nil {
+		return fmt.Errorf("error on reading security group rules %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunSecurityGroupRulesUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ModifySecurityGroupRules(d, resourceKsyunSecurityGroupRules())
+	if err != nil {
+		return fmt.Errorf("error on updating security group rules %q, %s", d.Id(), err)
+	}
+	return resourceKsyunSecurityGroupRulesRead(d, meta)
+}
+
+func resourceKsyunSecurityGroupRulesDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.RemoveSecurityGroupRules(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting security group rules %q, %s", d.Id(), err)
+	}
+	return err
+}
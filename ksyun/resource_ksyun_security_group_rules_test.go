@@ -0,0 +1,187 @@
+package ksyun
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccKsyunSecurityGroupRules_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "ksyun_security_group_rules.foo",
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSecurityGroupRulesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("ksyun_security_group_rules.foo"),
+					resource.TestCheckResourceAttr("ksyun_security_group_rules.foo", "security_group_entries.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "ksyun_security_group_rules.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccKsyunSecurityGroupRules_update(t *testing.T) {
+	var securityGroupId string
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "ksyun_security_group_rules.foo",
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSecurityGroupRulesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccGetSecurityGroupRulesId("ksyun_security_group_rules.foo", &securityGroupId),
+					resource.TestCheckResourceAttr("ksyun_security_group_rules.foo", "security_group_entries.#", "2"),
+				),
+			},
+			{
+				// a rule added out of band is revoked
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*KsyunClient)
+					_, err := client.vpcconn.AuthorizeSecurityGroupEntry(&map[string]interface{}{
+						"SecurityGroupId": securityGroupId,
+						"CidrBlock":       "10.7.112.0/24",
+						"Direction":       "in",
+						"Protocol":        "ip",
+					})
+					if err != nil {
+						t.Fatalf("error on adding an out of band rule: %s", err)
+					}
+				},
+				Config: testAccSecurityGroupRulesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_security_group_rules.foo", "security_group_entries.#", "2"),
+				),
+			},
+			{
+				Config: testAccSecurityGroupRulesUpdateConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_security_group_rules.foo", "security_group_entries.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGetSecurityGroupRulesId(n string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func TestFlattenSecurityGroupEntries(t *testing.T) {
+	entries := flattenSecurityGroupEntries([]interface{}{
+		map[string]interface{}{
+			"SecurityGroupEntryId": "entry-1",
+			"CidrBlock":            "10.0.0.0/16",
+			"Direction":            "in",
+			"Protocol":             "tcp",
+			"PortRangeFrom":        float64(22),
+			"PortRangeTo":          float64(22),
+			"Description":          "ssh",
+		},
+		map[string]interface{}{
+			"SecurityGroupEntryId": "entry-2",
+			"CidrBlock":            "0.0.0.0/0",
+			"Direction":            "out",
+			"Protocol":             "ip",
+		},
+	})
+	current := schema.NewSet(securityGroupEntryHash, entries)
+	desired := schema.NewSet(securityGroupEntryHash, []interface{}{
+		map[string]interface{}{
+			"cidr_block":      "10.0.0.0/16",
+			"direction":       "in",
+			"protocol":        "tcp",
+			"port_range_from": 22,
+			"port_range_to":   22,
+			"icmp_type":       0,
+			"icmp_code":       0,
+			"description":     "ssh",
+		},
+	})
+	if current.Len() != 2 {
+		t.Fatalf("flattenSecurityGroupEntries() returned %d entries, want 2", current.Len())
+	}
+	if remove := current.Difference(desired); remove.Len() != 1 || remove.List()[0].(map[string]interface{})["security_group_entry_id"] != "entry-2" {
+		t.Errorf("the default egress entry is not the only entry to revoke: %v", remove.List())
+	}
+	if add := desired.Difference(current); add.Len() != 0 {
+		t.Errorf("the declared entry already exists but would be authorized again: %v", add.List())
+	}
+}
+
+const testAccSecurityGroupRulesConfig = `
+resource "ksyun_vpc" "default" {
+  vpc_name   = "ksyun-vpc-tf"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_security_group" "default" {
+  vpc_id = "${ksyun_vpc.default.id}"
+  security_group_name="ksyun-security-group"
+}
+
+resource "ksyun_security_group_rules" "foo" {
+  security_group_id="${ksyun_security_group.default.id}"
+
+  security_group_entries {
+    description = "test1"
+    direction="in"
+    protocol="tcp"
+    port_range_from=22
+    port_range_to=22
+    cidr_block = "10.7.110.220/32"
+  }
+  security_group_entries {
+    description = "test2"
+    direction="out"
+    protocol="ip"
+    cidr_block = "0.0.0.0/0"
+  }
+}`
+
+const testAccSecurityGroupRulesUpdateConfig = `
+resource "ksyun_vpc" "default" {
+  vpc_name   = "ksyun-vpc-tf"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_security_group" "default" {
+  vpc_id = "${ksyun_vpc.default.id}"
+  security_group_name="ksyun-security-group"
+}
+
+resource "ksyun_security_group_rules" "foo" {
+  security_group_id="${ksyun_security_group.default.id}"
+
+  security_group_entries {
+    description = "ssh"
+    direction="in"
+    protocol="tcp"
+    port_range_from=22
+    port_range_to=22
+    cidr_block = "10.7.110.220/32"
+  }
+}
+`
@@ -0,0 +1,125 @@
+/*
+Provides a SNAT entry resource, which translates the source address of the outbound traffic of a subnet or an instance
+to a chosen NAT IP.
+
+The source of an entry is one of `subnet_id` and `network_interface_id`, so the workloads in the same vpc can leave
+through dedicated NAT IPs. The entry binds the source to the NAT by `AssociateNat` or `AssociateInstance` with the NAT IP,
+so a source can only have one entry on a NAT, and changing the NAT IP replaces the entry.
+
+# Example Usage
+
+```hcl
+
+	resource "ksyun_nat_ip" "partner" {
+	  nat_id = ksyun_nat.default.id
+	}
+
+	resource "ksyun_snat_entry" "subnet" {
+	  nat_id    = ksyun_nat.default.id
+	  nat_ip_id = ksyun_nat.default.nat_ip_set[0].nat_ip_id
+	  subnet_id = ksyun_subnet.default.id
+	}
+
+	resource "ksyun_snat_entry" "instance" {
+	  nat_id               = ksyun_nat.default.id
+	  nat_ip_id            = ksyun_nat_ip.partner.nat_ip_id
+	  network_interface_id = ksyun_instance.default.network_interface_id
+	}
+
+```
+
+# Import
+
+SNAT entry can be imported using the `id`, the id format must be `{nat_id}:{resource_id}`, resource_id range `subnet_id`, `network_interface_id` e.g.
+
+## Import Subnet entry
+```
+$ terraform import ksyun_snat_entry.example $nat_id:subnet-$subnet_id
+```
+## Import NetworkInterface entry
+```
+$ terraform import ksyun_snat_entry.example $nat_id:kni-$network_interface_id
+```
+*/
+
+package ksyun
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceKsyunSnatEntry() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunSnatEntryCreate,
+		Read:   resourceKsyunSnatEntryRead,
+		Delete: resourceKsyunSnatEntryDelete,
+		Importer: &schema.ResourceImporter{
+			State: importNatAssociate,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"nat_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the NAT.",
+			},
+			"nat_ip_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the NAT IP the source address is translated to.",
+			},
+			"subnet_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"subnet_id", "network_interface_id"},
+				Description:  "The ID of the subnet whose outbound traffic is translated.",
+			},
+			"network_interface_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+				ExactlyOneOf: []string{"subnet_id", "network_interface_id"},
+				Description:  "The ID of the network interface of the instance whose outbound traffic is translated.",
+			},
+			"nat_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The NAT IP the source address is translated to.",
+			},
+		},
+	}
+}
+
+func resourceKsyunSnatEntryCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.CreateSnat(d, resourceKsyunSnatEntry())
+	if err != nil {
+		return fmt.Errorf("error on creating snat entry %q, %s", d.Id(), err)
+	}
+	return resourceKsyunSnatEntryRead(d, meta)
+}
+
+func resourceKsyunSnatEntryRead(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ReadAndSetSnat(d, resourceKsyunSnatEntry())
+	if err != nil {
+		return fmt.Errorf("error on reading snat entry %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunSnatEntryDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.RemoveSnat(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting snat entry %q, %s", d.Id(), err)
+	}
+	return err
+}
@@ -0,0 +1,152 @@
+package ksyun
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestAccKsyunSnatEntry_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "ksyun_snat_entry.subnet",
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSnatEntryConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("ksyun_snat_entry.subnet"),
+					resource.TestCheckResourceAttrPair("ksyun_snat_entry.subnet", "nat_ip", "ksyun_nat_ip.foo", "nat_ip"),
+				),
+			},
+		},
+	})
+}
+
+func TestSnatEntryCallMapping(t *testing.T) {
+	r := resourceKsyunSnatEntry()
+	s := VpcService{}
+	cases := []struct {
+		raw    map[string]interface{}
+		create string
+		key    string
+		remove string
+	}{
+		{
+			raw:    map[string]interface{}{"nat_id": "nat-1", "nat_ip_id": "ip-1", "subnet_id": "subnet-1"},
+			create: "AssociateNat",
+			key:    "SubnetId",
+			remove: "DisassociateNat",
+		},
+		{
+			raw:    map[string]interface{}{"nat_id": "nat-1", "nat_ip_id": "ip-1", "network_interface_id": "subnet-1"},
+			create: "AssociateInstance",
+			key:    "NetworkInterfaceId",
+			remove: "DisassociateInstance",
+		},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, r.Schema, c.raw)
+		call, err := s.CreateSnatCall(d, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]interface{}{
+			"NatId":     "nat-1",
+			c.key:       "subnet-1",
+			"NatIpId.1": "ip-1",
+		}
+		if call.action != c.create || !reflect.DeepEqual(*call.param, want) {
+			t.Errorf("create: got %s %v, want %s %v", call.action, *call.param, c.create, want)
+		}
+
+		call, err = s.RemoveSnatCall(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = map[string]interface{}{
+			"NatId": "nat-1",
+			c.key:   "subnet-1",
+		}
+		if call.action != c.remove || !reflect.DeepEqual(*call.param, want) {
+			t.Errorf("remove: got %s %v, want %s %v", call.action, *call.param, c.remove, want)
+		}
+	}
+}
+
+func TestSnatEntryFromNat(t *testing.T) {
+	nat := map[string]interface{}{
+		"NatId": "nat-1",
+		"NatIpSet": []interface{}{
+			map[string]interface{}{"NatIpId": "ip-1", "NatIp": "1.1.1.1"},
+			map[string]interface{}{"NatIpId": "ip-2", "NatIp": "2.2.2.2"},
+		},
+		"AssociateNatSet": []interface{}{
+			map[string]interface{}{"SubnetId": "subnet-1", "NatIps": []interface{}{"ip-1", "ip-2"}},
+		},
+		"AssociateInstanceSet": []interface{}{
+			map[string]interface{}{"NetworkInterfaceId": "kni-1", "NatIps": []interface{}{"2.2.2.2"}},
+		},
+	}
+
+	data, err := snatEntryFromNat(nat, "subnet-1", "", "ip-2")
+	if err != nil || data["NatIpId"] != "ip-2" || data["NatIp"] != "2.2.2.2" {
+		t.Errorf("subnet entry: got %v, %v", data, err)
+	}
+	data, err = snatEntryFromNat(nat, "subnet-1", "", "")
+	if err != nil || data["NatIpId"] != "ip-1" {
+		t.Errorf("subnet entry without a preferred nat ip: got %v, %v", data, err)
+	}
+	data, err = snatEntryFromNat(nat, "", "kni-1", "ip-1")
+	if err != nil || data["NatIpId"] != "ip-2" || data["NetworkInterfaceId"] != "kni-1" {
+		t.Errorf("instance entry: got %v, %v", data, err)
+	}
+	if _, err = snatEntryFromNat(nat, "subnet-2", "", ""); err == nil || !notFoundError(err) {
+		t.Errorf("missing entry: got %v, want a not found error", err)
+	}
+}
+
+const testAccSnatEntryConfig = `
+data "ksyun_availability_zones" "default" {
+}
+
+resource "ksyun_vpc" "foo" {
+  vpc_name   = "tf-acc-snat-vpc"
+  cidr_block = "10.0.5.0/24"
+}
+
+resource "ksyun_subnet" "foo" {
+  subnet_name       = "tf-acc-snat-subnet"
+  cidr_block        = "10.0.5.0/24"
+  subnet_type       = "Normal"
+  vpc_id            = "${ksyun_vpc.foo.id}"
+  availability_zone = "${data.ksyun_availability_zones.default.availability_zones.0.availability_zone_name}"
+}
+
+resource "ksyun_nat" "foo" {
+  nat_name    = "tf-acc-snat"
+  nat_mode    = "Subnet"
+  nat_type    = "public"
+  band_width  = 1
+  charge_type = "DailyPaidByTransfer"
+  vpc_id      = "${ksyun_vpc.foo.id}"
+
+  lifecycle {
+    ignore_changes = [nat_ip_number]
+  }
+}
+
+resource "ksyun_nat_ip" "foo" {
+  nat_id = "${ksyun_nat.foo.id}"
+}
+
+resource "ksyun_snat_entry" "subnet" {
+  nat_id    = "${ksyun_nat.foo.id}"
+  nat_ip_id = "${ksyun_nat_ip.foo.nat_ip_id}"
+  subnet_id = "${ksyun_subnet.foo.id}"
+}
+`
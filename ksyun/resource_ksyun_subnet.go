@@ -23,6 +23,15 @@ Provides a Subnet resource under VPC resource.
 	      availability_zone = "cn-shanghai-2a"
 	}
 
+	# the next free /24 of the vpc is assigned
+	resource "ksyun_subnet" "auto" {
+	  subnet_name        = "tf-acc-subnet2"
+	  cidr_prefix_length = 24
+	  subnet_type        = "Normal"
+	  vpc_id             = "${ksyun_vpc.test.id}"
+	  availability_zone  = "cn-shanghai-2a"
+	}
+
 ```
 
 # Import
@@ -51,6 +60,7 @@ func resourceKsyunSubnet() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: subnetCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"availability_zone": {
 				Type:        schema.TypeString,
@@ -68,10 +78,22 @@ func resourceKsyunSubnet() *schema.Resource {
 
 			"cidr_block": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				ForceNew:     true,
 				ValidateFunc: validateCIDRNetworkAddress,
-				Description:  "The CIDR block assigned to the subnet.",
+				ExactlyOneOf: []string{"cidr_block", "cidr_prefix_length"},
+				Description:  "The CIDR block assigned to the subnet. Exactly one of `cidr_block` and `cidr_prefix_length` must be set.",
+			},
+
+			"cidr_prefix_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(16, 29),
+				ExactlyOneOf: []string{"cidr_block", "cidr_prefix_length"},
+				Description:  "The prefix length of the subnet, the lowest free CIDR block of this length in the vpc is planned as `cidr_block`, it is known after apply only when the vpc is created in the same apply. The creation fails if the planned block is taken by another subnet since the plan, and the block stays the same once the subnet is created.",
 			},
 
 			"subnet_type": {
@@ -142,8 +164,7 @@ func resourceKsyunSubnet() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				ForceNew:    true,
-				Description: "whether support IPV6 CIDR blocks. <br> NOTES: providing a part of regions now.",
+				Description: "whether support IPV6 CIDR blocks, an IPv6 CIDR block can be assigned to an existing subnet, turning it off replaces the subnet. <br> NOTES: providing a part of regions now.",
 			},
 
 			"visit_internet": {
@@ -203,6 +224,11 @@ func resourceKsyunSubnet() *schema.Resource {
 				},
 				Description: "An Ipv6 association list of this subnet.",
 			},
+			"ipv6_cidr_block": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IPv6 CIDR block of the subnet.",
+			},
 		},
 	}
 }
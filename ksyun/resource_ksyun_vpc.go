@@ -37,6 +37,7 @@ func resourceKsyunVpc() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: ipv6CidrBlockCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"vpc_name": {
 				Type:        schema.TypeString,
@@ -56,8 +57,7 @@ func resourceKsyunVpc() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				ForceNew:    true,
-				Description: "whether support IPV6 CIDR blocks. <br> NOTES: providing a part of regions now.",
+				Description: "whether support IPV6 CIDR blocks, an IPv6 CIDR block can be assigned to an existing vpc, turning it off replaces the vpc. <br> NOTES: providing a part of regions now.",
 			},
 
 			"is_default": {
@@ -86,6 +86,11 @@ func resourceKsyunVpc() *schema.Resource {
 				},
 				Description: "An Ipv6 association list of this vpc.",
 			},
+			"ipv6_cidr_block": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IPv6 CIDR block of the vpc.",
+			},
 		},
 	}
 }
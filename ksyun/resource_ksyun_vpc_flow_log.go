@@ -0,0 +1,157 @@
+/*
+Provides a VPC flow log resource, which captures the traffic of a vpc, a subnet or a network interface into a KLog log pool.
+
+The KLog project and log pool are checked on plan, they must exist before the flow log is created.
+
+# Example Usage
+
+```hcl
+
+	resource "ksyun_vpc" "default" {
+	  vpc_name   = "tf-flow-log-vpc"
+	  cidr_block = "10.1.0.0/16"
+	}
+
+	resource "ksyun_vpc_flow_log" "default" {
+	  flow_log_name = "tf-flow-log"
+	  resource_type = "Vpc"
+	  resource_id   = ksyun_vpc.default.id
+	  traffic_type  = "All"
+	  project_name  = "vpc-flow-logs"
+	  log_pool_name = "production"
+	  window_time   = 5
+	  description   = "required by security"
+	}
+
+```
+
+# Import
+
+VPC flow log can be imported using the `id`, e.g.
+
+```
+$ terraform import ksyun_vpc_flow_log.default 7385c8ea-79f7-4e9c-b99f-517fc3726256
+```
+*/
+package ksyun
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceKsyunVpcFlowLog() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunVpcFlowLogCreate,
+		Read:   resourceKsyunVpcFlowLogRead,
+		Update: resourceKsyunVpcFlowLogUpdate,
+		Delete: resourceKsyunVpcFlowLogDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: vpcFlowLogCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"flow_log_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the flow log.",
+			},
+			"resource_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"Vpc",
+					"Subnet",
+					"NetworkInterface",
+				}, false),
+				Description: "The type of the resource whose traffic is captured. Valid Values: 'Vpc', 'Subnet', 'NetworkInterface'.",
+			},
+			"resource_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the vpc, the subnet or the network interface whose traffic is captured.",
+			},
+			"traffic_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"All",
+					"Accept",
+					"Reject",
+				}, false),
+				Description: "The type of the traffic to capture. Valid Values: 'All', 'Accept', 'Reject'.",
+			},
+			"project_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the KLog project to deliver the flow log to.",
+			},
+			"log_pool_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the log pool in the KLog project to deliver the flow log to.",
+			},
+			"window_time": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The sampling interval of the flow log in minutes.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the flow log.",
+			},
+			"create_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The creation time of the flow log.",
+			},
+		},
+	}
+}
+
+func resourceKsyunVpcFlowLogCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.CreateFlowLog(d, resourceKsyunVpcFlowLog())
+	if err != nil {
+		return fmt.Errorf("error on creating flow log %q, %s", d.Id(), err)
+	}
+	return resourceKsyunVpcFlowLogRead(d, meta)
+}
+
+func resourceKsyunVpcFlowLogRead(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ReadAndSetFlowLog(d, resourceKsyunVpcFlowLog())
+	if err != nil {
+		return fmt.Errorf("error on reading flow log %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunVpcFlowLogUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ModifyFlowLog(d, resourceKsyunVpcFlowLog())
+	if err != nil {
+		return fmt.Errorf("error on updating flow log %q, %s", d.Id(), err)
+	}
+	return resourceKsyunVpcFlowLogRead(d, meta)
+}
+
+func resourceKsyunVpcFlowLogDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.RemoveFlowLog(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting flow log %q, %s", d.Id(), err)
+	}
+	return err
+}
@@ -0,0 +1,84 @@
+package ksyun
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunVpcFlowLog_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "ksyun_vpc_flow_log.foo",
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccVpcFlowLogConfig, "tf-acc-flow-log", 5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("ksyun_vpc_flow_log.foo"),
+					resource.TestCheckResourceAttr("ksyun_vpc_flow_log.foo", "traffic_type", "All"),
+					resource.TestCheckResourceAttr("ksyun_vpc_flow_log.foo", "window_time", "5"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccVpcFlowLogConfig, "tf-acc-flow-log-1", 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_vpc_flow_log.foo", "flow_log_name", "tf-acc-flow-log-1"),
+					resource.TestCheckResourceAttr("ksyun_vpc_flow_log.foo", "window_time", "10"),
+				),
+			},
+			{
+				ResourceName:      "ksyun_vpc_flow_log.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccKsyunVpcFlowLog_logPoolNotExist(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccVpcFlowLogNoLogPoolConfig,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("does not exist in the klog project"),
+			},
+		},
+	})
+}
+
+const testAccVpcFlowLogConfig = `
+resource "ksyun_vpc" "default" {
+  vpc_name   = "tf-acc-flow-log-vpc"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_vpc_flow_log" "foo" {
+  flow_log_name = "%s"
+  resource_type = "Vpc"
+  resource_id   = "${ksyun_vpc.default.id}"
+  traffic_type  = "All"
+  project_name  = "tf-acc-flow-log"
+  log_pool_name = "tf-acc-flow-log"
+  window_time   = %d
+}
+`
+
+const testAccVpcFlowLogNoLogPoolConfig = `
+resource "ksyun_vpc_flow_log" "foo" {
+  resource_type = "Vpc"
+  resource_id   = "vpc-not-exist"
+  traffic_type  = "All"
+  project_name  = "tf-acc-flow-log"
+  log_pool_name = "tf-acc-log-pool-not-exist"
+}
+`
@@ -0,0 +1,199 @@
+/*
+Provides a VPC peering connection resource, the requester side of the peering between two vpcs.
+
+The peering between the vpcs of the same account and region, of different regions or of different accounts is active
+after the peer side accepts it, see `ksyun_vpc_peering_connection_accepter`. The cross region peering is charged by
+`band_width`.
+
+# Example Usage
+
+```hcl
+
+	resource "ksyun_vpc" "requester" {
+	  vpc_name   = "tf-peering-requester"
+	  cidr_block = "10.1.0.0/16"
+	}
+
+	resource "ksyun_vpc" "accepter" {
+	  provider   = ksyun.peer
+	  vpc_name   = "tf-peering-accepter"
+	  cidr_block = "10.2.0.0/16"
+	}
+
+	resource "ksyun_vpc_peering_connection" "default" {
+	  vpc_id          = ksyun_vpc.requester.id
+	  peer_vpc_id     = ksyun_vpc.accepter.id
+	  peer_region     = "cn-shanghai-2"
+	  peer_account_id = "2000xxxxxx"
+	  peering_name    = "tf-peering"
+	  band_width      = 10
+	  charge_type     = "Daily"
+	}
+
+	resource "ksyun_vpc_peering_connection_accepter" "default" {
+	  provider                  = ksyun.peer
+	  vpc_peering_connection_id = ksyun_vpc_peering_connection.default.id
+	}
+
+	resource "ksyun_route" "default" {
+	  destination_cidr_block    = ksyun_vpc_peering_connection.default.peer_cidr_block
+	  route_type                = "Peering"
+	  vpc_id                    = ksyun_vpc.requester.id
+	  vpc_peering_connection_id = ksyun_vpc_peering_connection_accepter.default.id
+	}
+
+```
+
+# Import
+
+VPC peering connection can be imported using the `id`, e.g.
+
+```
+$ terraform import ksyun_vpc_peering_connection.default 7385c8ea-79f7-4e9c-b99f-517fc3726256
+```
+*/
+package ksyun
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceKsyunVpcPeeringConnection() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunVpcPeeringConnectionCreate,
+		Read:   resourceKsyunVpcPeeringConnectionRead,
+		Update: resourceKsyunVpcPeeringConnectionUpdate,
+		Delete: resourceKsyunVpcPeeringConnectionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"vpc_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the requester vpc.",
+			},
+			"peer_vpc_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the accepter vpc.",
+			},
+			"peer_region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The region of the accepter vpc, the region of the requester vpc by default.",
+			},
+			"peer_account_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The account ID of the accepter vpc, the account of the requester vpc by default.",
+			},
+			"peering_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the peering connection.",
+			},
+			"band_width": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The bandwidth of the peering connection in Mbps, it is required by the cross region peering connection.",
+			},
+			"charge_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: chargeSchemaDiffSuppressFunc,
+				Description:      "The charge type of the bandwidth of the cross region peering connection, such as `Daily`, `Peak` or `Monthly`.",
+			},
+			"purchase_time": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: purchaseTimeDiffSuppressFunc,
+				ValidateFunc:     validation.IntBetween(0, 36),
+				Description:      "Purchase time. If charge_type is Monthly or PrePaidByMonth, this is Required.",
+			},
+			"project_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the project.",
+			},
+			"peer_cidr_block": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The CIDR block of the accepter vpc.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the peering connection, such as `pending-acceptance` and `active`.",
+			},
+			"vpc_peering_connection_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the peering connection.",
+			},
+			"create_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The creation time of the peering connection.",
+			},
+		},
+	}
+}
+
+func resourceKsyunVpcPeeringConnectionCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.CreateVpcPeeringConnection(d, resourceKsyunVpcPeeringConnection())
+	if err != nil {
+		return fmt.Errorf("error on creating vpc peering connection %q, %s", d.Id(), err)
+	}
+	return resourceKsyunVpcPeeringConnectionRead(d, meta)
+}
+
+func resourceKsyunVpcPeeringConnectionRead(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ReadAndSetVpcPeeringConnection(d, resourceKsyunVpcPeeringConnection(), false)
+	if err != nil {
+		return fmt.Errorf("error on reading vpc peering connection %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunVpcPeeringConnectionUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ModifyVpcPeeringConnection(d, resourceKsyunVpcPeeringConnection())
+	if err != nil {
+		return fmt.Errorf("error on updating vpc peering connection %q, %s", d.Id(), err)
+	}
+	return resourceKsyunVpcPeeringConnectionRead(d, meta)
+}
+
+func resourceKsyunVpcPeeringConnectionDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.RemoveVpcPeeringConnection(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting vpc peering connection %q, %s", d.Id(), err)
+	}
+	return err
+}
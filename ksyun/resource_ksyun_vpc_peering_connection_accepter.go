@@ -0,0 +1,127 @@
+/*
+Provides a VPC peering connection accepter resource, which accepts a peering connection on the accepter side and waits for it to be active.
+
+Use a provider of the accepter account for the cross account peering connection and set `region` to the region of the
+accepter vpc for the cross region one. Destroying the resource does not delete the peering connection, it only removes
+the resource from the state, the peering connection is deleted with `ksyun_vpc_peering_connection`.
+
+# Example Usage
+
+```hcl
+
+	resource "ksyun_vpc_peering_connection_accepter" "default" {
+	  provider                  = ksyun.peer
+	  vpc_peering_connection_id = ksyun_vpc_peering_connection.default.id
+	}
+
+```
+
+# Import
+
+VPC peering connection accepter can be imported using the `id` of the peering connection, e.g.
+
+```
+$ terraform import ksyun_vpc_peering_connection_accepter.default 7385c8ea-79f7-4e9c-b99f-517fc3726256
+```
+*/
+package ksyun
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceKsyunVpcPeeringConnectionAccepter() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunVpcPeeringConnectionAccepterCreate,
+		Read:   resourceKsyunVpcPeeringConnectionAccepterRead,
+		Delete: resourceKsyunVpcPeeringConnectionAccepterDelete,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				err := d.Set("vpc_peering_connection_id", d.Id())
+				return []*schema.ResourceData{d}, err
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"vpc_peering_connection_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the peering connection to accept.",
+			},
+			"band_width": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The bandwidth of the peering connection in Mbps, it is set by the requester side.",
+			},
+			"vpc_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the accepter vpc.",
+			},
+			"peer_vpc_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the requester vpc.",
+			},
+			"peer_region": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The region of the requester vpc.",
+			},
+			"peer_account_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The account ID of the requester vpc.",
+			},
+			"peer_cidr_block": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The CIDR block of the requester vpc.",
+			},
+			"peering_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the peering connection.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the peering connection.",
+			},
+			"create_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The creation time of the peering connection.",
+			},
+		},
+	}
+}
+
+func resourceKsyunVpcPeeringConnectionAccepterCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.AcceptVpcPeeringConnection(d)
+	if err != nil {
+		return fmt.Errorf("error on accepting vpc peering connection %q, %s", d.Get("vpc_peering_connection_id"), err)
+	}
+	return resourceKsyunVpcPeeringConnectionAccepterRead(d, meta)
+}
+
+func resourceKsyunVpcPeeringConnectionAccepterRead(d *schema.ResourceData, meta interface{}) (err error) {
+	vpcService := VpcService{meta.(*KsyunClient)}
+	err = vpcService.ReadAndSetVpcPeeringConnection(d, resourceKsyunVpcPeeringConnectionAccepter(), true)
+	if err != nil {
+		return fmt.Errorf("error on reading vpc peering connection %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunVpcPeeringConnectionAccepterDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	d.SetId("")
+	return err
+}
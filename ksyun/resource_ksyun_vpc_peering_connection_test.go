@@ -0,0 +1,146 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKsyunVpcPeeringConnection_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "ksyun_vpc_peering_connection.foo",
+		Providers:     testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcPeeringConnectionConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("ksyun_vpc_peering_connection.foo"),
+					resource.TestCheckResourceAttr("ksyun_vpc_peering_connection_accepter.foo", "state", "active"),
+					resource.TestCheckResourceAttr("ksyun_vpc_peering_connection.foo", "peer_cidr_block", "10.8.0.0/21"),
+					resource.TestCheckResourceAttrPair("ksyun_vpc_peering_connection_accepter.foo", "peer_vpc_id", "ksyun_vpc.requester", "id"),
+					resource.TestCheckResourceAttr("data.ksyun_vpc_peering_connections.foo", "total_count", "1"),
+				),
+			},
+			{
+				Config: testAccVpcPeeringConnectionUpdateConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_vpc_peering_connection.foo", "peering_name", "tf-acc-peering-1"),
+				),
+			},
+			{
+				ResourceName:            "ksyun_vpc_peering_connection.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"purchase_time"},
+			},
+		},
+	})
+}
+
+func TestFlattenVpcPeeringConnection(t *testing.T) {
+	peering := func() map[string]interface{} {
+		return map[string]interface{}{
+			"VpcPeeringConnectionId": "peering-1",
+			"VpcId":                  "vpc-requester",
+			"RequesterVpcInfo": map[string]interface{}{
+				"AccountId": "1000",
+				"Region":    "cn-beijing-6",
+				"VpcId":     "vpc-requester",
+				"CidrBlock": "10.7.0.0/21",
+			},
+			"AccepterVpcInfo": map[string]interface{}{
+				"AccountId": "2000",
+				"Region":    "cn-shanghai-2",
+				"VpcId":     "vpc-accepter",
+				"CidrBlock": "10.8.0.0/21",
+			},
+		}
+	}
+	for _, c := range []struct {
+		accepter bool
+		want     map[string]string
+	}{
+		{
+			accepter: false,
+			want: map[string]string{
+				"VpcId":         "vpc-requester",
+				"PeerVpcId":     "vpc-accepter",
+				"PeerRegion":    "cn-shanghai-2",
+				"PeerAccountId": "2000",
+				"PeerCidrBlock": "10.8.0.0/21",
+			},
+		},
+		{
+			accepter: true,
+			want: map[string]string{
+				"VpcId":         "vpc-accepter",
+				"PeerVpcId":     "vpc-requester",
+				"PeerRegion":    "cn-beijing-6",
+				"PeerAccountId": "1000",
+				"PeerCidrBlock": "10.7.0.0/21",
+			},
+		},
+	} {
+		data := flattenVpcPeeringConnection(peering(), c.accepter)
+		for k, v := range c.want {
+			if data[k] != v {
+				t.Errorf("accepter %t: %s = %v, want %s", c.accepter, k, data[k], v)
+			}
+		}
+		if _, ok := data["AccepterVpcInfo"]; ok {
+			t.Errorf("accepter %t: AccepterVpcInfo is not flattened", c.accepter)
+		}
+	}
+}
+
+const testAccVpcPeeringConnectionConfig = `
+resource "ksyun_vpc" "requester" {
+  vpc_name   = "tf-acc-peering-requester"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_vpc" "accepter" {
+  vpc_name   = "tf-acc-peering-accepter"
+  cidr_block = "10.8.0.0/21"
+}
+
+resource "ksyun_vpc_peering_connection" "foo" {
+  vpc_id       = "${ksyun_vpc.requester.id}"
+  peer_vpc_id  = "${ksyun_vpc.accepter.id}"
+  peering_name = "tf-acc-peering"
+}
+
+resource "ksyun_vpc_peering_connection_accepter" "foo" {
+  vpc_peering_connection_id = "${ksyun_vpc_peering_connection.foo.id}"
+}
+
+data "ksyun_vpc_peering_connections" "foo" {
+  ids         = ["${ksyun_vpc_peering_connection_accepter.foo.id}"]
+  output_file = "output_result_peering"
+}
+`
+
+const testAccVpcPeeringConnectionUpdateConfig = `
+resource "ksyun_vpc" "requester" {
+  vpc_name   = "tf-acc-peering-requester"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_vpc" "accepter" {
+  vpc_name   = "tf-acc-peering-accepter"
+  cidr_block = "10.8.0.0/21"
+}
+
+resource "ksyun_vpc_peering_connection" "foo" {
+  vpc_id       = "${ksyun_vpc.requester.id}"
+  peer_vpc_id  = "${ksyun_vpc.accepter.id}"
+  peering_name = "tf-acc-peering-1"
+}
+
+resource "ksyun_vpc_peering_connection_accepter" "foo" {
+  vpc_peering_connection_id = "${ksyun_vpc_peering_connection.foo.id}"
+}
+`
@@ -2,9 +2,11 @@ package ksyun
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
@@ -30,6 +32,7 @@ func TestAccKsyunVPC_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("ksyun_vpc.foo", "vpc_name", "tf-vpc-supp-ipv6"),
 					resource.TestCheckResourceAttr("ksyun_vpc.foo", "cidr_block", "192.168.0.0/16"),
 					resource.TestCheckResourceAttr("ksyun_vpc.foo", "ipv6_cidr_block_association_set.#", "1"),
+					resource.TestCheckResourceAttrSet("ksyun_vpc.foo", "ipv6_cidr_block"),
 				),
 			},
 		},
@@ -144,6 +147,40 @@ func testAccCheckVPCDestroy(s *terraform.State) error {
 	return nil
 }
 
+func TestCidrBlockDiffSuppressFunc(t *testing.T) {
+	cases := []struct {
+		old, new string
+		suppress bool
+	}{
+		{"10.0.0.0/16", "10.0.0.0/16", true},
+		{"10.0.0.0/16", "10.1.0.0/16", false},
+		{"2400:A480:0:1::/64", "2400:a480:0:1::/64", true},
+		{"2400:a480:0000:0001:0000:0000:0000:0000/64", "2400:a480:0:1::/64", true},
+		{"2400:a480:0:1::/64", "2400:a480:0:1::/56", false},
+		{"", "::/0", false},
+	}
+	for _, c := range cases {
+		if got := cidrBlockDiffSuppressFunc("cidr_block", c.old, c.new, nil); got != c.suppress {
+			t.Errorf("cidrBlockDiffSuppressFunc(%q, %q) = %v, want %v", c.old, c.new, got, c.suppress)
+		}
+	}
+}
+
+func TestFirstIpv6CidrBlock(t *testing.T) {
+	data := map[string]interface{}{
+		"Ipv6CidrBlockAssociationSet": []interface{}{
+			map[string]interface{}{"Ipv6CidrBlock": ""},
+			map[string]interface{}{"Ipv6CidrBlock": "2400:a480:0:1::/56"},
+		},
+	}
+	if got := firstIpv6CidrBlock(data); got != "2400:a480:0:1::/56" {
+		t.Errorf("firstIpv6CidrBlock() = %q, want %q", got, "2400:a480:0:1::/56")
+	}
+	if got := firstIpv6CidrBlock(map[string]interface{}{}); got != "" {
+		t.Errorf("firstIpv6CidrBlock() = %q, want empty", got)
+	}
+}
+
 const testAccVPCConfig = `
 provider "ksyun" {
 	region = "cn-guangzhou-1"
@@ -164,3 +201,39 @@ resource "ksyun_vpc" "foo" {
     cidr_block      = "192.168.0.0/16"
 }
 `
+
+func TestAssociateVpcIpv6CidrBlockCall(t *testing.T) {
+	s := VpcService{}
+	d := schema.TestResourceDataRaw(t, resourceKsyunVpc().Schema, map[string]interface{}{
+		"cidr_block":               "10.0.0.0/16",
+		"provided_ipv6_cidr_block": true,
+	})
+	d.SetId("vpc-1")
+	call, err := s.AssociateVpcIpv6CidrBlockCall(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"VpcId":                 "vpc-1",
+		"ProvidedIpv6CidrBlock": true,
+	}
+	if call.action != "AssociateVpcCidrBlock" || !reflect.DeepEqual(*call.param, want) {
+		t.Errorf("got %s %v, want AssociateVpcCidrBlock %v", call.action, *call.param, want)
+	}
+
+	resp := map[string]interface{}{
+		"Vpc": map[string]interface{}{
+			"VpcId":            "vpc-1",
+			"SecondaryCidrSet": []interface{}{map[string]interface{}{"Cidr": "172.16.0.0/16"}},
+		},
+	}
+	if err = call.afterCall(d, nil, &resp, call); err == nil {
+		t.Errorf("a response without an IPv6 CIDR block passed")
+	}
+	resp["Vpc"].(map[string]interface{})["Ipv6CidrBlockAssociationSet"] = []interface{}{
+		map[string]interface{}{"Ipv6CidrBlock": "2408:4000:1::/56"},
+	}
+	if err = call.afterCall(d, nil, &resp, call); err != nil {
+		t.Errorf("a response with an IPv6 CIDR block failed: %s", err)
+	}
+}
@@ -115,7 +115,7 @@ func resourceKsyunVpnGateway() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
-				Description: "The project id  of the vpn gateway.Default is 0.",
+				Description: "The project id  of the vpn gateway.Default is 0. Changing it moves the vpn gateway to the project.",
 			},
 
 			"vpn_gateway_id": {
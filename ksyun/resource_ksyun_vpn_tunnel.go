@@ -1,6 +1,10 @@
 /*
 Provides a Vpn Tunnel resource.
 
+The routes of a `RouteIpsec` tunnel with Vpn 2.0 are exchanged by BGP if `bgp_local_asn` is set, the learned routes are
+listed by `ksyun_vpn_tunnel_bgp_routes`. The `tunnel_health` shows the state of the master and slave tunnels, and their
+IKE and IPsec SA status with Vpn 2.0.
+
 # Example Usage
 
 ```hcl
@@ -27,6 +31,25 @@ resource "ksyun_vpn_tunnel" "tunnel-vpn2" {
   pre_shared_key = "123456789abcd"
 }
 
+# create Vpn Tunnel exchanging routes by BGP with Vpn 2.0
+resource "ksyun_vpn_tunnel" "tunnel-bgp" {
+  vpn_gateway_version = "2.0"
+  vpn_tunnel_name   = "tf_vpn_tunnel_bgp"
+  type = "RouteIpsec"
+  ike_version = "v2"
+  vpn_gateway_id = "9b3d361e-f65b-464b-947a-fafb5cfb10d2"
+  customer_gateway_id = "7f5a5c91-4814-41bf-b9d6-d9d811f4df0f"
+  ike_dh_group = 2
+  pre_shared_key = "123456789abcd"
+  local_peer_ip = "169.254.10.1/30"
+  customer_peer_ip = "169.254.10.2/30"
+  bgp_local_asn = "64512"
+  bgp_peer_asn = "65001"
+  bgp_local_ip = "169.254.10.1"
+  bgp_peer_ip = "169.254.10.2"
+  bgp_advertised_cidr_blocks = ["10.0.0.0/16"]
+}
+
 ```
 
 # Import
@@ -42,6 +65,8 @@ package ksyun
 
 import (
 	"fmt"
+	"net"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
@@ -116,6 +141,50 @@ func resourceKsyunVpnTunnel() (r *schema.Resource) {
 				Description: "The IP of customer with CIDR indicated.",
 			},
 
+			"bgp_local_asn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateBgpAsn,
+				Description:  "The BGP ASN of the vpn gateway side. If set, the routes of the vpn tunnel are exchanged by BGP.",
+			},
+
+			"bgp_peer_asn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateBgpAsn,
+				Description:  "The BGP ASN of the customer side. If bgp_local_asn is set, Required.",
+			},
+
+			"bgp_local_ip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPAddress,
+				Description:  "The BGP peer IP of the vpn gateway side, it must be in the CIDR block of local_peer_ip. If bgp_local_asn is set, Required.",
+			},
+
+			"bgp_peer_ip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPAddress,
+				Description:  "The BGP peer IP of the customer side, it must be in the CIDR block of customer_peer_ip. If bgp_local_asn is set, Required.",
+			},
+
+			"bgp_advertised_cidr_blocks": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
+				Set:         schema.HashString,
+				Description: "The CIDR blocks advertised to the customer side by BGP.",
+			},
+
 			"vpn_gre_ip": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -306,6 +375,7 @@ func resourceKsyunVpnTunnel() (r *schema.Resource) {
 				Computed:    true,
 				Description: "the vpn tunnel created time.",
 			},
+			"tunnel_health": vpnTunnelHealthSchema(),
 		},
 	}
 
@@ -432,6 +502,13 @@ func checkVpnType(d *schema.ResourceData) error {
 
 	}
 
+	if bgpErrs := checkVpnTunnelBgp(d); bgpErrs != nil {
+		if !isV2 || vpnType != "RouteIpsec" {
+			errs = append(errs, fmt.Errorf("bgp is valid, when vpn_gateway_version is 2.0 and vpn type is RouteIpsec"))
+		}
+		errs = append(errs, bgpErrs...)
+	}
+
 	if !isAllowHealthCheckOpen && hcoExist {
 		errs = append(errs, fmt.Errorf("open_health_check is valid, when vpn_gateway_version is 2.0 and vpn type is RouteIpsec"))
 	}
@@ -443,6 +520,44 @@ func checkVpnType(d *schema.ResourceData) error {
 	return nil
 }
 
+// checkVpnTunnelBgp checks the bgp parameters, it returns nil if none of them is set
+func checkVpnTunnelBgp(d *schema.ResourceData) (errs []error) {
+	var isBgp bool
+	for _, k := range vpnBgpAttribute {
+		if _, ok := d.GetOk(k); ok {
+			isBgp = true
+		}
+	}
+	if !isBgp {
+		return nil
+	}
+	errs = []error{}
+	for _, k := range []string{"bgp_local_asn", "bgp_peer_asn", "bgp_local_ip", "bgp_peer_ip"} {
+		if _, ok := d.GetOk(k); !ok {
+			errs = append(errs, fmt.Errorf("%s cannot be blank, when bgp is set", k))
+		}
+	}
+	for ipKey, cidrKey := range map[string]string{"bgp_local_ip": "local_peer_ip", "bgp_peer_ip": "customer_peer_ip"} {
+		ip := net.ParseIP(d.Get(ipKey).(string))
+		_, cidr, err := net.ParseCIDR(d.Get(cidrKey).(string))
+		if ip != nil && err == nil && !cidr.Contains(ip) {
+			errs = append(errs, fmt.Errorf("%s must be in the CIDR block of %s", ipKey, cidrKey))
+		}
+	}
+	if d.Get("bgp_local_ip") != "" && d.Get("bgp_local_ip") == d.Get("bgp_peer_ip") {
+		errs = append(errs, fmt.Errorf("bgp_local_ip and bgp_peer_ip cannot be the same"))
+	}
+	return errs
+}
+
+func validateBgpAsn(v interface{}, k string) (ws []string, errs []error) {
+	asn, err := strconv.ParseUint(v.(string), 10, 32)
+	if err != nil || asn == 0 {
+		errs = append(errs, fmt.Errorf("expected %s to be an ASN in the range [1, 4294967295], got %s", k, v))
+	}
+	return ws, errs
+}
+
 func bannedPartialParamsChanges(d *schema.ResourceData) error {
 	bannedList := []string{"customer_peer_ip", "local_peer_ip"}
 	if d.HasChanges(bannedList...) {
@@ -450,3 +565,35 @@ func bannedPartialParamsChanges(d *schema.ResourceData) error {
 	}
 	return nil
 }
+
+func vpnTunnelHealthSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The health of the master and slave tunnels.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"role": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The role of the tunnel, `master` or `slave`.",
+				},
+				"state": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The state of the tunnel, the `vpn_m_tunnel_state` or `vpn_s_tunnel_state` of the tunnel.",
+				},
+				"ike_status": {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether the IKE SA is established, it is false if the status is not reported, such as with Vpn 1.0.",
+				},
+				"ipsec_status": {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether the IPsec SA is established, it is false if the status is not reported, such as with Vpn 1.0.",
+				},
+			},
+		},
+	}
+}
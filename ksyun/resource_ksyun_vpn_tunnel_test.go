@@ -2,6 +2,7 @@ package ksyun
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -30,6 +31,78 @@ func TestAccKsyunVpnTunnel_basic(t *testing.T) {
 	})
 }
 
+func TestAccKsyunVpnTunnel_bgp(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ksyun_vpn_tunnel.default",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckVPCDestroy,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpnTunnelBgpConfig("vpn-tunnel-bgp-unit-test"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("ksyun_vpn_tunnel.default"),
+					resource.TestCheckResourceAttr("ksyun_vpn_tunnel.default", "bgp_local_asn", "64512"),
+					resource.TestCheckResourceAttr("ksyun_vpn_tunnel.default", "bgp_advertised_cidr_blocks.#", "1"),
+					resource.TestCheckResourceAttr("ksyun_vpn_tunnel.default", "tunnel_health.0.role", "master"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateBgpAsn(t *testing.T) {
+	for asn, valid := range map[string]bool{
+		"1":          true,
+		"64512":      true,
+		"4294967295": true,
+		"0":          false,
+		"4294967296": false,
+		"-1":         false,
+		"as64512":    false,
+	} {
+		_, errs := validateBgpAsn(asn, "bgp_local_asn")
+		if valid != (len(errs) == 0) {
+			t.Errorf("validateBgpAsn(%q) = %v, want valid %t", asn, errs, valid)
+		}
+	}
+}
+
+func TestVpnTunnelHealth(t *testing.T) {
+	tunnel := map[string]interface{}{"VpnTunnelId": "tunnel", "VpnMTunnelState": "up", "VpnSTunnelState": "down"}
+	status := []interface{}{
+		map[string]interface{}{"Id": "tunnel", "IsMaster": float64(0), "IkeStatus": true, "IpsecStatus": false},
+		map[string]interface{}{"Id": "tunnel", "IsMaster": float64(1), "IkeStatus": true, "IpsecStatus": true},
+	}
+	health := vpnTunnelHealth(tunnel, status)
+	want := []interface{}{
+		map[string]interface{}{"role": "master", "state": "up", "ike_status": true, "ipsec_status": true},
+		map[string]interface{}{"role": "slave", "state": "down", "ike_status": true, "ipsec_status": false},
+	}
+	if !reflect.DeepEqual(health, want) {
+		t.Fatalf("vpnTunnelHealth() = %v, want %v", health, want)
+	}
+
+	// the states are kept without the SA status, such as with vpn 1.0
+	health = vpnTunnelHealth(map[string]interface{}{"VpnMTunnelState": true, "VpnSTunnelState": false}, nil)
+	want = []interface{}{
+		map[string]interface{}{"role": "master", "state": "up", "ike_status": false, "ipsec_status": false},
+		map[string]interface{}{"role": "slave", "state": "down", "ike_status": false, "ipsec_status": false},
+	}
+	if !reflect.DeepEqual(health, want) {
+		t.Errorf("vpnTunnelHealth() = %v without the SA status, want %v", health, want)
+	}
+
+	if health = vpnTunnelHealth(map[string]interface{}{}, nil); len(health) != 0 {
+		t.Errorf("vpnTunnelHealth() = %v without any state, want none", health)
+	}
+}
+
 func testAccVpnTunnelConfig(suffix string) (s string) {
 	defer func() {
 		s = strings.ReplaceAll(s, "${var.suffix}", suffix)
@@ -66,3 +139,49 @@ resource "ksyun_vpn_tunnel" "default" {
 
 `, basicConfig)
 }
+
+func testAccVpnTunnelBgpConfig(suffix string) (s string) {
+	defer func() {
+		s = strings.ReplaceAll(s, "${var.suffix}", suffix)
+	}()
+	basicConfig := testBasicNetworkConfig("cn-guangzhou-1", suffix)
+	return fmt.Sprintf(`
+	%s
+resource "ksyun_vpn_gateway" "default" {
+  vpn_gateway_name   = "tf-${var.suffix}-vpn-gw"
+  band_width = 10
+  vpc_id = ksyun_vpc.foo.id
+  charge_type = "Daily"
+  vpn_gateway_version = "2.0"
+}
+
+resource "ksyun_vpn_customer_gateway" "default" {
+  customer_gateway_address   = "100.0.0.66"
+  ha_customer_gateway_address = "100.0.2.66"
+  customer_gateway_name = "tf-${var.suffix}-vpn-cgw"
+}
+
+resource "ksyun_vpn_tunnel" "default" {
+  vpn_tunnel_name = "tf-${var.suffix}-vpn-tunnel"
+  type = "RouteIpsec"
+  vpn_gateway_version = "2.0"
+  vpn_gateway_id = ksyun_vpn_gateway.default.id
+  customer_gateway_id = ksyun_vpn_customer_gateway.default.id
+  ike_dh_group = 2
+  ike_version = "v2"
+  pre_shared_key = "123456789abcd"
+  local_peer_ip = "169.254.10.1/30"
+  customer_peer_ip = "169.254.10.2/30"
+  bgp_local_asn = "64512"
+  bgp_peer_asn = "65001"
+  bgp_local_ip = "169.254.10.1"
+  bgp_peer_ip = "169.254.10.2"
+  bgp_advertised_cidr_blocks = ["10.0.0.0/16"]
+}
+
+data "ksyun_vpn_tunnel_bgp_routes" "default" {
+  vpn_tunnel_id = ksyun_vpn_tunnel.default.id
+  output_file   = "output_result_vpn_tunnel_bgp_routes"
+}
+`, basicConfig)
+}
@@ -2,10 +2,12 @@ package ksyun
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-ksyun/logger"
-	"time"
 )
 
 type CenService struct {
@@ -215,3 +217,516 @@ func (s *CenService) RemoveCen(d *schema.ResourceData) (err error) {
 	}
 	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
 }
+
+// doCenCustomAction calls the cen actions the cen client of the sdk does not provide yet, such as the network instance ones
+func (s *CenService) doCenCustomAction(action string, input *map[string]interface{}) (resp *map[string]interface{}, err error) {
+	conn := s.client.cenconn
+	op := &request.Operation{
+		Name:       action,
+		HTTPMethod: "GET",
+		HTTPPath:   "/",
+	}
+	if input == nil {
+		input = &map[string]interface{}{}
+	}
+	output := &map[string]interface{}{}
+	req := conn.NewRequest(op, input, output)
+	err = req.Send()
+	return output, err
+}
+
+func (s *CenService) readCenCollection(action string, collection string, condition map[string]interface{}) (data []interface{}, err error) {
+	var (
+		resp    *map[string]interface{}
+		results interface{}
+	)
+	return pageQueryWithNextToken(condition, "MaxResults", "NextToken", 100, func(condition map[string]interface{}) ([]interface{}, string, error) {
+		logger.Debug(logger.ReqFormat, action, condition)
+		resp, err = s.doCenCustomAction(action, &condition)
+		if err != nil {
+			return data, "", err
+		}
+		nextToken := (*resp)["NextToken"]
+		results, err = getSdkValue(collection, *resp)
+		if err != nil {
+			return data, "", err
+		}
+		data, _ = results.([]interface{})
+		return data, indirectString(nextToken), err
+	})
+}
+
+func (s *CenService) ReadNetworkInstances(condition map[string]interface{}) (data []interface{}, err error) {
+	return s.readCenCollection("DescribeNetworkInstances", "NetworkInstanceSet", condition)
+}
+
+func (s *CenService) ReadCenInstanceAttachment(d *schema.ResourceData, cenId string, instanceId string) (data map[string]interface{}, err error) {
+	results, err := s.ReadNetworkInstances(map[string]interface{}{
+		"NetworkInstanceId.1": instanceId,
+	})
+	if err != nil {
+		return data, err
+	}
+	for _, v := range results {
+		if v.(map[string]interface{})["CenId"] == cenId {
+			data = v.(map[string]interface{})
+		}
+	}
+	if len(data) == 0 {
+		return data, fmt.Errorf("Network instance %s attached to Cen %s not exist ", instanceId, cenId)
+	}
+	return data, err
+}
+
+func (s *CenService) ReadAndSetCenInstanceAttachment(d *schema.ResourceData, r *schema.Resource) (err error) {
+	data, err := s.ReadCenInstanceAttachment(d, d.Get("cen_id").(string), d.Get("instance_id").(string))
+	if err != nil {
+		if notFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	SdkResponseAutoResourceData(d, r, data, map[string]SdkResponseMapping{
+		"NetworkInstanceId": {
+			Field: "instance_id",
+		},
+	})
+	return err
+}
+
+func (s *CenService) checkCenInstanceAttachment(d *schema.ResourceData, attached bool, timeout time.Duration) (err error) {
+	cenId := d.Get("cen_id").(string)
+	instanceId := d.Get("instance_id").(string)
+	return resource.Retry(timeout, func() *resource.RetryError {
+		_, callErr := s.ReadCenInstanceAttachment(d, cenId, instanceId)
+		if callErr != nil && !notFoundError(callErr) {
+			return resource.NonRetryableError(callErr)
+		}
+		if (callErr == nil) != attached {
+			return resource.RetryableError(fmt.Errorf("waiting for the network instance %s attached to cen %s", instanceId, cenId))
+		}
+		return nil
+	})
+}
+
+func (s *CenService) CreateCenInstanceAttachmentCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	transform := map[string]SdkReqTransform{
+		"instance_id": {
+			mapping: "NetworkInstanceId",
+		},
+	}
+	req, err := SdkRequestAutoMapping(d, r, false, transform, nil, SdkReqParameter{
+		onlyTransform: false,
+	})
+	if err != nil {
+		return callback, err
+	}
+	if _, ok := req["InstanceRegion"]; !ok {
+		req["InstanceRegion"] = s.client.region
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "AttachNetworkInstance",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			return s.doCenCustomAction(call.action, call.param)
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			err = s.checkCenInstanceAttachment(d, true, d.Timeout(schema.TimeoutCreate))
+			if err != nil {
+				return err
+			}
+			d.SetId(d.Get("cen_id").(string) + ":" + d.Get("instance_id").(string))
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *CenService) CreateCenInstanceAttachment(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.CreateCenInstanceAttachmentCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *CenService) RemoveCenInstanceAttachmentCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	removeReq := map[string]interface{}{
+		"CenId":             d.Get("cen_id"),
+		"NetworkInstanceId": d.Get("instance_id"),
+	}
+	callback = ApiCall{
+		param:  &removeReq,
+		action: "DetachNetworkInstance",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			return s.doCenCustomAction(call.action, call.param)
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			return resource.Retry(5*time.Minute, func() *resource.RetryError {
+				_, callErr := s.ReadCenInstanceAttachment(d, d.Get("cen_id").(string), d.Get("instance_id").(string))
+				if callErr != nil {
+					if notFoundError(callErr) {
+						return nil
+					}
+					return resource.NonRetryableError(fmt.Errorf("error on reading cen instance attachment when delete %q, %s", d.Id(), callErr))
+				}
+				_, callErr = call.executeCall(d, client, call)
+				if callErr == nil {
+					return nil
+				}
+				return resource.RetryableError(callErr)
+			})
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return s.checkCenInstanceAttachment(d, false, d.Timeout(schema.TimeoutDelete))
+		},
+	}
+	return callback, err
+}
+
+func (s *CenService) RemoveCenInstanceAttachment(d *schema.ResourceData) (err error) {
+	call, err := s.RemoveCenInstanceAttachmentCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *CenService) ReadCenBandwidthPackages(condition map[string]interface{}) (data []interface{}, err error) {
+	return s.readCenCollection("DescribeCenBandWidthPackages", "CenBandWidthPackageSet", condition)
+}
+
+func (s *CenService) ReadCenBandwidthPackage(d *schema.ResourceData, packageId string) (data map[string]interface{}, err error) {
+	if packageId == "" {
+		packageId = d.Id()
+	}
+	results, err := s.ReadCenBandwidthPackages(map[string]interface{}{
+		"CenBandWidthPackageId.1": packageId,
+	})
+	if err != nil {
+		return data, err
+	}
+	for _, v := range results {
+		data = v.(map[string]interface{})
+	}
+	if len(data) == 0 {
+		return data, fmt.Errorf("Cen bandwidth package %s not exist ", packageId)
+	}
+	return data, err
+}
+
+func (s *CenService) ReadCenRegionBandwidths(packageId string) (data []interface{}, err error) {
+	results, err := s.readCenCollection("DescribeCenRegionBandwidths", "CenRegionBandwidthSet", map[string]interface{}{
+		"Filter.1.Name":    "cen-band-width-package-id",
+		"Filter.1.Value.1": packageId,
+	})
+	if err != nil {
+		return data, err
+	}
+	for _, v := range results {
+		if v.(map[string]interface{})["CenBandWidthPackageId"] == packageId {
+			data = append(data, v)
+		}
+	}
+	return data, err
+}
+
+func (s *CenService) ReadAndSetCenBandwidthPackage(d *schema.ResourceData, r *schema.Resource) (err error) {
+	data, err := s.ReadCenBandwidthPackage(d, "")
+	if err != nil {
+		if notFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	regionBandwidths, err := s.ReadCenRegionBandwidths(d.Id())
+	if err != nil {
+		return err
+	}
+	data["RegionBandwidths"] = regionBandwidths
+	SdkResponseAutoResourceData(d, r, data, nil)
+	return err
+}
+
+func cenRegionBandwidthKey(localRegion interface{}, remoteRegion interface{}) string {
+	return fmt.Sprintf("%v:%v", localRegion, remoteRegion)
+}
+
+// cenRegionBandwidthsDeltaCalls returns the calls that change the region bandwidths of the package from current,
+// the DescribeCenRegionBandwidths results, to desired, the region_bandwidths of the resource, the deletes come first
+// to free the bandwidth of the package for the others
+func (s *CenService) cenRegionBandwidthsDeltaCalls(packageId string, current []interface{}, desired []interface{}) (calls []ApiCall) {
+	existing := make(map[string]map[string]interface{})
+	for _, v := range current {
+		item := v.(map[string]interface{})
+		existing[cenRegionBandwidthKey(item["LocalRegion"], item["RemoteRegion"])] = item
+	}
+	var (
+		modifies []ApiCall
+		creates  []ApiCall
+		kept     = make(map[string]bool)
+	)
+	for _, v := range desired {
+		item := v.(map[string]interface{})
+		key := cenRegionBandwidthKey(item["local_region"], item["remote_region"])
+		kept[key] = true
+		if old, ok := existing[key]; ok {
+			if fmt.Sprintf("%v", old["InterBandWidth"]) != fmt.Sprintf("%v", item["inter_band_width"]) {
+				modifies = append(modifies, s.cenRegionBandwidthCall("ModifyCenRegionBandwidth", map[string]interface{}{
+					"CenRegionBandwidthId": old["CenRegionBandwidthId"],
+					"InterBandWidth":       item["inter_band_width"],
+				}))
+			}
+			continue
+		}
+		creates = append(creates, s.cenRegionBandwidthCall("CreateCenRegionBandwidth", map[string]interface{}{
+			"CenBandWidthPackageId": packageId,
+			"LocalRegion":           item["local_region"],
+			"RemoteRegion":          item["remote_region"],
+			"InterBandWidth":        item["inter_band_width"],
+		}))
+	}
+	for _, v := range current {
+		item := v.(map[string]interface{})
+		if !kept[cenRegionBandwidthKey(item["LocalRegion"], item["RemoteRegion"])] {
+			calls = append(calls, s.cenRegionBandwidthCall("DeleteCenRegionBandwidth", map[string]interface{}{
+				"CenRegionBandwidthId": item["CenRegionBandwidthId"],
+			}))
+		}
+	}
+	calls = append(calls, modifies...)
+	return append(calls, creates...)
+}
+
+// validateCenRegionBandwidths checks that a region pair is limited once, in either direction, and the limits
+// of the region pairs do not exceed the bandwidth of the package together
+func validateCenRegionBandwidths(packageBandWidth int, entries []interface{}) error {
+	var total int
+	pairs := make(map[string]bool)
+	for _, v := range entries {
+		item := v.(map[string]interface{})
+		local, remote := item["local_region"].(string), item["remote_region"].(string)
+		if local == remote {
+			return fmt.Errorf("the local_region and remote_region of region_bandwidths are both %s", local)
+		}
+		if pairs[cenRegionBandwidthKey(local, remote)] || pairs[cenRegionBandwidthKey(remote, local)] {
+			return fmt.Errorf("the bandwidth between %s and %s is limited more than once in region_bandwidths", local, remote)
+		}
+		pairs[cenRegionBandwidthKey(local, remote)] = true
+		total += item["inter_band_width"].(int)
+	}
+	if total > packageBandWidth {
+		return fmt.Errorf("the region_bandwidths add up to %d Mbps, more than the package_band_width %d Mbps", total, packageBandWidth)
+	}
+	return nil
+}
+
+func (s *CenService) cenRegionBandwidthCall(action string, req map[string]interface{}) ApiCall {
+	return ApiCall{
+		param:  &req,
+		action: action,
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			return s.doCenCustomAction(call.action, call.param)
+		},
+		afterCall: logCall,
+	}
+}
+
+func (s *CenService) applyCenRegionBandwidthsCalls(d *schema.ResourceData) (calls []ApiCall, err error) {
+	current, err := s.ReadCenRegionBandwidths(d.Id())
+	if err != nil {
+		return calls, err
+	}
+	return s.cenRegionBandwidthsDeltaCalls(d.Id(), current, d.Get("region_bandwidths").(*schema.Set).List()), err
+}
+
+func (s *CenService) CreateCenBandwidthPackageCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	transform := map[string]SdkReqTransform{
+		"region_bandwidths": {
+			Ignore: true,
+		},
+	}
+	req, err := SdkRequestAutoMapping(d, r, false, transform, nil, SdkReqParameter{
+		onlyTransform: false,
+	})
+	if err != nil {
+		return callback, err
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "CreateCenBandWidthPackage",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			return s.doCenCustomAction(call.action, call.param)
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			id, err := getSdkValue("CenBandWidthPackage.CenBandWidthPackageId", *resp)
+			if err != nil {
+				return err
+			}
+			d.SetId(id.(string))
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *CenService) CreateCenBandwidthPackage(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.CreateCenBandwidthPackageCall(d, r)
+	if err != nil {
+		return err
+	}
+	err = ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+	if err != nil {
+		return err
+	}
+	calls, err := s.applyCenRegionBandwidthsCalls(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew(calls, d, s.client, true)
+}
+
+func (s *CenService) ModifyCenBandwidthPackageCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	transform := map[string]SdkReqTransform{
+		"cen_band_width_package_name": {},
+		"package_band_width":          {},
+	}
+	req, err := SdkRequestAutoMapping(d, r, true, transform, nil, SdkReqParameter{
+		onlyTransform: true,
+	})
+	if err != nil {
+		return callback, err
+	}
+	if len(req) > 0 {
+		req["CenBandWidthPackageId"] = d.Id()
+		callback = ApiCall{
+			param:  &req,
+			action: "ModifyCenBandWidthPackage",
+			executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+				logger.Debug(logger.RespFormat, call.action, *(call.param))
+				return s.doCenCustomAction(call.action, call.param)
+			},
+			afterCall: logCall,
+		}
+	}
+	return callback, err
+}
+
+func (s *CenService) ModifyCenBandwidthPackage(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.ModifyCenBandwidthPackageCall(d, r)
+	if err != nil {
+		return err
+	}
+	var calls []ApiCall
+	if d.HasChange("region_bandwidths") {
+		calls, err = s.applyCenRegionBandwidthsCalls(d)
+		if err != nil {
+			return err
+		}
+	}
+	// the package shrinks after the region bandwidths and grows before them
+	o, n := d.GetChange("package_band_width")
+	if o.(int) > n.(int) {
+		calls = append(calls, call)
+	} else {
+		calls = append([]ApiCall{call}, calls...)
+	}
+	return ksyunApiCallNew(calls, d, s.client, true)
+}
+
+func (s *CenService) RemoveCenBandwidthPackageCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	removeReq := map[string]interface{}{
+		"CenBandWidthPackageId": d.Id(),
+	}
+	callback = ApiCall{
+		param:  &removeReq,
+		action: "DeleteCenBandWidthPackage",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			return s.doCenCustomAction(call.action, call.param)
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			return resource.Retry(5*time.Minute, func() *resource.RetryError {
+				_, callErr := s.ReadCenBandwidthPackage(d, "")
+				if callErr != nil {
+					if notFoundError(callErr) {
+						return nil
+					}
+					return resource.NonRetryableError(fmt.Errorf("error on reading cen bandwidth package when delete %q, %s", d.Id(), callErr))
+				}
+				_, callErr = call.executeCall(d, client, call)
+				if callErr == nil {
+					return nil
+				}
+				return resource.RetryableError(callErr)
+			})
+		},
+		afterCall: logCall,
+	}
+	return callback, err
+}
+
+func (s *CenService) RemoveCenBandwidthPackage(d *schema.ResourceData) (err error) {
+	current, err := s.ReadCenRegionBandwidths(d.Id())
+	if err != nil {
+		return err
+	}
+	calls := s.cenRegionBandwidthsDeltaCalls(d.Id(), current, nil)
+	call, err := s.RemoveCenBandwidthPackageCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew(append(calls, call), d, s.client, true)
+}
+
+func (s *CenService) ReadCenRoutes(condition map[string]interface{}) (data []interface{}, err error) {
+	return s.readCenCollection("DescribeCenRoutes", "CenRouteSet", condition)
+}
+
+func (s *CenService) ReadAndSetCenRoutes(d *schema.ResourceData, r *schema.Resource) (err error) {
+	transform := map[string]SdkReqTransform{
+		"ids": {
+			mapping: "CenRouteId",
+			Type:    TransformWithN,
+		},
+		"cen_ids": {
+			mapping: "cen-id",
+			Type:    TransformWithFilter,
+		},
+		"instance_ids": {
+			mapping: "network-instance-id",
+			Type:    TransformWithFilter,
+		},
+	}
+	req, err := mergeDataSourcesReq(d, r, transform)
+	if err != nil {
+		return err
+	}
+	data, err := s.ReadCenRoutes(req)
+	if err != nil {
+		return err
+	}
+	return mergeDataSourcesResp(d, r, ksyunDataSource{
+		collection:  data,
+		idFiled:     "CenRouteId",
+		targetField: "routes",
+		extra: map[string]SdkResponseMapping{
+			"CenRouteId": {
+				Field:    "id",
+				KeepAuto: true,
+			},
+			"NetworkInstanceId": {
+				Field: "instance_id",
+			},
+		},
+	})
+}
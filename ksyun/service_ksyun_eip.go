@@ -322,7 +322,8 @@ func (s *EipService) ReadAddressAssociate(d *schema.ResourceData, allocationId s
 	} else {
 		return data, fmt.Errorf("InstanceId %s not associate in Address %s ", instanceId, allocationId)
 	}
-	if networkInterfaceId != "" {
+	// the network interface of a HaVip is its master one, which changes on a failover
+	if networkInterfaceId != "" && data["InstanceType"] != "HaVip" && d.Get("instance_type") != "HaVip" {
 		if vifId, ok := data["NetworkInterfaceId"]; ok {
 			if vifId != networkInterfaceId {
 				return data, fmt.Errorf("InstanceId %s not associate in Address %s ", instanceId, allocationId)
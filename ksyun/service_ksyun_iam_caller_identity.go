@@ -0,0 +1,146 @@
+package ksyun
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-ksyun/logger"
+)
+
+const (
+	callerIdentityTypeRoot = "root"
+	callerIdentityTypeUser = "user"
+	callerIdentityTypeRole = "role"
+
+	// callerIdentityRootErrorCode is the error of GetUser without a user name called with the credentials of the
+	// main account, which is not an IAM user
+	callerIdentityRootErrorCode = "UserNotExist"
+)
+
+type IamCallerIdentityService struct {
+	client *KsyunClient
+}
+
+// ReadCallerIdentity returns the identity of the provider credentials. GetUser without a user name answers with
+// the calling sub-account or role, whose KRN carries the account id. It fails with callerIdentityRootErrorCode for
+// the credentials of the main account, which is not an IAM user, and only then the account id is read from the
+// projects of the account, which the main account is always allowed to list.
+func (s *IamCallerIdentityService) ReadCallerIdentity() (data map[string]interface{}, err error) {
+	conn := s.client.iamconn
+	action := "GetUser"
+	logger.Debug(logger.ReqFormat, action, nil)
+	resp, err := conn.GetUser(nil)
+	if err != nil {
+		logger.Debug(logger.RespFormat, action, nil, err)
+	} else {
+		logger.Debug(logger.RespFormat, action, nil, *resp)
+	}
+	data, err = callerIdentityFromGetUser(resp, err)
+	if err != nil {
+		return data, err
+	}
+	if data["identity_type"] == callerIdentityTypeRoot {
+		accountId, err := s.readRootAccountId()
+		if err != nil {
+			return data, err
+		}
+		data["account_id"] = accountId
+		data["krn"] = fmt.Sprintf("krn:ksc:iam::%s:root", accountId)
+	}
+	data["region"] = s.client.region
+	return data, nil
+}
+
+// readRootAccountId returns the id of the main account from its projects, every account has its default project
+func (s *IamCallerIdentityService) readRootAccountId() (accountId string, err error) {
+	projectService := IamProjectService{s.client}
+	projects, err := projectService.ReadProjects(nil)
+	if err != nil {
+		return accountId, fmt.Errorf("error on reading the account of the caller: %s", err)
+	}
+	for _, project := range projects {
+		if id, ok := project.(map[string]interface{})["AccountId"]; ok && id != nil {
+			return fmt.Sprintf("%v", id), nil
+		}
+	}
+	return accountId, fmt.Errorf("error on reading the account of the caller: no project returned the account id")
+}
+
+// krnAccountId returns the account of a KRN `krn:ksc:<service>:<region>:<account>:<resource>`, empty if there is none
+func krnAccountId(krn string) string {
+	parts := strings.SplitN(krn, ":", 6)
+	if len(parts) != 6 || parts[0] != "krn" {
+		return ""
+	}
+	return parts[4]
+}
+
+// callerIdentityFromGetUser builds the identity of the caller from the result of GetUser without a user name, the
+// account id is taken from the KRN of the user or the role. Only the error of the main account means the caller is
+// the root, whose account id is left empty, the other errors are returned.
+func callerIdentityFromGetUser(resp *map[string]interface{}, callErr error) (data map[string]interface{}, err error) {
+	data = map[string]interface{}{
+		"account_id":    "",
+		"identity_type": callerIdentityTypeRoot,
+		"krn":           "",
+		"user_id":       "",
+		"user_name":     "",
+		"role_name":     "",
+	}
+	if callErr != nil {
+		if e, ok := callErr.(awserr.Error); ok && e.Code() == callerIdentityRootErrorCode {
+			return data, nil
+		}
+		return nil, fmt.Errorf("error on reading the identity of the caller: %s", callErr)
+	}
+
+	user, err := getSdkValue("GetUserResult.User", *resp)
+	if err != nil || user == nil {
+		return nil, fmt.Errorf("error on reading the identity of the caller: GetUser returned no user")
+	}
+	m, _ := user.(map[string]interface{})
+	krn, _ := m["Krn"].(string)
+	accountId := krnAccountId(krn)
+	if accountId == "" {
+		return nil, fmt.Errorf("error on reading the identity of the caller: GetUser returned the KRN %q without account", krn)
+	}
+	data["account_id"] = accountId
+	data["krn"] = krn
+	// the credentials of a role carry the krn of the role, such as krn:ksc:iam::<account>:role/<role>
+	for _, prefix := range []string{":role/", ":assumed-role/"} {
+		if i := strings.Index(krn, prefix); i >= 0 {
+			data["identity_type"] = callerIdentityTypeRole
+			data["role_name"] = strings.SplitN(krn[i+len(prefix):], "/", 2)[0]
+			return data, nil
+		}
+	}
+	if m["UserName"] == nil || m["UserName"] == "" {
+		return nil, fmt.Errorf("error on reading the identity of the caller: GetUser returned a user without name")
+	}
+	data["identity_type"] = callerIdentityTypeUser
+	data["user_name"] = fmt.Sprintf("%v", m["UserName"])
+	if m["UserId"] != nil {
+		data["user_id"] = fmt.Sprintf("%v", m["UserId"])
+	}
+	return data, nil
+}
+
+func (s *IamCallerIdentityService) ReadAndSetCallerIdentity(d *schema.ResourceData, r *schema.Resource) (err error) {
+	data, err := s.ReadCallerIdentity()
+	if err != nil {
+		return err
+	}
+	d.SetId(data["account_id"].(string))
+	for k, v := range data {
+		if err = d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	if outputFile, ok := d.GetOk("output_file"); ok && outputFile.(string) != "" {
+		return writeToFile(outputFile.(string), data, dataSourceOutputOptions(d))
+	}
+	return nil
+}
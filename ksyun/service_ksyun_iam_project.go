@@ -2,10 +2,26 @@ package ksyun
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-ksyun/logger"
 )
 
+const (
+	iamProjectStatusEnabled = 1
+	// the projects can not be deleted by the API, they are disabled
+	iamProjectStatusDisabled = 0
+)
+
+// iamProjectMemberIdentityTypes maps the identity types of the project members to the values of the API
+var iamProjectMemberIdentityTypes = map[string]int{
+	"user": 1,
+	"role": 2,
+}
+
 type IamProjectService struct {
 	client *KsyunClient
 }
@@ -56,17 +72,37 @@ func (s *IamProjectService) CreateIamProjectCall(d *schema.ResourceData, r *sche
 }
 
 func (s *IamProjectService) ReadAndSetIamProject(d *schema.ResourceData, r *schema.Resource) (err error) {
-
-	params := map[string]interface{}{}
-	params["ProjectName"] = d.Get("project_name")
-
-	var data []interface{}
-	data, err = s.ReadProject(params)
+	data, err := s.ReadProjectById(d.Id())
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		d.SetId("")
+		return nil
+	}
 	SdkResponseAutoResourceData(d, r, data, nil)
-
 	return
 }
 
+// ReadProjectById returns the project with the id, nil if it does not exist or is disabled
+func (s *IamProjectService) ReadProjectById(id string) (data map[string]interface{}, err error) {
+	projects, err := s.ReadProjects(nil)
+	if err != nil {
+		return data, err
+	}
+	for _, item := range projects {
+		project := item.(map[string]interface{})
+		if fmt.Sprintf("%v", project["ProjectId"]) != id {
+			continue
+		}
+		if status, ok := project["Status"].(float64); ok && int(status) != iamProjectStatusEnabled {
+			return nil, nil
+		}
+		return project, nil
+	}
+	return nil, nil
+}
+
 func (s *IamProjectService) ReadProject(condition map[string]interface{}) (data []interface{}, err error) {
 	var (
 		resp    *map[string]interface{}
@@ -133,19 +169,276 @@ func (s *IamProjectService) ReadProjects(condition map[string]interface{}) (data
 }
 
 func (s *IamProjectService) ReadAndSetIamProjects(d *schema.ResourceData, r *schema.Resource) (err error) {
-	req, err := mergeDataSourcesReq(d, r, nil)
-	logger.Debug(logger.ReqFormat, "GetAccountAllProjectList", req)
+	// the projects are filtered locally, GetAccountAllProjectList only pages
+	data, err := s.ReadProjects(nil)
 	if err != nil {
 		return err
 	}
-	data, err := s.ReadProjects(req)
-	if err != nil {
-		return err
+
+	var ids []string
+	if v, ok := d.GetOk("ids"); ok {
+		ids = SchemaSetToStringSlice(v)
+	}
+	var projects []interface{}
+	for _, item := range data {
+		project := item.(map[string]interface{})
+		// the ids are numbers in the response
+		project["ProjectId"] = fmt.Sprintf("%v", project["ProjectId"])
+		if len(ids) > 0 && !checkValueInSlice(ids, project["ProjectId"].(string)) {
+			continue
+		}
+		if status, ok := project["Status"].(float64); ok && int(status) != iamProjectStatusEnabled && !d.Get("include_all").(bool) {
+			continue
+		}
+		projects = append(projects, project)
 	}
 
 	return mergeDataSourcesResp(d, r, ksyunDataSource{
-		collection:  data,
+		collection:  projects,
+		nameField:   "ProjectName",
+		idFiled:     "ProjectId",
 		targetField: "projects",
 		extra:       map[string]SdkResponseMapping{},
 	})
 }
+
+func (s *IamProjectService) ModifyIamProject(d *schema.ResourceData, r *schema.Resource) error {
+	apiProcess := NewApiProcess(context.Background(), d, s.client, false)
+
+	modifyCall, err := s.ModifyIamProjectCall(d, r)
+	if err != nil {
+		return err
+	}
+	apiProcess.PutCalls(modifyCall)
+	return apiProcess.Run()
+}
+
+func (s *IamProjectService) ModifyIamProjectCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	// UpdateProjectInfo replaces both the name and the description
+	params := map[string]interface{}{
+		"ProjectId":   d.Id(),
+		"ProjectName": d.Get("project_name"),
+		"ProjectDesc": d.Get("project_desc"),
+	}
+	callback = ApiCall{
+		param:  &params,
+		action: "UpdateProjectInfo",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.iamconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.UpdateProjectInfo(call.param)
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *IamProjectService) DeleteIamProject(d *schema.ResourceData) error {
+	apiProcess := NewApiProcess(context.Background(), d, s.client, true)
+
+	deleteCall, err := s.DeleteIamProjectCall(d)
+	if err != nil {
+		return err
+	}
+	apiProcess.PutCalls(deleteCall)
+	return apiProcess.Run()
+}
+
+func (s *IamProjectService) DeleteIamProjectCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	params := map[string]interface{}{
+		"ProjectId": d.Id(),
+		"Status":    iamProjectStatusDisabled,
+	}
+	callback = ApiCall{
+		param:  &params,
+		action: "UpdateProjectStatus",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.iamconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.UpdateProjectStatus(call.param)
+			return resp, err
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			if notFoundError(baseErr) {
+				return nil
+			}
+			return baseErr
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return err
+		},
+	}
+	return callback, err
+}
+
+// parseIamProjectMemberId splits the id of a project member, `<project_id>:<member_id>`
+func parseIamProjectMemberId(id string) (projectId, memberId string, err error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid project member id %q, expected `<project_id>:<member_id>`", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func (s *IamProjectService) CreateIamProjectMember(d *schema.ResourceData, r *schema.Resource) error {
+	apiProcess := NewApiProcess(context.Background(), d, s.client, false)
+
+	createCall, err := s.CreateIamProjectMemberCall(d, r)
+	if err != nil {
+		return err
+	}
+	apiProcess.PutCalls(createCall)
+	return apiProcess.Run()
+}
+
+func (s *IamProjectService) CreateIamProjectMemberCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	params := map[string]interface{}{
+		"ProjectId":    d.Get("project_id"),
+		"IdentityId":   d.Get("identity_id"),
+		"IdentityType": iamProjectMemberIdentityTypes[d.Get("identity_type").(string)],
+	}
+	callback = ApiCall{
+		param:  &params,
+		action: "AddProjectMember",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.iamconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.AddProjectMember(call.param)
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			// the result is the id of the member
+			id, err := getSdkValue("Result", *resp)
+			if err != nil {
+				return err
+			}
+			memberId, ok := id.(float64)
+			if !ok || memberId == 0 {
+				return fmt.Errorf("unexpected member id %v", id)
+			}
+			d.SetId(fmt.Sprintf("%s:%s", d.Get("project_id"), Float64ToString(memberId)))
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *IamProjectService) ReadProjectMembers(projectId string) (data []interface{}, err error) {
+	var (
+		resp    *map[string]interface{}
+		results interface{}
+	)
+	id, err := strconv.Atoi(projectId)
+	if err != nil {
+		return data, fmt.Errorf("invalid project id %q", projectId)
+	}
+	condition := map[string]interface{}{
+		"ProjectId": id,
+	}
+	conn := s.client.iamconn
+	action := "ListProjectMember"
+	logger.Debug(logger.ReqFormat, action, condition)
+	resp, err = conn.ListProjectMember(&condition)
+	if err != nil {
+		return data, err
+	}
+	results, err = getSdkValue("ListProjectMember", *resp)
+	if err != nil {
+		return data, err
+	}
+	data, _ = results.([]interface{})
+	return data, err
+}
+
+func (s *IamProjectService) ReadAndSetIamProjectMember(d *schema.ResourceData, r *schema.Resource) (err error) {
+	projectId, memberId, err := parseIamProjectMemberId(d.Id())
+	if err != nil {
+		return err
+	}
+	members, err := s.ReadProjectMembers(projectId)
+	if err != nil {
+		if notFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	for _, item := range members {
+		member := item.(map[string]interface{})
+		if fmt.Sprintf("%v", member["MemberId"]) != memberId {
+			continue
+		}
+		_ = d.Set("project_id", projectId)
+		SdkResponseAutoResourceData(d, r, member, map[string]SdkResponseMapping{
+			"MemberId": {
+				Field: "member_id",
+				FieldRespFunc: func(i interface{}) interface{} {
+					return fmt.Sprintf("%v", i)
+				},
+			},
+			"IdentityType": {
+				Field: "identity_type",
+				FieldRespFunc: func(i interface{}) interface{} {
+					for k, v := range iamProjectMemberIdentityTypes {
+						if identityType, ok := i.(float64); ok && v == int(identityType) {
+							return k
+						}
+					}
+					return d.Get("identity_type")
+				},
+			},
+		})
+		return nil
+	}
+	d.SetId("")
+	return nil
+}
+
+func (s *IamProjectService) DeleteIamProjectMember(d *schema.ResourceData) error {
+	apiProcess := NewApiProcess(context.Background(), d, s.client, true)
+
+	deleteCall, err := s.DeleteIamProjectMemberCall(d)
+	if err != nil {
+		return err
+	}
+	apiProcess.PutCalls(deleteCall)
+	return apiProcess.Run()
+}
+
+func (s *IamProjectService) DeleteIamProjectMemberCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	projectId, memberId, err := parseIamProjectMemberId(d.Id())
+	if err != nil {
+		return callback, err
+	}
+	params := map[string]interface{}{
+		"ProjectId": projectId,
+		"MemberIds": memberId,
+	}
+	callback = ApiCall{
+		param:  &params,
+		action: "DeleteProjectMember",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.iamconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.DeleteProjectMember(call.param)
+			return resp, err
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			if notFoundError(baseErr) {
+				return nil
+			}
+			return baseErr
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return err
+		},
+	}
+	return callback, err
+}
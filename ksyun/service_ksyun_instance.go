@@ -59,14 +59,14 @@ func (s *KecService) readAndSetKecInstance(d *schema.ResourceData, r *schema.Res
 							},
 						}
 						SdkResponseAutoResourceData(d, r, vif, extra)
-						// read dns info
+						// read dns and ipv6 info
 						var networkInterface map[string]interface{}
 						networkInterface, err = s.readKecNetworkInterface(d.Get("network_interface_id").(string))
 						if err != nil {
 							return resource.NonRetryableError(err)
 						}
 						for k := range networkInterface {
-							if k == "DNS1" || k == "DNS2" {
+							if k == "DNS1" || k == "DNS2" || k == "Ipv6Address" {
 								continue
 							}
 							delete(networkInterface, k)
@@ -578,6 +578,9 @@ func transKecInstanceParams(d *schema.ResourceData, resource *schema.Resource) (
 				"disk_type":  "Type",
 			}, Type: TransformListN,
 		},
+		"is_distribute_ipv6": {
+			mapping: "DistributeIpv6",
+		},
 		"instance_status":        {Ignore: true},
 		"force_delete":           {Ignore: true},
 		"force_reinstall_system": {Ignore: true},
@@ -1461,20 +1464,29 @@ func (s *KecService) createNetworkInterfaceCall(d *schema.ResourceData, resource
 	if data["SubnetType"] != "Normal" {
 		return callback, fmt.Errorf("Subnet type %s not support for kec network interface ", data["SubnetType"].(string))
 	}
+	createReq, err := networkInterfaceCreateRequest(d, resource)
+	if err != nil {
+		return callback, err
+	}
+
+	return vpcService.CreateNetworkInterfaceCall(&createReq)
+}
+
+// networkInterfaceCreateRequest maps the arguments of the network interface to the CreateNetworkInterface request,
+// is_distribute_ipv6 is sent as DistributeIpv6 as the primary network interface of an instance does
+func networkInterfaceCreateRequest(d *schema.ResourceData, resource *schema.Resource) (map[string]interface{}, error) {
 	transform := map[string]SdkReqTransform{
 		"security_group_ids": {
 			mapping: "SecurityGroupId",
 			Type:    TransformWithN,
 		},
+		"is_distribute_ipv6": {
+			mapping: "DistributeIpv6",
+		},
 	}
-	createReq, err := SdkRequestAutoMapping(d, resource, false, transform, nil, SdkReqParameter{
+	return SdkRequestAutoMapping(d, resource, false, transform, nil, SdkReqParameter{
 		onlyTransform: false,
 	})
-	if err != nil {
-		return callback, err
-	}
-
-	return vpcService.CreateNetworkInterfaceCall(&createReq)
 }
 
 func (s *KecService) AssignPrivateIpsCall(d *schema.ResourceData, resource *schema.Resource) (callback ApiCall, err error) {
@@ -1584,6 +1596,8 @@ func (s *KecService) readAndSetNetworkInterface(d *schema.ResourceData, resource
 		},
 	}
 	SdkResponseAutoResourceData(d, resource, data, extra)
+	ipv6Address, _ := data["Ipv6Address"].(string)
+	_ = d.Set("is_distribute_ipv6", ipv6Address != "")
 	_, manually := d.GetOk("secondary_private_ips")
 	_, count := d.GetOk("secondary_private_ip_address_count")
 
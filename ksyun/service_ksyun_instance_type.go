@@ -0,0 +1,215 @@
+package ksyun
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-ksyun/ksyun/internal/pkg/helper"
+	"github.com/terraform-providers/terraform-provider-ksyun/ksyun/internal/structor/v1/kec"
+	"github.com/terraform-providers/terraform-provider-ksyun/logger"
+)
+
+// instanceTypeCondition holds the arguments of ksyun_instance_types which are evaluated locally,
+// a zero value means no restriction
+type instanceTypeCondition struct {
+	nameRegex        *regexp.Regexp
+	availabilityZone string
+	minCpu, maxCpu   int
+	minMemory        int
+	maxMemory        int
+	minGpu           int
+	gpuSpec          string
+	excludeSoldOut   bool
+}
+
+func (s *KecService) ReadInstanceTypeConfigs(condition map[string]interface{}) (data []kec.InstanceTypeConfig, err error) {
+	var (
+		resp *map[string]interface{}
+	)
+	conn := s.client.kecconn
+	action := "DescribeInstanceTypeConfigs"
+	logger.Debug(logger.ReqFormat, action, condition)
+	if len(condition) == 0 {
+		resp, err = conn.DescribeInstanceTypeConfigs(nil)
+	} else {
+		resp, err = conn.DescribeInstanceTypeConfigs(&condition)
+	}
+	if err != nil {
+		return data, fmt.Errorf("error on reading instance types: %s", err)
+	}
+	logger.Debug(logger.RespFormat, action, condition, *resp)
+
+	var result kec.DescribeInstanceTypeConfigsResponse
+	if err = helper.MapstructureFiller(*resp, &result, ""); err != nil {
+		return data, fmt.Errorf("error on reading instance types: %s", err)
+	}
+	return result.InstanceTypeConfigSet, err
+}
+
+func (s *KecService) ReadAndSetInstanceTypes(d *schema.ResourceData, r *schema.Resource) (err error) {
+	req := make(map[string]interface{})
+	if family, ok := d.GetOk("instance_family"); ok {
+		req["Filter.1.Name"] = "instance-family"
+		req["Filter.1.Value.1"] = family
+	}
+
+	condition := instanceTypeCondition{
+		availabilityZone: d.Get("availability_zone").(string),
+		minCpu:           d.Get("min_cpu").(int),
+		maxCpu:           d.Get("max_cpu").(int),
+		minMemory:        d.Get("min_memory").(int),
+		maxMemory:        d.Get("max_memory").(int),
+		minGpu:           d.Get("min_gpu").(int),
+		gpuSpec:          d.Get("gpu_spec").(string),
+		excludeSoldOut:   d.Get("exclude_sold_out").(bool),
+	}
+	if nameRegex, ok := d.GetOk("name_regex"); ok {
+		condition.nameRegex = regexp.MustCompile(nameRegex.(string))
+	}
+
+	configs, err := s.ReadInstanceTypeConfigs(req)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = SdkSliceMapping(d, filterInstanceTypeConfigs(configs, condition), SdkSliceData{
+		IdField: "instance_type",
+		IdMappingFunc: func(idField string, item map[string]interface{}) string {
+			return item[idField].(string)
+		},
+		SliceMappingFunc: func(item map[string]interface{}) map[string]interface{} {
+			return item
+		},
+		TargetName: "instance_types",
+	})
+	return err
+}
+
+// filterInstanceTypeConfigs returns the instance types matching the condition flattened for the schema,
+// sorted by vCPUs, memory and type so the smallest matching type comes first
+func filterInstanceTypeConfigs(configs []kec.InstanceTypeConfig, condition instanceTypeCondition) []map[string]interface{} {
+	sort.SliceStable(configs, func(i, j int) bool {
+		ci, cj := configs[i].Configure(), configs[j].Configure()
+		if ci.VCPU != cj.VCPU {
+			return ci.VCPU < cj.VCPU
+		}
+		if ci.MemoryGb != cj.MemoryGb {
+			return ci.MemoryGb < cj.MemoryGb
+		}
+		return configs[i].InstanceType < configs[j].InstanceType
+	})
+
+	result := make([]map[string]interface{}, 0, len(configs))
+	for _, config := range configs {
+		configure := config.Configure()
+		if condition.nameRegex != nil && !condition.nameRegex.MatchString(config.InstanceType) {
+			continue
+		}
+		if configure.VCPU < condition.minCpu || (condition.maxCpu > 0 && configure.VCPU > condition.maxCpu) {
+			continue
+		}
+		if configure.MemoryGb < condition.minMemory || (condition.maxMemory > 0 && configure.MemoryGb > condition.maxMemory) {
+			continue
+		}
+		if configure.GPU < condition.minGpu || (condition.gpuSpec != "" && config.GPUSpec != condition.gpuSpec) {
+			continue
+		}
+
+		var zones []string
+		for _, zone := range config.AvailabilityZoneSet {
+			zones = append(zones, zone.AzCode)
+		}
+		if condition.availabilityZone != "" && !checkValueInSlice(zones, condition.availabilityZone) {
+			continue
+		}
+
+		soldOutZones := instanceTypeSoldOutZones(config)
+		soldOut := len(zones) > 0 && len(soldOutZones) == len(zones)
+		if condition.availabilityZone != "" {
+			soldOut = checkValueInSlice(soldOutZones, condition.availabilityZone)
+		}
+		if soldOut && condition.excludeSoldOut {
+			continue
+		}
+
+		var systemDiskTypes []string
+		for _, disk := range config.SystemDiskQuotaSet {
+			systemDiskTypes = append(systemDiskTypes, disk.SystemDiskType)
+		}
+		var dataDisks []interface{}
+		for _, disk := range config.DataDiskQuotaSet {
+			var diskSoldOutZones []string
+			for _, zone := range disk.AvailabilityZoneSet {
+				if zone.IsSellOut {
+					diskSoldOutZones = append(diskSoldOutZones, zone.AzCode)
+				}
+			}
+			dataDisks = append(dataDisks, map[string]interface{}{
+				"disk_type":      disk.DataDiskType,
+				"min_size":       disk.DataDiskMinSize,
+				"max_size":       disk.DataDiskMaxSize,
+				"max_count":      disk.DataDiskCount,
+				"sold_out_zones": diskSoldOutZones,
+			})
+		}
+
+		item := flattenInstanceConfigure(configure)
+		for k, v := range map[string]interface{}{
+			"instance_type":           config.InstanceType,
+			"instance_family":         config.InstanceFamily,
+			"instance_family_name":    config.InstanceFamilyName,
+			"gpu_spec":                config.GPUSpec,
+			"network_bandwidth":       config.NetworkPerformance.Bandwidth,
+			"network_pps":             config.NetworkPerformance.Pps,
+			"network_interface_quota": config.NetworkInterfaceQuota.NetworkInterfaceCount,
+			"private_ip_quota":        config.PrivateIpQuota.PrivateIpCount,
+			"availability_zones":      zones,
+			"sold_out_zones":          soldOutZones,
+			"sold_out":                soldOut,
+			"system_disk_types":       systemDiskTypes,
+			"data_disks":              dataDisks,
+		} {
+			item[k] = v
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// flattenInstanceConfigure converts the vCPU, memory, GPU and vGPU specifications into the fields of an instance type
+func flattenInstanceConfigure(configure kec.InstanceConfigure) map[string]interface{} {
+	return map[string]interface{}{
+		"cpu":    configure.VCPU,
+		"memory": configure.MemoryGb,
+		"gpu":    configure.GPU,
+		"vgpu":   configure.VGPU,
+	}
+}
+
+// instanceTypeSoldOutZones returns the zones of an instance type which are marked sold out, or where every
+// data disk reporting that zone is sold out, this is how the types with local disks run out of stock
+func instanceTypeSoldOutZones(config kec.InstanceTypeConfig) []string {
+	var soldOutZones []string
+	for _, zone := range config.AvailabilityZoneSet {
+		if zone.IsSellOut {
+			soldOutZones = append(soldOutZones, zone.AzCode)
+			continue
+		}
+		reported, available := false, false
+		for _, disk := range config.DataDiskQuotaSet {
+			for _, diskZone := range disk.AvailabilityZoneSet {
+				if diskZone.AzCode != zone.AzCode {
+					continue
+				}
+				reported = true
+				available = available || !diskZone.IsSellOut
+			}
+		}
+		if reported && !available {
+			soldOutZones = append(soldOutZones, zone.AzCode)
+		}
+	}
+	return soldOutZones
+}
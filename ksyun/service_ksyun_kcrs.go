@@ -96,6 +96,33 @@ func (s *KcrsService) CreateKcrsNamespace(d *schema.ResourceData, r *schema.Reso
 
 	return apiProcess.Run()
 }
+
+// ModifyKcrsInstanceProject moves the instance to the project of project_id
+func (s *KcrsService) ModifyKcrsInstanceProject(d *schema.ResourceData, r *schema.Resource) error {
+	transform := map[string]SdkReqTransform{
+		"project_id": {},
+	}
+	updateReq, err := SdkRequestAutoMapping(d, r, true, transform, nil)
+	if err != nil {
+		return err
+	}
+	if len(updateReq) == 0 {
+		return nil
+	}
+	call := ApiCall{
+		param: &updateReq,
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			return resp, ModifyProjectInstanceNew(d.Id(), call.param, client)
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			return err
+		},
+	}
+	apiProcess := NewApiProcess(context.Background(), d, s.client, true)
+	apiProcess.PutCalls(call)
+	return apiProcess.Run()
+}
+
 func (s *KcrsService) ModifyKcrsInstanceEoIEndpoint(d *schema.ResourceData, r *schema.Resource) error {
 	apiProcess := NewApiProcess(context.Background(), d, s.client, true)
 
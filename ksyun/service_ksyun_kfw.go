@@ -239,11 +239,6 @@ func (s *KfwService) ReadAndSetKfwInstances(d *schema.ResourceData, resource *sc
 	// Apply filters if provided
 	applyKfwInstancesFilters(d, &returnData, mockInstance)
 
-	// Set total count
-	if err := d.Set("total_count", len(returnData)); err != nil {
-		return err
-	}
-
 	// Transform data to match output schema
 	var kfwInstances []map[string]interface{}
 	for _, instance := range returnData {
@@ -266,6 +261,16 @@ func (s *KfwService) ReadAndSetKfwInstances(d *schema.ResourceData, resource *sc
 		kfwInstances = append(kfwInstances, kfwInstance)
 	}
 
+	// Apply the generic filters on the transformed data
+	if _, kfwInstances, err = filterDataSourceItems(d, nil, kfwInstances); err != nil {
+		return err
+	}
+
+	// Set total count
+	if err := d.Set("total_count", len(kfwInstances)); err != nil {
+		return err
+	}
+
 	// Set the output data
 	if err := d.Set("kfw_instances", kfwInstances); err != nil {
 		return err
@@ -392,11 +397,6 @@ func (s *KfwService) ReadAndSetKfwAddrbooks(d *schema.ResourceData, resource *sc
 	applyKfwAddrbooksFilters(d, &returnData, mockAddrbook1)
 	applyKfwAddrbooksFilters(d, &returnData, mockAddrbook2)
 
-	// Set total count
-	if err := d.Set("total_count", len(returnData)); err != nil {
-		return err
-	}
-
 	// Transform data to match output schema
 	var kfwAddrbooks []map[string]interface{}
 	for _, addrbook := range returnData {
@@ -413,6 +413,16 @@ func (s *KfwService) ReadAndSetKfwAddrbooks(d *schema.ResourceData, resource *sc
 		kfwAddrbooks = append(kfwAddrbooks, kfwAddrbook)
 	}
 
+	// Apply the generic filters on the transformed data
+	if _, kfwAddrbooks, err = filterDataSourceItems(d, nil, kfwAddrbooks); err != nil {
+		return err
+	}
+
+	// Set total count
+	if err := d.Set("total_count", len(kfwAddrbooks)); err != nil {
+		return err
+	}
+
 	// Set the output data
 	if err := d.Set("kfw_addrbooks", kfwAddrbooks); err != nil {
 		return err
@@ -581,11 +591,6 @@ func (s *KfwService) ReadAndSetKfwAcls(d *schema.ResourceData, resource *schema.
 	applyKfwAclsFilters(d, &returnData, mockAcl2)
 	applyKfwAclsFilters(d, &returnData, mockAcl3)
 
-	// Set total count
-	if err := d.Set("total_count", len(returnData)); err != nil {
-		return err
-	}
-
 	// Transform data to match output schema
 	var kfwAcls []map[string]interface{}
 	for _, acl := range returnData {
@@ -619,6 +624,16 @@ func (s *KfwService) ReadAndSetKfwAcls(d *schema.ResourceData, resource *schema.
 		kfwAcls = append(kfwAcls, kfwAcl)
 	}
 
+	// Apply the generic filters on the transformed data
+	if _, kfwAcls, err = filterDataSourceItems(d, nil, kfwAcls); err != nil {
+		return err
+	}
+
+	// Set total count
+	if err := d.Set("total_count", len(kfwAcls)); err != nil {
+		return err
+	}
+
 	// Set the output data
 	if err := d.Set("kfw_acls", kfwAcls); err != nil {
 		return err
@@ -781,11 +796,6 @@ func (s *KfwService) ReadAndSetKfwServiceGroups(d *schema.ResourceData, resource
 	applyKfwServiceGroupsFilters(d, &returnData, mockServiceGroup2)
 	applyKfwServiceGroupsFilters(d, &returnData, mockServiceGroup3)
 
-	// Set total count
-	if err := d.Set("total_count", len(returnData)); err != nil {
-		return err
-	}
-
 	// Transform data to match output schema
 	var kfwServiceGroups []map[string]interface{}
 	for _, sg := range returnData {
@@ -800,6 +810,16 @@ func (s *KfwService) ReadAndSetKfwServiceGroups(d *schema.ResourceData, resource
 		kfwServiceGroups = append(kfwServiceGroups, kfwServiceGroup)
 	}
 
+	// Apply the generic filters on the transformed data
+	if _, kfwServiceGroups, err = filterDataSourceItems(d, nil, kfwServiceGroups); err != nil {
+		return err
+	}
+
+	// Set total count
+	if err := d.Set("total_count", len(kfwServiceGroups)); err != nil {
+		return err
+	}
+
 	// Set the output data
 	if err := d.Set("kfw_service_groups", kfwServiceGroups); err != nil {
 		return err
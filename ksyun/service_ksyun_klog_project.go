@@ -1,6 +1,8 @@
 package ksyun
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	klog "github.com/kingsoftcloud/sdk-go/v2/ksyun/client/klog/v20200731"
 )
@@ -91,3 +93,20 @@ func (lg *KlogProjectService) ReadAndSetProjects(d *schema.ResourceData, r *sche
 		//},
 	})
 }
+
+// CheckLogPool returns an error unless the log pool exists in the klog project
+func (lg *KlogProjectService) CheckLogPool(projectName string, logPoolName string) (err error) {
+	req := klog.NewListLogPoolsRequest()
+	req.ProjectName = &projectName
+	req.LogPoolName = &logPoolName
+	resp, err := lg.client.klogconn.ListLogPoolsSend(req)
+	if err != nil {
+		return fmt.Errorf("unable to list the log pools of the klog project %s: %s", projectName, err)
+	}
+	for _, pool := range resp.LogPools {
+		if pool.LogPoolName != nil && *pool.LogPoolName == logPoolName {
+			return nil
+		}
+	}
+	return fmt.Errorf("the log pool %s does not exist in the klog project %s", logPoolName, projectName)
+}
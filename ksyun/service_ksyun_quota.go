@@ -0,0 +1,271 @@
+package ksyun
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-ksyun/logger"
+)
+
+// the services whose quotas are reported by ksyun_quotas and checked by the quota_check of the provider,
+// the instances are counted per availability zone and the security group rules per security group
+const (
+	quotaServiceEip               = "eip"
+	quotaServiceVpc               = "vpc"
+	quotaServiceInstance          = "instance"
+	quotaServiceSlb               = "slb"
+	quotaServiceSecurityGroupRule = "security_group_rule"
+)
+
+var quotaServices = []string{
+	quotaServiceEip,
+	quotaServiceVpc,
+	quotaServiceInstance,
+	quotaServiceSlb,
+	quotaServiceSecurityGroupRule,
+}
+
+// quotaUsage is the number of resources of a service in a scope, the scope is the availability zone of the
+// instances, the security group of the rules and empty for the quotas of the region
+type quotaUsage struct {
+	service string
+	scope   string
+	used    int
+}
+
+// quotaTracker keeps, within one run, the usage read for the pre-flight checks and the number of resources the
+// plan creates so far per service and scope, so a plan creating several resources is checked as a whole. The
+// provider protocol customizes the diff of each planned resource once, so every checked diff counts as one.
+type quotaTracker struct {
+	sync.Mutex
+	used    map[string]map[string]int
+	planned map[string]int
+}
+
+func newQuotaTracker() *quotaTracker {
+	return &quotaTracker{
+		used:    make(map[string]map[string]int),
+		planned: make(map[string]int),
+	}
+}
+
+type QuotaService struct {
+	client *KsyunClient
+}
+
+func (s *QuotaService) ReadQuotaUsages(service string) (usages []quotaUsage, err error) {
+	var data []interface{}
+	switch service {
+	case quotaServiceEip:
+		eipService := EipService{s.client}
+		data, err = eipService.ReadAddresses(nil)
+	case quotaServiceVpc:
+		data, err = s.readAll("DescribeVpcs", "VpcSet", s.client.vpcconn.DescribeVpcs)
+	case quotaServiceInstance:
+		kecService := KecService{s.client}
+		data, err = kecService.readKecInstances(nil)
+	case quotaServiceSlb:
+		data, err = s.readAll("DescribeLoadBalancers", "LoadBalancerDescriptions", s.client.slbconn.DescribeLoadBalancers)
+	case quotaServiceSecurityGroupRule:
+		data, err = s.readAll("DescribeSecurityGroups", "SecurityGroupSet", s.client.vpcconn.DescribeSecurityGroups)
+	default:
+		return usages, fmt.Errorf("unsupported quota service %q", service)
+	}
+	if err != nil {
+		return usages, fmt.Errorf("error on reading the usage of %s: %s", service, err)
+	}
+	return countQuotaUsages(service, data), nil
+}
+
+// ReadQuotaLimit returns the limit of a service set in the quota_limits of the provider, the API does not report
+// the quotas of the account, so ok is false when no limit is set.
+func (s *QuotaService) ReadQuotaLimit(service string) (limit int, ok bool) {
+	if s.client.config != nil {
+		limit, ok = s.client.config.QuotaLimits[service]
+	}
+	return limit, ok
+}
+
+// readAll reads all the pages of a describe call
+func (s *QuotaService) readAll(action string, resultKey string, call func(*map[string]interface{}) (*map[string]interface{}, error)) (data []interface{}, err error) {
+	var (
+		resp    *map[string]interface{}
+		results interface{}
+	)
+	return pageQueryWithNextToken(nil, "MaxResults", "NextToken", 100, func(condition map[string]interface{}) ([]interface{}, string, error) {
+		logger.Debug(logger.ReqFormat, action, condition)
+		resp, err = call(&condition)
+		if err != nil {
+			return data, "", err
+		}
+		nextToken := (*resp)["NextToken"]
+		results, err = getSdkValue(resultKey, *resp)
+		if err != nil {
+			return data, "", err
+		}
+		data, _ = results.([]interface{})
+		return data, indirectString(nextToken), err
+	})
+}
+
+// countQuotaUsages counts the described resources of a service per scope, sorted by scope
+func countQuotaUsages(service string, data []interface{}) []quotaUsage {
+	counts := make(map[string]int)
+	switch service {
+	case quotaServiceInstance:
+		for _, item := range data {
+			if zone, ok := item.(map[string]interface{})["AvailabilityZone"].(string); ok {
+				counts[zone]++
+			}
+		}
+	case quotaServiceSecurityGroupRule:
+		for _, item := range data {
+			group := item.(map[string]interface{})
+			id, _ := group["SecurityGroupId"].(string)
+			entries, _ := group["SecurityGroupEntrySet"].([]interface{})
+			counts[id] = len(entries)
+		}
+	default:
+		counts[""] = len(data)
+	}
+
+	usages := make([]quotaUsage, 0, len(counts))
+	for scope, used := range counts {
+		usages = append(usages, quotaUsage{service: service, scope: scope, used: used})
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].scope < usages[j].scope
+	})
+	return usages
+}
+
+func (s *QuotaService) ReadAndSetQuotas(d *schema.ResourceData, r *schema.Resource) (err error) {
+	services := quotaServices
+	if v, ok := d.GetOk("services"); ok {
+		services = SchemaSetToStringSlice(v)
+		sort.Strings(services)
+	}
+
+	var quotas []interface{}
+	for _, service := range services {
+		usages, err := s.ReadQuotaUsages(service)
+		if err != nil {
+			return err
+		}
+		limit, limited := s.ReadQuotaLimit(service)
+		for _, usage := range usages {
+			remaining := -1
+			if limited {
+				remaining = limit - usage.used
+			}
+			quotas = append(quotas, map[string]interface{}{
+				"service":   usage.service,
+				"scope":     usage.scope,
+				"used":      usage.used,
+				"limit":     limit,
+				"remaining": remaining,
+			})
+		}
+	}
+
+	_, _, err = SdkSliceMapping(d, quotas, SdkSliceData{
+		IdMappingFunc: func(idField string, item map[string]interface{}) string {
+			return fmt.Sprintf("%s:%s", item["service"], item["scope"])
+		},
+		SliceMappingFunc: func(item map[string]interface{}) map[string]interface{} {
+			return item
+		},
+		TargetName: "quotas",
+	})
+	return err
+}
+
+// CheckQuota fails the diff of a new resource when the resources of the service already in the scope,
+// plus the ones planned so far in this run, reach the limit of the service. It is a no-op unless
+// quota_check is enabled and the limit of the service is set in quota_limits.
+func (s *QuotaService) CheckQuota(d *schema.ResourceDiff, service string, scope string) error {
+	if d.Id() != "" || !s.quotaCheckEnabled() {
+		return nil
+	}
+	limit, ok := s.ReadQuotaLimit(service)
+	if !ok {
+		return nil
+	}
+
+	tracker := s.client.quotaTracker
+	tracker.Lock()
+	defer tracker.Unlock()
+	if _, ok := tracker.used[service]; !ok {
+		usages, err := s.ReadQuotaUsages(service)
+		if err != nil {
+			return err
+		}
+		tracker.used[service] = make(map[string]int)
+		for _, usage := range usages {
+			tracker.used[service][usage.scope] = usage.used
+		}
+	}
+
+	key := service + ":" + scope
+	used := tracker.used[service][scope]
+	planned := tracker.planned[key]
+	if used+planned >= limit {
+		if scope != "" {
+			return fmt.Errorf("creating this %s exceeds the quota of %s in %s, %d used, %d planned, the limit is %d",
+				service, service, scope, used, planned, limit)
+		}
+		return fmt.Errorf("creating this %s exceeds the quota of %s in region %s, %d used, %d planned, the limit is %d",
+			service, service, s.client.region, used, planned, limit)
+	}
+	tracker.planned[key]++
+	return nil
+}
+
+func (s *QuotaService) quotaCheckEnabled() bool {
+	return s.client.config != nil && s.client.config.QuotaCheck && s.client.quotaTracker != nil
+}
+
+// instanceQuotaScope returns the availability zone of the subnet of a new instance, empty if the subnet
+// is not known at plan time
+func (s *QuotaService) instanceQuotaScope(d *schema.ResourceDiff) (string, error) {
+	subnetId, ok := d.Get("subnet_id").(string)
+	if !ok || subnetId == "" || !d.NewValueKnown("subnet_id") {
+		return "", nil
+	}
+	vpcService := VpcService{s.client}
+	subnets, err := vpcService.ReadSubnets(map[string]interface{}{
+		"SubnetId.1": subnetId,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(subnets) == 0 {
+		return "", nil
+	}
+	zone, _ := subnets[0].(map[string]interface{})["AvailabilityZoneName"].(string)
+	return zone, nil
+}
+
+func eipQuotaCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	quotaService := QuotaService{meta.(*KsyunClient)}
+	return quotaService.CheckQuota(d, quotaServiceEip, "")
+}
+
+func lbQuotaCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	quotaService := QuotaService{meta.(*KsyunClient)}
+	return quotaService.CheckQuota(d, quotaServiceSlb, "")
+}
+
+func instanceQuotaCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	quotaService := QuotaService{meta.(*KsyunClient)}
+	if d.Id() != "" || !quotaService.quotaCheckEnabled() {
+		return nil
+	}
+	zone, err := quotaService.instanceQuotaScope(d)
+	if err != nil || zone == "" {
+		return err
+	}
+	return quotaService.CheckQuota(d, quotaServiceInstance, zone)
+}
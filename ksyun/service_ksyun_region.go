@@ -0,0 +1,51 @@
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-ksyun/logger"
+)
+
+func (s *KecService) ReadRegions() (data []interface{}, err error) {
+	var (
+		resp    *map[string]interface{}
+		results interface{}
+	)
+	conn := s.client.kecconn
+	action := "DescribeRegions"
+	logger.Debug(logger.ReqFormat, action, nil)
+	resp, err = conn.DescribeRegions(nil)
+	if err != nil {
+		return data, err
+	}
+
+	results, err = getSdkValue("RegionSet", *resp)
+	if err != nil {
+		return data, err
+	}
+	data = results.([]interface{})
+	return data, err
+}
+
+func (s *KecService) ReadAndSetRegions(d *schema.ResourceData, r *schema.Resource) (err error) {
+	data, err := s.ReadRegions()
+	if err != nil {
+		return err
+	}
+	var newData []interface{}
+	for _, region := range data {
+		m := region.(map[string]interface{})
+		current := m["Region"] == s.client.region
+		if d.Get("current").(bool) && !current {
+			continue
+		}
+		m["Current"] = current
+		newData = append(newData, m)
+	}
+
+	return mergeDataSourcesResp(d, r, ksyunDataSource{
+		collection:  newData,
+		nameField:   "RegionName",
+		idFiled:     "Region",
+		targetField: "regions",
+	})
+}
@@ -4,11 +4,16 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"log"
+	"net"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -302,6 +307,7 @@ func (s *VpcService) ReadVpc(d *schema.ResourceData, vpcId string) (data map[str
 		if val, ok := data["Ipv6CidrBlockAssociationSet"]; !ok {
 			data["Ipv6CidrBlockAssociationSet"] = val
 		}
+		data["Ipv6CidrBlock"] = firstIpv6CidrBlock(data)
 	}
 	if len(data) == 0 {
 		return data, fmt.Errorf("Vpc %s not exist ", vpcId)
@@ -337,6 +343,10 @@ func (s *VpcService) ReadAndSetVpcs(d *schema.ResourceData, r *schema.Resource)
 	if err != nil {
 		return err
 	}
+	for _, item := range data {
+		vpc := item.(map[string]interface{})
+		vpc["Ipv6CidrBlock"] = firstIpv6CidrBlock(vpc)
+	}
 
 	return mergeDataSourcesResp(d, r, ksyunDataSource{
 		collection:  data,
@@ -392,7 +402,10 @@ func (s *VpcService) CreateVpc(d *schema.ResourceData, r *schema.Resource) (err
 }
 
 func (s *VpcService) ModifyVpcCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
-	req, err := SdkRequestAutoMapping(d, r, true, nil, nil)
+	transform := map[string]SdkReqTransform{
+		"provided_ipv6_cidr_block": {Ignore: true},
+	}
+	req, err := SdkRequestAutoMapping(d, r, true, transform, nil)
 	if err != nil {
 		return callback, err
 	}
@@ -421,7 +434,43 @@ func (s *VpcService) ModifyVpc(d *schema.ResourceData, r *schema.Resource) (err
 	if err != nil {
 		return err
 	}
-	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+	ipv6Call, err := s.AssociateVpcIpv6CidrBlockCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call, ipv6Call}, d, s.client, true)
+}
+
+// AssociateVpcIpv6CidrBlockCall assigns an IPv6 CIDR block to the vpc when provided_ipv6_cidr_block is turned on,
+// the request asks for it by ProvidedIpv6CidrBlock as CreateVpc does, and the response must list the block in
+// the Ipv6CidrBlockAssociationSet of the vpc
+func (s *VpcService) AssociateVpcIpv6CidrBlockCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	if !d.HasChange("provided_ipv6_cidr_block") || !d.Get("provided_ipv6_cidr_block").(bool) {
+		return callback, err
+	}
+	req := map[string]interface{}{
+		"VpcId":                 d.Id(),
+		"ProvidedIpv6CidrBlock": true,
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "AssociateVpcCidrBlock",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.vpcconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.AssociateVpcCidrBlock(call.param)
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			blocks, _ := getSdkValue("Vpc.Ipv6CidrBlockAssociationSet", *resp)
+			if items, _ := blocks.([]interface{}); len(items) == 0 {
+				return fmt.Errorf("no IPv6 CIDR block is associated with vpc %s", d.Id())
+			}
+			return err
+		},
+	}
+	return callback, err
 }
 
 func (s *VpcService) RemoveVpcCall(d *schema.ResourceData) (callback ApiCall, err error) {
@@ -500,27 +549,24 @@ func (s *VpcService) ReadSubnets(condition map[string]interface{}) (data []inter
 		resp    *map[string]interface{}
 		results interface{}
 	)
-	conn := s.client.vpcconn
-	action := "DescribeSubnets"
-	logger.Debug(logger.ReqFormat, action, condition)
-	if condition == nil {
-		resp, err = conn.DescribeSubnets(nil)
+	// all the pages are read, the cidr allocation of ksyun_subnet needs every subnet of the vpc
+	return pageQueryWithNextToken(condition, "MaxResults", "NextToken", 100, func(condition map[string]interface{}) ([]interface{}, string, error) {
+		conn := s.client.vpcconn
+		action := "DescribeSubnets"
+		logger.Debug(logger.ReqFormat, action, condition)
+		resp, err = conn.DescribeSubnets(&condition)
 		if err != nil {
-			return data, err
+			return data, "", err
 		}
-	} else {
-		resp, err = conn.DescribeSubnets(&condition)
+		logger.Debug(logger.ReqFormat, action, *resp)
+		nextToken := (*resp)["NextToken"]
+		results, err = getSdkValue("SubnetSet", *resp)
 		if err != nil {
-			return data, err
+			return data, "", err
 		}
-	}
-	logger.Debug(logger.ReqFormat, action, *resp)
-	results, err = getSdkValue("SubnetSet", *resp)
-	if err != nil {
-		return data, err
-	}
-	data = results.([]interface{})
-	return data, err
+		data, _ = results.([]interface{})
+		return data, indirectString(nextToken), err
+	})
 }
 
 func (s *VpcService) ReadSubnet(d *schema.ResourceData, subnetId string) (data map[string]interface{}, err error) {
@@ -547,6 +593,10 @@ func (s *VpcService) ReadSubnet(d *schema.ResourceData, subnetId string) (data m
 		if val, ok := data["Ipv6CidrBlockAssociationSet"]; !ok {
 			data["Ipv6CidrBlockAssociationSet"] = val
 		}
+		data["Ipv6CidrBlock"] = firstIpv6CidrBlock(data)
+		if cidr, ok := data["CidrBlock"].(string); ok {
+			data["CidrPrefixLength"] = cidrPrefixLength(cidr)
+		}
 	}
 	if len(data) == 0 {
 		return data, fmt.Errorf("Subnet %s not exist ", subnetId)
@@ -606,6 +656,10 @@ func (s *VpcService) ReadAndSetSubnets(d *schema.ResourceData, r *schema.Resourc
 	if err != nil {
 		return err
 	}
+	for _, item := range data {
+		subnet := item.(map[string]interface{})
+		subnet["Ipv6CidrBlock"] = firstIpv6CidrBlock(subnet)
+	}
 
 	return mergeDataSourcesResp(d, r, ksyunDataSource{
 		collection:  data,
@@ -641,8 +695,143 @@ func (s *VpcService) SubnetAutoMatch(req *map[string]interface{}) {
 	}
 }
 
+// subnetCidrPlan keeps, within one run, the cidr_block planned from cidr_prefix_length per vpc, so the subnets
+// planned together get different blocks, and serializes the checks of the planned blocks on apply
+var subnetCidrPlan = struct {
+	sync.Mutex
+	planned map[string][]string
+}{planned: make(map[string][]string)}
+
+// ReadVpcCidrUsage returns the CIDR blocks of a vpc, the secondary ones included, and the CIDR blocks
+// of its subnets
+func (s *VpcService) ReadVpcCidrUsage(vpcId string) (vpcCidrs []string, subnetCidrs []string, err error) {
+	vpc, err := s.ReadVpc(nil, vpcId)
+	if err != nil {
+		return vpcCidrs, subnetCidrs, err
+	}
+	if cidr, ok := vpc["CidrBlock"].(string); ok && cidr != "" {
+		vpcCidrs = append(vpcCidrs, cidr)
+	}
+	if secondaryCidrs, ok := vpc["SecondaryCidrSet"].([]interface{}); ok {
+		for _, secondaryCidr := range secondaryCidrs {
+			if cidr, ok := secondaryCidr.(map[string]interface{})["Cidr"].(string); ok && cidr != "" {
+				vpcCidrs = append(vpcCidrs, cidr)
+			}
+		}
+	}
+
+	subnets, err := s.ReadSubnets(map[string]interface{}{
+		"Filter.1.Name":    "vpc-id",
+		"Filter.1.Value.1": vpcId,
+	})
+	if err != nil {
+		return vpcCidrs, subnetCidrs, err
+	}
+	for _, subnet := range subnets {
+		if cidr, ok := subnet.(map[string]interface{})["CidrBlock"].(string); ok && cidr != "" {
+			subnetCidrs = append(subnetCidrs, cidr)
+		}
+	}
+	return vpcCidrs, subnetCidrs, err
+}
+
+// planSubnetCidrBlock returns the lowest CIDR block of the prefix length in the vpc which is used neither by
+// the subnets of the vpc nor by the blocks planned so far in this run, and keeps it as planned
+func planSubnetCidrBlock(vpcId string, vpcCidrs []string, subnetCidrs []string, prefixLength int) (cidr string, err error) {
+	subnetCidrPlan.Lock()
+	defer subnetCidrPlan.Unlock()
+	used := append(append([]string{}, subnetCidrs...), subnetCidrPlan.planned[vpcId]...)
+	cidr, err = nextFreeCidrBlock(vpcCidrs, used, prefixLength)
+	if err != nil {
+		return cidr, err
+	}
+	if cidr == "" {
+		return cidr, fmt.Errorf("no free CIDR block of /%d left in vpc %s", prefixLength, vpcId)
+	}
+	subnetCidrPlan.planned[vpcId] = append(subnetCidrPlan.planned[vpcId], cidr)
+	return cidr, err
+}
+
+// PlanSubnetCidrBlock returns the lowest free CIDR block of the prefix length in the vpc for a subnet planned
+// with cidr_prefix_length
+func (s *VpcService) PlanSubnetCidrBlock(vpcId string, prefixLength int) (cidr string, err error) {
+	vpcCidrs, subnetCidrs, err := s.ReadVpcCidrUsage(vpcId)
+	if err != nil {
+		return cidr, err
+	}
+	return planSubnetCidrBlock(vpcId, vpcCidrs, subnetCidrs, prefixLength)
+}
+
+// CheckSubnetCidrBlock fails when the CIDR block overlaps a subnet of the vpc, such as a subnet created out of
+// band after the block was planned
+func (s *VpcService) CheckSubnetCidrBlock(vpcId string, cidr string) (err error) {
+	subnetCidrPlan.Lock()
+	defer subnetCidrPlan.Unlock()
+	_, subnetCidrs, err := s.ReadVpcCidrUsage(vpcId)
+	if err != nil {
+		return err
+	}
+	for _, subnetCidr := range subnetCidrs {
+		if cidrOverlaps(cidr, subnetCidr) {
+			return fmt.Errorf("cidr_block %s overlaps the CIDR block %s of another subnet in vpc %s, run plan again to get a free block for cidr_prefix_length",
+				cidr, subnetCidr, vpcId)
+		}
+	}
+	return err
+}
+
+func (s *VpcService) ReadAndSetVpcFreeCidrs(d *schema.ResourceData, r *schema.Resource) (err error) {
+	vpcId := d.Get("vpc_id").(string)
+	prefixLength := d.Get("cidr_prefix_length").(int)
+	vpcCidrs, subnetCidrs, err := s.ReadVpcCidrUsage(vpcId)
+	if err != nil {
+		return err
+	}
+	free, err := freeCidrBlocks(vpcCidrs, subnetCidrs)
+	if err != nil {
+		return err
+	}
+
+	var data []interface{}
+	for _, cidr := range free {
+		length := cidrPrefixLength(cidr)
+		if prefixLength > 0 && length > prefixLength {
+			continue
+		}
+		data = append(data, map[string]interface{}{
+			"cidr_block":    cidr,
+			"prefix_length": length,
+			"ip_count":      1 << uint(32-length),
+		})
+	}
+
+	nextCidr := ""
+	if prefixLength > 0 {
+		if nextCidr, err = nextFreeCidrBlock(vpcCidrs, subnetCidrs, prefixLength); err != nil {
+			return err
+		}
+	}
+	if err = d.Set("next_cidr_block", nextCidr); err != nil {
+		return err
+	}
+
+	_, _, err = SdkSliceMapping(d, data, SdkSliceData{
+		IdMappingFunc: func(idField string, item map[string]interface{}) string {
+			return item["cidr_block"].(string)
+		},
+		SliceMappingFunc: func(item map[string]interface{}) map[string]interface{} {
+			return item
+		},
+		TargetName: "free_cidrs",
+	})
+	return err
+}
+
 func (s *VpcService) CreateSubnetCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
-	req, err := SdkRequestAutoMapping(d, r, false, nil, nil)
+	transform := map[string]SdkReqTransform{
+		"cidr_prefix_length": {Ignore: true},
+	}
+	req, err := SdkRequestAutoMapping(d, r, false, transform, nil)
 	if err != nil {
 		return callback, err
 	}
@@ -684,6 +873,22 @@ func (s *VpcService) CreateSubnetCall(d *schema.ResourceData, r *schema.Resource
 }
 
 func (s *VpcService) CreateSubnet(d *schema.ResourceData, r *schema.Resource) (err error) {
+	vpcId := d.Get("vpc_id").(string)
+	if cidr, ok := d.GetOk("cidr_block"); ok {
+		// the block planned from cidr_prefix_length may have been taken since the plan
+		if err = s.CheckSubnetCidrBlock(vpcId, cidr.(string)); err != nil {
+			return err
+		}
+	} else {
+		// the vpc was unknown on plan
+		cidr, err := s.PlanSubnetCidrBlock(vpcId, d.Get("cidr_prefix_length").(int))
+		if err != nil {
+			return err
+		}
+		if err = d.Set("cidr_block", cidr); err != nil {
+			return err
+		}
+	}
 	call, err := s.CreateSubnetCall(d, r)
 	if err != nil {
 		return err
@@ -692,7 +897,10 @@ func (s *VpcService) CreateSubnet(d *schema.ResourceData, r *schema.Resource) (e
 }
 
 func (s *VpcService) ModifySubnetCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
-	req, err := SdkRequestAutoMapping(d, r, true, nil, nil)
+	transform := map[string]SdkReqTransform{
+		"provided_ipv6_cidr_block": {Ignore: true},
+	}
+	req, err := SdkRequestAutoMapping(d, r, true, transform, nil)
 	if err != nil {
 		return callback, err
 	}
@@ -721,7 +929,37 @@ func (s *VpcService) ModifySubnet(d *schema.ResourceData, r *schema.Resource) (e
 	if err != nil {
 		return err
 	}
-	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+	ipv6Call, err := s.AllocateSubnetIpv6CidrBlockCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call, ipv6Call}, d, s.client, true)
+}
+
+// AllocateSubnetIpv6CidrBlockCall assigns an IPv6 CIDR block to the subnet when provided_ipv6_cidr_block is turned on,
+// the vpc of the subnet must have one
+func (s *VpcService) AllocateSubnetIpv6CidrBlockCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	if !d.HasChange("provided_ipv6_cidr_block") || !d.Get("provided_ipv6_cidr_block").(bool) {
+		return callback, err
+	}
+	req := map[string]interface{}{
+		"SubnetId": d.Id(),
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "AllocateSubnetIpv6CidrBlock",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.vpcconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.AllocateSubnetIpv6CidrBlock(call.param)
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return err
+		},
+	}
+	return callback, err
 }
 
 func (s *VpcService) RemoveSubnetCall(d *schema.ResourceData) (callback ApiCall, err error) {
@@ -2017,7 +2255,7 @@ func (s *VpcService) CreateNetworkAclEntryCall(d *schema.ResourceData, r *schema
 	req, err := SdkRequestAutoMapping(d, r, false, transform, nil, SdkReqParameter{
 		false,
 	})
-	if req["Protocol"] != "icmp" {
+	if !isIcmpProtocol(req["Protocol"]) {
 		delete(req, "IcmpType")
 		delete(req, "IcmpCode")
 	}
@@ -2053,7 +2291,7 @@ func (s *VpcService) CreateNetworkAclAssociateCall(d *schema.ResourceData, r *sc
 
 func (s *VpcService) CreateNetworkAclEntryCommonCall(req map[string]interface{}, isSetId bool) (callback ApiCall, err error) {
 	// check
-	if req["Protocol"] == "icmp" {
+	if isIcmpProtocol(req["Protocol"]) {
 		if _, ok := req["IcmpType"]; !ok {
 			return callback, fmt.Errorf("NetworkAcl Protocol is icmp,must set IcmpType")
 		}
@@ -2452,116 +2690,600 @@ func (s *VpcService) RemoveNetworkAclAssociate(d *schema.ResourceData) (err erro
 	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
 }
 
-func (s *VpcService) ReadSecurityGroups(condition map[string]interface{}) (data []interface{}, err error) {
-	var (
-		resp    *map[string]interface{}
-		results interface{}
-	)
-	conn := s.client.vpcconn
-	action := "DescribeSecurityGroups"
-	logger.Debug(logger.ReqFormat, action, condition)
-	if condition == nil {
-		resp, err = conn.DescribeSecurityGroups(nil)
-		if err != nil {
-			return data, err
-		}
-	} else {
-		resp, err = conn.DescribeSecurityGroups(&condition)
-		if err != nil {
-			return data, err
-		}
-	}
+// isIcmpProtocol reports whether the protocol of a rule uses the icmp type and code, icmpv6 is the icmp of the
+// ipv6 rules
+func isIcmpProtocol(protocol interface{}) bool {
+	return protocol == "icmp" || protocol == "icmpv6"
+}
 
-	results, err = getSdkValue("SecurityGroupSet", *resp)
-	if err != nil {
-		return data, err
-	}
-	data = results.([]interface{})
-	return data, err
+// networkAclRule is an entry of ksyun_network_acl_rules with its effective rule number
+type networkAclRule struct {
+	direction     string
+	ruleNumber    int
+	ruleAction    string
+	protocol      string
+	cidrBlock     string
+	portRangeFrom int
+	portRangeTo   int
+	icmpType      int
+	icmpCode      int
+	description   string
+	entryId       string
 }
 
-func (s *VpcService) ReadSecurityGroup(d *schema.ResourceData, securityGroupId string) (data map[string]interface{}, err error) {
-	var results []interface{}
-	if securityGroupId == "" {
-		securityGroupId = d.Id()
-	}
-	req := map[string]interface{}{
-		"SecurityGroupId.1": securityGroupId,
-	}
-	results, err = s.ReadSecurityGroups(req)
-	if err != nil {
-		return data, err
+// normalize clears the fields the protocol does not use and canonicalizes the cidr block
+func (r *networkAclRule) normalize() {
+	if r.protocol != "tcp" && r.protocol != "udp" {
+		r.portRangeFrom, r.portRangeTo = 0, 0
 	}
-	for _, v := range results {
-		data = v.(map[string]interface{})
+	if !isIcmpProtocol(r.protocol) {
+		r.icmpType, r.icmpCode = 0, 0
 	}
-	if len(data) == 0 {
-		return data, fmt.Errorf("security group %s not exist ", securityGroupId)
+	if _, ipNet, err := net.ParseCIDR(r.cidrBlock); err == nil {
+		r.cidrBlock = ipNet.String()
 	}
-	return data, err
 }
 
-func (s *VpcService) ReadSecurityGroupEntry(d *schema.ResourceData, securityGroupId string) (data map[string]interface{}, err error) {
-	sg, err := s.ReadSecurityGroup(d, securityGroupId)
-	if err != nil {
-		return data, err
+// key identifies the entry regardless of its description, the only field ModifyNetworkAclEntry can change
+func (r networkAclRule) key() string {
+	return fmt.Sprintf("%d-%s", r.ruleNumber, r.contentKey())
+}
+
+// contentKey identifies the packets the entry matches and its action, regardless of its rule number
+func (r networkAclRule) contentKey() string {
+	return fmt.Sprintf("%s-%s-%s-%s-%d-%d-%d-%d", r.direction, r.ruleAction, r.protocol,
+		r.cidrBlock, r.portRangeFrom, r.portRangeTo, r.icmpType, r.icmpCode)
+}
+
+// covers reports whether every packet matched by other is matched by r
+func (r networkAclRule) covers(other networkAclRule) bool {
+	if !cidrContains(r.cidrBlock, other.cidrBlock) {
+		return false
 	}
-	found := false
-	for _, entry := range sg["SecurityGroupEntrySet"].([]interface{}) {
-		h1 := securityGroupEntrySimpleHashWithHump(entry)
-		h2 := securityGroupEntrySimpleHash(d)
-		if h1 == h2 {
-			found = true
-			data = entry.(map[string]interface{})
-			break
+	switch {
+	case r.protocol == "ip":
+		return true
+	case r.protocol != other.protocol:
+		return false
+	case isIcmpProtocol(r.protocol):
+		return r.icmpType == other.icmpType && r.icmpCode == other.icmpCode
+	default:
+		return r.portRangeFrom <= other.portRangeFrom && other.portRangeTo <= r.portRangeTo
+	}
+}
+
+// networkAclRuleFromElement converts an element of ingress or egress into an entry, rule_number as it is set
+func networkAclRuleFromElement(direction string, m map[string]interface{}) networkAclRule {
+	rule := networkAclRule{direction: direction}
+	rule.ruleNumber, _ = m["rule_number"].(int)
+	rule.ruleAction, _ = m["rule_action"].(string)
+	rule.protocol, _ = m["protocol"].(string)
+	rule.cidrBlock, _ = m["cidr_block"].(string)
+	rule.portRangeFrom, _ = m["port_range_from"].(int)
+	rule.portRangeTo, _ = m["port_range_to"].(int)
+	rule.icmpType, _ = m["icmp_type"].(int)
+	rule.icmpCode, _ = m["icmp_code"].(int)
+	rule.description, _ = m["description"].(string)
+	rule.normalize()
+	return rule
+}
+
+// numberNetworkAclRules returns the rule numbers of the entries in list order, explicit holds the rule_number set
+// for each entry or 0, and kept the number of the current entry with the same content or 0. An entry without
+// rule_number keeps its current number when it still fits between the entries around it, so inserting an entry
+// does not renumber the entries after it. The other entries take the number of the entry before it plus step, or
+// are numbered evenly below the next kept number when the gap is narrower, and a kept number is given up only
+// when the entries before it do not fit below it.
+func numberNetworkAclRules(explicit []int, kept []int, step int) []int {
+	n := len(explicit)
+	// the highest number an entry may keep, leaving room for the entries numbered by step up to the next
+	// explicit number
+	limits := make([]int, n)
+	limit := 32766 + step
+	for i := n - 1; i >= 0; i-- {
+		if explicit[i] != 0 {
+			limit = explicit[i]
+		} else {
+			limit -= step
 		}
+		limits[i] = limit
 	}
-	if !found {
-		return data, fmt.Errorf("security group entry not exist")
+
+	numbers := make([]int, n)
+	prev := 0
+	for i := 0; i < n; i++ {
+		if explicit[i] != 0 {
+			numbers[i] = explicit[i]
+			prev = numbers[i]
+			continue
+		}
+		// the next entry which keeps its number, the entries from i up to it are numbered below it
+		next, bound := n, 0
+		for k := i; k < n && explicit[k] == 0; k++ {
+			if kept[k] > prev+k-i && kept[k] <= limits[k] {
+				next, bound = k, kept[k]
+				break
+			}
+		}
+		if next == i {
+			numbers[i] = kept[i]
+			prev = numbers[i]
+			continue
+		}
+		gap := step
+		if next < n && (bound-prev)/(next-i+1) < step {
+			gap = (bound - prev) / (next - i + 1)
+		}
+		numbers[i] = prev + gap
+		prev = numbers[i]
 	}
-	return data, err
+	return numbers
 }
 
-func (s *VpcService) ReadAndSetSecurityGroupEntryLite(d *schema.ResourceData, r *schema.Resource) (err error) {
-	securityGroupId := d.Get("security_group_id").(string)
-	sg, err := s.ReadSecurityGroup(d, securityGroupId)
-	if err != nil {
-		return err
+// numberNetworkAclRuleElements converts the elements of ingress or egress into entries with their effective rule
+// numbers, the entries without rule_number keep the numbers of the current entries with the same content
+func numberNetworkAclRuleElements(direction string, entries []interface{}, step int, current []networkAclRule) []networkAclRule {
+	if step == 0 {
+		step = 10
 	}
-	sgEntryIdMap := make(map[string]map[string]interface{}, 0)
-	securityGroupEntryIdList := make([]string, 0)
-	cidrBlockSet := make([]string, 0)
-	var sgEntry map[string]interface{}
-
-	sgEntrySet := sg["SecurityGroupEntrySet"].([]interface{})
-	for _, entryIf := range sgEntrySet {
-		if entry, ok := entryIf.(map[string]interface{}); !ok {
-			continue
-		} else {
-
-			entryId := entry["SecurityGroupEntryId"].(string)
-			sgEntryIdMap[entryId] = entry
+	numbers := make(map[string][]int)
+	for _, rule := range current {
+		if rule.direction == direction {
+			numbers[rule.contentKey()] = append(numbers[rule.contentKey()], rule.ruleNumber)
 		}
 	}
-	entryIdSetStr, _ := d.Get("security_group_entry_id_list").(string)
-	entryIdList := strings.Split(entryIdSetStr, ",")
-	for _, entryId := range entryIdList {
-		if rule, ok := sgEntryIdMap[entryId]; ok {
-			if sgEntry == nil {
-				sgEntry = rule
-			}
-			securityGroupEntryIdList = append(securityGroupEntryIdList, entryId)
-			cidrBlock := rule["CidrBlock"].(string)
-			cidrBlockSet = append(cidrBlockSet, cidrBlock)
+	rules := make([]networkAclRule, 0, len(entries))
+	explicit := make([]int, 0, len(entries))
+	kept := make([]int, 0, len(entries))
+	for _, item := range entries {
+		m, _ := item.(map[string]interface{})
+		rule := networkAclRuleFromElement(direction, m)
+		number := 0
+		if key := rule.contentKey(); rule.ruleNumber == 0 && len(numbers[key]) > 0 {
+			number, numbers[key] = numbers[key][0], numbers[key][1:]
 		}
+		rules = append(rules, rule)
+		explicit = append(explicit, rule.ruleNumber)
+		kept = append(kept, number)
 	}
+	for i, number := range numberNetworkAclRules(explicit, kept, step) {
+		rules[i].ruleNumber = number
+	}
+	return rules
+}
 
-	delete(sgEntry, "CidrBlock")
-	SdkResponseAutoResourceData(d, r, sgEntry, nil)
-
-	_ = d.Set("cidr_block", cidrBlockSet)
-	_ = d.Set("security_group_entry_id_list", securityGroupEntryIdList)
-	return err
+// expandNetworkAclRules numbers the entries of ingress or egress in the order of the list as
+// numberNetworkAclRules does, the entries with the content of the current entries keep their numbers
+func expandNetworkAclRules(field string, direction string, entries []interface{}, step int, current []networkAclRule) (rules []networkAclRule, err error) {
+	rules = numberNetworkAclRuleElements(direction, entries, step, current)
+	prev := 0
+	for i, rule := range rules {
+		if rule.ruleNumber <= prev {
+			return rules, fmt.Errorf("%s.%d: rule_number %d must be greater than %d of the entry before it, the entries are listed in priority order",
+				field, i, rule.ruleNumber, prev)
+		}
+		if rule.ruleNumber > 32766 {
+			return rules, fmt.Errorf("%s.%d: rule number %d is out of range [1,32766], decrease rule_number_step or set rule_number explicitly",
+				field, i, rule.ruleNumber)
+		}
+		if (rule.protocol == "tcp" || rule.protocol == "udp") && rule.portRangeFrom > rule.portRangeTo {
+			return rules, fmt.Errorf("%s.%d: port_range_from %d is greater than port_range_to %d",
+				field, i, rule.portRangeFrom, rule.portRangeTo)
+		}
+		prev = rule.ruleNumber
+	}
+	return rules, err
+}
+
+// networkAclRuleNumbers returns the effective rule numbers of the elements of ingress or egress, numbered as
+// expandNetworkAclRules does
+func networkAclRuleNumbers(direction string, entries []interface{}, step int, current []networkAclRule) []int {
+	rules := numberNetworkAclRuleElements(direction, entries, step, current)
+	numbers := make([]int, 0, len(rules))
+	for _, rule := range rules {
+		numbers = append(numbers, rule.ruleNumber)
+	}
+	return numbers
+}
+
+// networkAclRulesFromState returns the entries of ingress and egress in the previous state, which are the entries
+// of the network acl as read last
+func networkAclRulesFromState(getChange func(string) (interface{}, interface{})) (rules []networkAclRule) {
+	step, _ := getChange("rule_number_step")
+	for _, field := range []string{"ingress", "egress"} {
+		old, _ := getChange(field)
+		entries, _ := old.([]interface{})
+		stepValue, _ := step.(int)
+		rules = append(rules, numberNetworkAclRuleElements(networkAclRuleDirection(field), entries, stepValue, nil)...)
+	}
+	return rules
+}
+
+// networkAclRuleDirection returns the direction of the entries of ingress or egress
+func networkAclRuleDirection(field string) string {
+	if field == "egress" {
+		return "out"
+	}
+	return "in"
+}
+
+// validateNetworkAclRules rejects the entries that never match because an entry with a higher priority in the
+// same direction matches all of their packets, duplicated entries included
+func validateNetworkAclRules(field string, rules []networkAclRule) error {
+	for j := range rules {
+		if rules[j].cidrBlock == "" {
+			continue
+		}
+		for i := 0; i < j; i++ {
+			if rules[i].covers(rules[j]) {
+				return fmt.Errorf("%s.%d (rule number %d) is shadowed by %s.%d (rule number %d) and never matches",
+					field, j, rules[j].ruleNumber, field, i, rules[i].ruleNumber)
+			}
+		}
+	}
+	return nil
+}
+
+// networkAclRulesFromEntrySet converts the NetworkAclEntrySet of DescribeNetworkAcls into the ingress and egress
+// entries ordered by rule number, the default entries out of the range of rule_number are skipped
+func networkAclRulesFromEntrySet(entries []interface{}) (ingress []networkAclRule, egress []networkAclRule) {
+	for _, item := range entries {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rule := networkAclRule{}
+		rule.direction, _ = entry["Direction"].(string)
+		rule.ruleAction, _ = entry["RuleAction"].(string)
+		rule.protocol, _ = entry["Protocol"].(string)
+		rule.cidrBlock, _ = entry["CidrBlock"].(string)
+		rule.description, _ = entry["Description"].(string)
+		rule.entryId, _ = entry["NetworkAclEntryId"].(string)
+		for k, v := range map[string]*int{
+			"RuleNumber":    &rule.ruleNumber,
+			"PortRangeFrom": &rule.portRangeFrom,
+			"PortRangeTo":   &rule.portRangeTo,
+			"IcmpType":      &rule.icmpType,
+			"IcmpCode":      &rule.icmpCode,
+		} {
+			if f, ok := entry[k].(float64); ok {
+				*v = int(f)
+			}
+		}
+		if rule.ruleNumber < 1 || rule.ruleNumber > 32766 {
+			continue
+		}
+		rule.normalize()
+		if rule.direction == "in" {
+			ingress = append(ingress, rule)
+		} else {
+			egress = append(egress, rule)
+		}
+	}
+	for _, rules := range [][]networkAclRule{ingress, egress} {
+		sort.Slice(rules, func(i, j int) bool {
+			return rules[i].ruleNumber < rules[j].ruleNumber
+		})
+	}
+	return ingress, egress
+}
+
+// flattenNetworkAclRules converts the entries ordered by rule number into the elements of ingress or egress,
+// rule_number is left empty when it is the one numbered automatically with step
+func flattenNetworkAclRules(rules []networkAclRule, step int) []interface{} {
+	result := make([]interface{}, 0, len(rules))
+	prev := 0
+	for _, rule := range rules {
+		number := rule.ruleNumber
+		if number == prev+step {
+			number = 0
+		}
+		prev = rule.ruleNumber
+		result = append(result, map[string]interface{}{
+			"rule_number":     number,
+			"rule_action":     rule.ruleAction,
+			"protocol":        rule.protocol,
+			"cidr_block":      rule.cidrBlock,
+			"port_range_from": rule.portRangeFrom,
+			"port_range_to":   rule.portRangeTo,
+			"icmp_type":       rule.icmpType,
+			"icmp_code":       rule.icmpCode,
+			"description":     rule.description,
+		})
+	}
+	return result
+}
+
+// expandNetworkAclRulesFromConfig returns the numbered and validated entries of ingress and egress, the entries
+// with the content of the current entries keep their numbers
+func expandNetworkAclRulesFromConfig(get func(string) interface{}, current []networkAclRule) (rules []networkAclRule, err error) {
+	step := get("rule_number_step").(int)
+	for _, field := range []string{"ingress", "egress"} {
+		var expanded []networkAclRule
+		expanded, err = expandNetworkAclRules(field, networkAclRuleDirection(field), get(field).([]interface{}), step, current)
+		if err != nil {
+			return rules, err
+		}
+		if err = validateNetworkAclRules(field, expanded); err != nil {
+			return rules, err
+		}
+		rules = append(rules, expanded...)
+	}
+	return rules, err
+}
+
+// networkAclRulesDeltaCalls turns the current entries of the network acl into the desired ones. The entries are
+// created before the stale entries are deleted, so the network acl is never left without them, except an entry
+// reusing the rule number of a stale entry, which is created right after the stale entry is deleted.
+func (s *VpcService) networkAclRulesDeltaCalls(aclId string, current []networkAclRule, desired []networkAclRule) (callbacks []ApiCall, err error) {
+	existing := make(map[string]networkAclRule)
+	for _, rule := range current {
+		existing[rule.key()] = rule
+	}
+	wanted := make(map[string]bool)
+	for _, rule := range desired {
+		wanted[rule.key()] = true
+	}
+	// the stale entries by direction and rule number
+	stale := make(map[string]networkAclRule)
+	for _, rule := range current {
+		if !wanted[rule.key()] {
+			stale[fmt.Sprintf("%s-%d", rule.direction, rule.ruleNumber)] = rule
+		}
+	}
+
+	var modifies, creates, replaces, deletes []ApiCall
+	replaced := make(map[string]bool)
+	for _, rule := range desired {
+		var callback ApiCall
+		if old, ok := existing[rule.key()]; ok {
+			if old.description != rule.description {
+				callback, err = s.ModifyNetworkAclEntryCommonCall(map[string]interface{}{
+					"NetworkAclEntryId": old.entryId,
+					"Description":       rule.description,
+				})
+				if err != nil {
+					return callbacks, err
+				}
+				modifies = append(modifies, callback)
+			}
+			continue
+		}
+		req := map[string]interface{}{
+			"CidrBlock":  rule.cidrBlock,
+			"RuleNumber": rule.ruleNumber,
+			"Direction":  rule.direction,
+			"RuleAction": rule.ruleAction,
+			"Protocol":   rule.protocol,
+		}
+		if rule.description != "" {
+			req["Description"] = rule.description
+		}
+		switch rule.protocol {
+		case "tcp", "udp":
+			req["PortRangeFrom"] = rule.portRangeFrom
+			req["PortRangeTo"] = rule.portRangeTo
+		case "icmp", "icmpv6":
+			req["IcmpType"] = rule.icmpType
+			req["IcmpCode"] = rule.icmpCode
+		}
+		callback, err = s.CreateNetworkAclEntryCommonCall(req, false)
+		if err != nil {
+			return callbacks, err
+		}
+		number := fmt.Sprintf("%s-%d", rule.direction, rule.ruleNumber)
+		old, ok := stale[number]
+		if !ok {
+			creates = append(creates, callback)
+			continue
+		}
+		var remove ApiCall
+		remove, err = s.RemoveNetworkAclEntryCommonCall(aclId, old.entryId)
+		if err != nil {
+			return callbacks, err
+		}
+		replaces = append(replaces, remove, callback)
+		replaced[old.entryId] = true
+	}
+	for _, rule := range current {
+		if wanted[rule.key()] || replaced[rule.entryId] {
+			continue
+		}
+		var callback ApiCall
+		callback, err = s.RemoveNetworkAclEntryCommonCall(aclId, rule.entryId)
+		if err != nil {
+			return callbacks, err
+		}
+		deletes = append(deletes, callback)
+	}
+	callbacks = append(callbacks, modifies...)
+	callbacks = append(callbacks, creates...)
+	callbacks = append(callbacks, replaces...)
+	callbacks = append(callbacks, deletes...)
+	return callbacks, err
+}
+
+// ApplyNetworkAclRules makes the entries of the network acl exactly the ingress and egress entries, it reads the
+// entries of the network acl again so that the entries changed out of band are reconciled as well, and the
+// unchanged entries keep their rule numbers
+func (s *VpcService) ApplyNetworkAclRules(d *schema.ResourceData) (err error) {
+	if d.Id() == "" {
+		d.SetId(d.Get("network_acl_id").(string))
+	}
+	acl, err := s.ReadNetworkAcl(d, "")
+	if err != nil {
+		return err
+	}
+	entries, _ := acl["NetworkAclEntrySet"].([]interface{})
+	ingress, egress := networkAclRulesFromEntrySet(entries)
+	current := append(ingress, egress...)
+	desired, err := expandNetworkAclRulesFromConfig(d.Get, current)
+	if err != nil {
+		return err
+	}
+	calls, err := s.networkAclRulesDeltaCalls(d.Id(), current, desired)
+	if err != nil {
+		return err
+	}
+	apiProcess := NewApiProcess(context.Background(), d, s.client, true)
+	apiProcess.PutCalls(calls...)
+	return apiProcess.Run()
+}
+
+func (s *VpcService) ReadAndSetNetworkAclRules(d *schema.ResourceData) (err error) {
+	acl, err := s.ReadNetworkAcl(d, "")
+	if err != nil {
+		if notFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	step := d.Get("rule_number_step").(int)
+	if step == 0 {
+		step = 10
+		_ = d.Set("rule_number_step", step)
+	}
+	entries, _ := acl["NetworkAclEntrySet"].([]interface{})
+	ingress, egress := networkAclRulesFromEntrySet(entries)
+	if err = d.Set("network_acl_id", d.Id()); err != nil {
+		return err
+	}
+	if err = d.Set("ingress", flattenNetworkAclRules(ingress, step)); err != nil {
+		return err
+	}
+	return d.Set("egress", flattenNetworkAclRules(egress, step))
+}
+
+func (s *VpcService) RemoveNetworkAclRules(d *schema.ResourceData) (err error) {
+	acl, err := s.ReadNetworkAcl(d, "")
+	if err != nil {
+		if notFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	entries, _ := acl["NetworkAclEntrySet"].([]interface{})
+	ingress, egress := networkAclRulesFromEntrySet(entries)
+	calls, err := s.networkAclRulesDeltaCalls(d.Id(), append(ingress, egress...), nil)
+	if err != nil {
+		return err
+	}
+	apiProcess := NewApiProcess(context.Background(), d, s.client, true)
+	apiProcess.PutCalls(calls...)
+	return apiProcess.Run()
+}
+
+func (s *VpcService) ReadSecurityGroups(condition map[string]interface{}) (data []interface{}, err error) {
+	var (
+		resp    *map[string]interface{}
+		results interface{}
+	)
+	conn := s.client.vpcconn
+	action := "DescribeSecurityGroups"
+	logger.Debug(logger.ReqFormat, action, condition)
+	if condition == nil {
+		resp, err = conn.DescribeSecurityGroups(nil)
+		if err != nil {
+			return data, err
+		}
+	} else {
+		resp, err = conn.DescribeSecurityGroups(&condition)
+		if err != nil {
+			return data, err
+		}
+	}
+
+	results, err = getSdkValue("SecurityGroupSet", *resp)
+	if err != nil {
+		return data, err
+	}
+	data = results.([]interface{})
+	return data, err
+}
+
+func (s *VpcService) ReadSecurityGroup(d *schema.ResourceData, securityGroupId string) (data map[string]interface{}, err error) {
+	var results []interface{}
+	if securityGroupId == "" {
+		securityGroupId = d.Id()
+	}
+	req := map[string]interface{}{
+		"SecurityGroupId.1": securityGroupId,
+	}
+	results, err = s.ReadSecurityGroups(req)
+	if err != nil {
+		return data, err
+	}
+	for _, v := range results {
+		data = v.(map[string]interface{})
+	}
+	if len(data) == 0 {
+		return data, fmt.Errorf("security group %s not exist ", securityGroupId)
+	}
+	return data, err
+}
+
+func (s *VpcService) ReadSecurityGroupEntry(d *schema.ResourceData, securityGroupId string) (data map[string]interface{}, err error) {
+	sg, err := s.ReadSecurityGroup(d, securityGroupId)
+	if err != nil {
+		return data, err
+	}
+	found := false
+	for _, entry := range sg["SecurityGroupEntrySet"].([]interface{}) {
+		h1 := securityGroupEntrySimpleHashWithHump(entry)
+		h2 := securityGroupEntrySimpleHash(d)
+		if h1 == h2 {
+			found = true
+			data = entry.(map[string]interface{})
+			break
+		}
+	}
+	if !found {
+		return data, fmt.Errorf("security group entry not exist")
+	}
+	return data, err
+}
+
+func (s *VpcService) ReadAndSetSecurityGroupEntryLite(d *schema.ResourceData, r *schema.Resource) (err error) {
+	securityGroupId := d.Get("security_group_id").(string)
+	sg, err := s.ReadSecurityGroup(d, securityGroupId)
+	if err != nil {
+		return err
+	}
+	sgEntryIdMap := make(map[string]map[string]interface{}, 0)
+	securityGroupEntryIdList := make([]string, 0)
+	cidrBlockSet := make([]string, 0)
+	var sgEntry map[string]interface{}
+
+	sgEntrySet := sg["SecurityGroupEntrySet"].([]interface{})
+	for _, entryIf := range sgEntrySet {
+		if entry, ok := entryIf.(map[string]interface{}); !ok {
+			continue
+		} else {
+
+			entryId := entry["SecurityGroupEntryId"].(string)
+			sgEntryIdMap[entryId] = entry
+		}
+	}
+	entryIdSetStr, _ := d.Get("security_group_entry_id_list").(string)
+	entryIdList := strings.Split(entryIdSetStr, ",")
+	for _, entryId := range entryIdList {
+		if rule, ok := sgEntryIdMap[entryId]; ok {
+			if sgEntry == nil {
+				sgEntry = rule
+			}
+			securityGroupEntryIdList = append(securityGroupEntryIdList, entryId)
+			cidrBlock := rule["CidrBlock"].(string)
+			cidrBlockSet = append(cidrBlockSet, cidrBlock)
+		}
+	}
+
+	delete(sgEntry, "CidrBlock")
+	SdkResponseAutoResourceData(d, r, sgEntry, nil)
+
+	_ = d.Set("cidr_block", cidrBlockSet)
+	_ = d.Set("security_group_entry_id_list", securityGroupEntryIdList)
+	return err
 }
 
 func (s *VpcService) ReadAndSetSecurityGroups(d *schema.ResourceData, r *schema.Resource) (err error) {
@@ -2663,31 +3385,8 @@ func (s *VpcService) CreateSecurityGroupCall(d *schema.ResourceData, r *schema.R
 func (s *VpcService) CreateSecurityGroupEntryWithSgCall(d *schema.ResourceData, r *schema.Resource) (callbacks []ApiCall, err error) {
 	if entries, ok := d.GetOk("security_group_entries"); ok {
 		for _, entry := range entries.(*schema.Set).List() {
-			var (
-				req      map[string]interface{}
-				callback ApiCall
-			)
-			index := securityGroupEntryHash(entry)
-			transform := make(map[string]SdkReqTransform)
-			for k := range entry.(map[string]interface{}) {
-				key := "security_group_entries." + strconv.Itoa(index) + "." + k
-				if k == "icmp_type" || k == "icmp_code" {
-					transform[key] = SdkReqTransform{
-						mapping: Downline2Hump(k),
-						ValueFunc: func(data *schema.ResourceData) (interface{}, bool) {
-							return d.Get(key), true
-						},
-					}
-				} else {
-					transform[key] = SdkReqTransform{mapping: Downline2Hump(k)}
-				}
-
-			}
-			req, err = SdkRequestAutoMapping(d, r, false, transform, nil)
-			if err != nil {
-				return callbacks, err
-			}
-			callback, err = s.CreateSecurityGroupEntryCommonCall(req, false)
+			var callback ApiCall
+			callback, err = s.createSecurityGroupEntryInSetCall(d, r, entry)
 			if err != nil {
 				return callbacks, err
 			}
@@ -2697,6 +3396,30 @@ func (s *VpcService) CreateSecurityGroupEntryWithSgCall(d *schema.ResourceData,
 	return callbacks, err
 }
 
+// createSecurityGroupEntryInSetCall authorizes an element of the security_group_entries set
+func (s *VpcService) createSecurityGroupEntryInSetCall(d *schema.ResourceData, r *schema.Resource, entry interface{}) (callback ApiCall, err error) {
+	index := securityGroupEntryHash(entry)
+	transform := make(map[string]SdkReqTransform)
+	for k := range entry.(map[string]interface{}) {
+		key := "security_group_entries." + strconv.Itoa(index) + "." + k
+		if k == "icmp_type" || k == "icmp_code" {
+			transform[key] = SdkReqTransform{
+				mapping: Downline2Hump(k),
+				ValueFunc: func(data *schema.ResourceData) (interface{}, bool) {
+					return d.Get(key), true
+				},
+			}
+		} else {
+			transform[key] = SdkReqTransform{mapping: Downline2Hump(k)}
+		}
+	}
+	req, err := SdkRequestAutoMapping(d, r, false, transform, nil)
+	if err != nil {
+		return callback, err
+	}
+	return s.CreateSecurityGroupEntryCommonCall(req, false)
+}
+
 func (s *VpcService) CreateSecurityGroupEntryCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
 	transform := map[string]SdkReqTransform{
 		"icmp_type": {
@@ -2713,7 +3436,7 @@ func (s *VpcService) CreateSecurityGroupEntryCall(d *schema.ResourceData, r *sch
 	req, err := SdkRequestAutoMapping(d, r, false, transform, nil, SdkReqParameter{
 		false,
 	})
-	if req["Protocol"] != "icmp" {
+	if !isIcmpProtocol(req["Protocol"]) {
 		delete(req, "IcmpType")
 		delete(req, "IcmpCode")
 	}
@@ -2725,7 +3448,7 @@ func (s *VpcService) CreateSecurityGroupEntryCall(d *schema.ResourceData, r *sch
 
 func (s *VpcService) CreateSecurityGroupEntryCommonCall(req map[string]interface{}, isSetId bool) (callback ApiCall, err error) {
 	// check
-	if req["Protocol"] == "icmp" {
+	if isIcmpProtocol(req["Protocol"]) {
 		if _, ok := req["IcmpType"]; !ok {
 			return callback, fmt.Errorf("SecurityGroup entry Protocol is icmp,must set IcmpType")
 		}
@@ -2795,16 +3518,55 @@ func (s *VpcService) CreateSecurityGroup(d *schema.ResourceData, r *schema.Resou
 	return ksyunApiCallNew(callbacks, d, s.client, false)
 }
 
-func (s *VpcService) CreateSecurityGroupEntrySet(d *schema.ResourceData, r *schema.Resource) (err error) {
-	apiProcess := NewApiProcess(context.Background(), d, s.client, true)
-	entries, err := s.CreateSecurityGroupEntryWithSgCall(d, r)
+// CreateSecurityGroupRules makes the entries of the security group exactly the security_group_entries, the
+// entries already in the group, such as the default egress entry, are revoked unless they are declared
+func (s *VpcService) CreateSecurityGroupRules(d *schema.ResourceData, r *schema.Resource) (err error) {
+	d.SetId(d.Get("security_group_id").(string))
+	sg, err := s.ReadSecurityGroup(d, "")
 	if err != nil {
 		return err
 	}
-	apiProcess.PutCalls(entries...)
+	entries, _ := sg["SecurityGroupEntrySet"].([]interface{})
+	current := schema.NewSet(securityGroupEntryHash, flattenSecurityGroupEntries(entries))
+	calls, err := s.securityGroupEntryDeltaCalls(d, r, current, d.Get("security_group_entries").(*schema.Set))
+	if err != nil {
+		return err
+	}
+	apiProcess := NewApiProcess(context.Background(), d, s.client, true)
+	apiProcess.PutCalls(calls...)
 	return apiProcess.Run()
 }
 
+// flattenSecurityGroupEntries converts the SecurityGroupEntrySet of DescribeSecurityGroups into the elements of
+// security_group_entries
+func flattenSecurityGroupEntries(entries []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(entries))
+	for _, item := range entries {
+		entry := item.(map[string]interface{})
+		m := map[string]interface{}{
+			"description":     "",
+			"cidr_block":      "",
+			"direction":       "",
+			"protocol":        "",
+			"icmp_type":       0,
+			"icmp_code":       0,
+			"port_range_from": 0,
+			"port_range_to":   0,
+		}
+		for k := range m {
+			switch v := entry[Downline2Hump(k)].(type) {
+			case string:
+				m[k] = v
+			case float64:
+				m[k] = int(v)
+			}
+		}
+		m["security_group_entry_id"], _ = entry["SecurityGroupEntryId"].(string)
+		result = append(result, m)
+	}
+	return result
+}
+
 func (s *VpcService) CreateSecurityGroupEntry(d *schema.ResourceData, r *schema.Resource) (err error) {
 	call, err := s.CreateSecurityGroupEntryCall(d, r)
 	if err != nil {
@@ -2920,73 +3682,59 @@ func (s *VpcService) ModifySecurityGroupEntryWithSgCall(d *schema.ResourceData,
 		if n == nil {
 			n = new(schema.Set)
 		}
-		os := o.(*schema.Set)
-		ns := n.(*schema.Set)
-		// generate new hashcode without description
-		mayAdd := schema.NewSet(securityGroupEntrySimpleHash, ns.Difference(os).List())
-		mayRemove := schema.NewSet(securityGroupEntrySimpleHash, os.Difference(ns).List())
-		addCache := make(map[int]interface{})
-		for _, entry := range mayAdd.List() {
-			index := securityGroupEntrySimpleHash(entry)
-			addCache[index] = entry
-		}
-		// compare hashcode without description
-		// need add entries
-		add := mayAdd.Difference(mayRemove)
-		// need remove entries
-		remove := mayRemove.Difference(mayAdd)
-		// need modify entries
-		modify := mayRemove.Difference(remove)
-		// process modify
-		if len(modify.List()) > 0 {
-			for _, entry := range modify.List() {
-				var callback ApiCall
-				index := securityGroupEntrySimpleHash(entry)
-				req := make(map[string]interface{})
-				req["Description"] = addCache[index].(map[string]interface{})["description"]
-				req["SecurityGroupEntryId"] = entry.(map[string]interface{})["security_group_entry_id"]
-				callback, err = s.ModifySecurityGroupEntryCommonCall(req)
-				if err != nil {
-					return callbacks, err
-				}
-				callbacks = append(callbacks, callback)
-			}
+		return s.securityGroupEntryDeltaCalls(d, r, o.(*schema.Set), n.(*schema.Set))
+	}
+	return callbacks, err
+}
+
+// securityGroupEntryDeltaCalls returns the calls turning the entries os of the security group into ns, the entries
+// only differing in the description are modified, the others are revoked or authorized
+func (s *VpcService) securityGroupEntryDeltaCalls(d *schema.ResourceData, r *schema.Resource, os *schema.Set, ns *schema.Set) (callbacks []ApiCall, err error) {
+	// generate new hashcode without description
+	mayAdd := schema.NewSet(securityGroupEntrySimpleHash, ns.Difference(os).List())
+	mayRemove := schema.NewSet(securityGroupEntrySimpleHash, os.Difference(ns).List())
+	addCache := make(map[int]interface{})
+	for _, entry := range mayAdd.List() {
+		index := securityGroupEntrySimpleHash(entry)
+		addCache[index] = entry
+	}
+	// compare hashcode without description
+	// need add entries
+	add := mayAdd.Difference(mayRemove)
+	// need remove entries
+	remove := mayRemove.Difference(mayAdd)
+	// need modify entries
+	modify := mayRemove.Difference(remove)
+	// process modify
+	for _, entry := range modify.List() {
+		var callback ApiCall
+		index := securityGroupEntrySimpleHash(entry)
+		req := make(map[string]interface{})
+		req["Description"] = addCache[index].(map[string]interface{})["description"]
+		req["SecurityGroupEntryId"] = entry.(map[string]interface{})["security_group_entry_id"]
+		callback, err = s.ModifySecurityGroupEntryCommonCall(req)
+		if err != nil {
+			return callbacks, err
 		}
-		// process remove
-		if len(remove.List()) > 0 {
-			for _, entry := range remove.List() {
-				var callback ApiCall
-				callback, err = s.RemoveSecurityGroupEntryCommonCall(d.Id(), entry.(map[string]interface{})["security_group_entry_id"].(string))
-				if err != nil {
-					return callbacks, err
-				}
-				callbacks = append(callbacks, callback)
-			}
+		callbacks = append(callbacks, callback)
+	}
+	// process remove
+	for _, entry := range remove.List() {
+		var callback ApiCall
+		callback, err = s.RemoveSecurityGroupEntryCommonCall(d.Id(), entry.(map[string]interface{})["security_group_entry_id"].(string))
+		if err != nil {
+			return callbacks, err
 		}
-		// process add
-		if len(add.List()) > 0 {
-			for _, entry := range add.List() {
-				var (
-					req      map[string]interface{}
-					callback ApiCall
-				)
-				index := securityGroupEntryHash(entry)
-				transform := make(map[string]SdkReqTransform)
-				for k := range entry.(map[string]interface{}) {
-					key := "security_group_entries." + strconv.Itoa(index) + "." + k
-					transform[key] = SdkReqTransform{mapping: Downline2Hump(k)}
-				}
-				req, err = SdkRequestAutoMapping(d, r, false, transform, nil)
-				if err != nil {
-					return callbacks, err
-				}
-				callback, err = s.CreateSecurityGroupEntryCommonCall(req, false)
-				if err != nil {
-					return callbacks, err
-				}
-				callbacks = append(callbacks, callback)
-			}
+		callbacks = append(callbacks, callback)
+	}
+	// process add
+	for _, entry := range add.List() {
+		var callback ApiCall
+		callback, err = s.createSecurityGroupEntryInSetCall(d, r, entry)
+		if err != nil {
+			return callbacks, err
 		}
+		callbacks = append(callbacks, callback)
 	}
 	return callbacks, err
 }
@@ -3051,7 +3799,7 @@ func (s *VpcService) ModifySecurityGroup(d *schema.ResourceData, r *schema.Resou
 	return ksyunApiCallNew(callbacks, d, s.client, true)
 }
 
-func (s *VpcService) ModifySecurityGroupSet(d *schema.ResourceData, r *schema.Resource) (err error) {
+func (s *VpcService) ModifySecurityGroupRules(d *schema.ResourceData, r *schema.Resource) (err error) {
 	apiProcess := NewApiProcess(context.Background(), d, s.client, true)
 	entries, err := s.ModifySecurityGroupEntryWithSgCall(d, r)
 	if err != nil {
@@ -3061,6 +3809,18 @@ func (s *VpcService) ModifySecurityGroupSet(d *schema.ResourceData, r *schema.Re
 	return apiProcess.Run()
 }
 
+func (s *VpcService) RemoveSecurityGroupRules(d *schema.ResourceData) (err error) {
+	apiProcess := NewApiProcess(context.Background(), d, s.client, true)
+	for _, entry := range d.Get("security_group_entries").(*schema.Set).List() {
+		call, err := s.RemoveSecurityGroupEntryCommonCall(d.Id(), entry.(map[string]interface{})["security_group_entry_id"].(string))
+		if err != nil {
+			return err
+		}
+		apiProcess.PutCalls(call)
+	}
+	return apiProcess.Run()
+}
+
 func (s *VpcService) ModifySecurityGroupEntry(d *schema.ResourceData, r *schema.Resource) (err error) {
 	var callbacks []ApiCall
 	call, err := s.ModifySecurityGroupEntryCall(d, r)
@@ -3365,8 +4125,33 @@ func (s *VpcService) CreateVpnGateway(d *schema.ResourceData, r *schema.Resource
 	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
 }
 
+func (s *VpcService) modifyVpnGatewayProjectCall(d *schema.ResourceData, resource *schema.Resource) (callback ApiCall, err error) {
+	transform := map[string]SdkReqTransform{
+		"project_id": {},
+	}
+	updateReq, err := SdkRequestAutoMapping(d, resource, true, transform, nil)
+	if err != nil {
+		return callback, err
+	}
+	if len(updateReq) > 0 {
+		callback = ApiCall{
+			param: &updateReq,
+			executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+				return resp, ModifyProjectInstanceNew(d.Id(), call.param, client)
+			},
+			afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+				return err
+			},
+		}
+	}
+	return callback, err
+}
+
 func (s *VpcService) ModifyVpnGatewayCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
-	req, err := SdkRequestAutoMapping(d, r, true, nil, nil)
+	transform := map[string]SdkReqTransform{
+		"project_id": {Ignore: true},
+	}
+	req, err := SdkRequestAutoMapping(d, r, true, transform, nil)
 	if err != nil {
 		return callback, err
 	}
@@ -3391,11 +4176,15 @@ func (s *VpcService) ModifyVpnGatewayCall(d *schema.ResourceData, r *schema.Reso
 }
 
 func (s *VpcService) ModifyVpnGateway(d *schema.ResourceData, r *schema.Resource) (err error) {
+	projectCall, err := s.modifyVpnGatewayProjectCall(d, r)
+	if err != nil {
+		return err
+	}
 	call, err := s.ModifyVpnGatewayCall(d, r)
 	if err != nil {
 		return err
 	}
-	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+	return ksyunApiCallNew([]ApiCall{projectCall, call}, d, s.client, true)
 }
 
 func (s *VpcService) RemoveVpnGatewayCall(d *schema.ResourceData) (callback ApiCall, err error) {
@@ -3726,9 +4515,109 @@ func (s *VpcService) ReadAndSetVpnTunnel(d *schema.ResourceData, r *schema.Resou
 				return Downline2Hump(i.(string))
 			},
 		},
+		"BgpAdvertisedCidrBlockSet": {
+			Field: "bgp_advertised_cidr_blocks",
+		},
 	}
 	SdkResponseAutoResourceData(d, r, data, extra)
-	return err
+
+	return d.Set("tunnel_health", s.readVpnTunnelHealth(data, d.Get("vpn_gateway_version")))
+}
+
+// readVpnTunnelHealth returns the tunnel_health of the tunnel, the IKE and IPsec SA status is only queried for the
+// tunnels of vpn 2.0 and is left unknown if the query fails, so a tunnel is read without it.
+func (s *VpcService) readVpnTunnelHealth(tunnel map[string]interface{}, version interface{}) []interface{} {
+	var status []interface{}
+	if id, ok := tunnel["VpnTunnelId"].(string); ok && version == "2.0" {
+		var err error
+		status, err = s.readVpnTunnelIpsecStatus(id)
+		if err != nil {
+			log.Printf("[WARN] Unable to get the IPsec status of the vpn tunnel %s: %v", id, err)
+			status = nil
+		}
+	}
+	return vpnTunnelHealth(tunnel, status)
+}
+
+func (s *VpcService) readVpnTunnelIpsecStatus(vpnTunnelId string) (data []interface{}, err error) {
+	req := map[string]interface{}{
+		"VpnTunnelId.1": vpnTunnelId,
+	}
+	action := "DescribeVpnTunnelIpsecStatus"
+	logger.Debug(logger.ReqFormat, action, req)
+	resp, err := s.doVpcCustomAction(action, &req)
+	if err != nil {
+		return data, err
+	}
+	results, err := getSdkValue("VpnTunnelIpsecStatusList", *resp)
+	if err != nil {
+		return data, err
+	}
+	data, _ = results.([]interface{})
+	return data, err
+}
+
+// vpnTunnelState returns the state of a tunnel reported by DescribeVpnTunnels, which is `up` or `down` if it is
+// reported as a bool.
+func vpnTunnelState(state interface{}) string {
+	switch v := state.(type) {
+	case bool:
+		if v {
+			return "up"
+		}
+		return "down"
+	case string:
+		return v
+	}
+	return ""
+}
+
+// vpnTunnelHealth converts the VpnMTunnelState and VpnSTunnelState of the tunnel and the IKE and IPsec SA status of
+// the master and slave tunnels to the tunnel_health, the SA status is false if it is not reported.
+func vpnTunnelHealth(tunnel map[string]interface{}, statusList []interface{}) []interface{} {
+	health := make(map[string]map[string]interface{})
+	for role, field := range map[string]string{"master": "VpnMTunnelState", "slave": "VpnSTunnelState"} {
+		if state := vpnTunnelState(tunnel[field]); state != "" {
+			health[role] = map[string]interface{}{
+				"role":         role,
+				"state":        state,
+				"ike_status":   false,
+				"ipsec_status": false,
+			}
+		}
+	}
+	for _, v := range statusList {
+		status, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		role := "slave"
+		if isMaster, _ := status["IsMaster"].(float64); isMaster == 1 {
+			role = "master"
+		}
+		ikeStatus, _ := status["IkeStatus"].(bool)
+		ipsecStatus, _ := status["IpsecStatus"].(bool)
+		if _, ok := health[role]; !ok {
+			state := "down"
+			if ikeStatus && ipsecStatus {
+				state = "up"
+			}
+			health[role] = map[string]interface{}{
+				"role":  role,
+				"state": state,
+			}
+		}
+		health[role]["ike_status"] = ikeStatus
+		health[role]["ipsec_status"] = ipsecStatus
+	}
+
+	var result []interface{}
+	for _, role := range []string{"master", "slave"} {
+		if v, ok := health[role]; ok {
+			result = append(result, v)
+		}
+	}
+	return result
 }
 
 func (s *VpcService) ReadAndSetVpnTunnels(d *schema.ResourceData, r *schema.Resource) (err error) {
@@ -3750,6 +4639,10 @@ func (s *VpcService) ReadAndSetVpnTunnels(d *schema.ResourceData, r *schema.Reso
 	if err != nil {
 		return err
 	}
+	for _, v := range data {
+		item := v.(map[string]interface{})
+		item["TunnelHealth"] = s.readVpnTunnelHealth(item, item["VpnGatewayVersion"])
+	}
 
 	return mergeDataSourcesResp(d, r, ksyunDataSource{
 		collection:  data,
@@ -3772,6 +4665,12 @@ func (s *VpcService) ReadAndSetVpnTunnels(d *schema.ResourceData, r *schema.Reso
 					return result
 				},
 			},
+			"BgpAdvertisedCidrBlockSet": {
+				Field: "bgp_advertised_cidr_blocks",
+			},
+			"TunnelHealth": {
+				Field: "tunnel_health",
+			},
 		},
 	})
 }
@@ -3781,6 +4680,10 @@ func (s *VpcService) CreateVpnTunnelCall(d *schema.ResourceData, r *schema.Resou
 		"ike_dh_group": {
 			mapping: "IkeDHGroup",
 		},
+		"bgp_advertised_cidr_blocks": {
+			mapping: "BgpAdvertisedCidrBlock",
+			Type:    TransformWithN,
+		},
 	}
 
 	if d.Get("vpn_gateway_version") == "2.0" {
@@ -4120,3 +5023,1069 @@ var logCall = func(d *schema.ResourceData, client *KsyunClient, resp *map[string
 	logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
 	return err
 }
+
+func (s *VpcService) ReadIpv6PublicIps(condition map[string]interface{}) (data []interface{}, err error) {
+	var (
+		resp    *map[string]interface{}
+		results interface{}
+	)
+	return pageQueryWithNextToken(condition, "MaxResults", "NextToken", 100, func(condition map[string]interface{}) ([]interface{}, string, error) {
+		conn := s.client.vpcconn
+		action := "DescribeIpv6PublicIpAddresses"
+		logger.Debug(logger.ReqFormat, action, condition)
+		if condition == nil {
+			resp, err = conn.DescribeIpv6PublicIpAddresses(nil)
+		} else {
+			resp, err = conn.DescribeIpv6PublicIpAddresses(&condition)
+		}
+		if err != nil {
+			return data, "", err
+		}
+		nextToken := (*resp)["NextToken"]
+		results, err = getSdkValue("Ipv6PublicIpAddressSet", *resp)
+		if err != nil {
+			return data, "", err
+		}
+		data, _ = results.([]interface{})
+		return data, indirectString(nextToken), err
+	})
+}
+
+func (s *VpcService) ReadIpv6PublicIp(d *schema.ResourceData, ipv6PublicIpId string) (data map[string]interface{}, err error) {
+	var (
+		results []interface{}
+	)
+	if ipv6PublicIpId == "" {
+		ipv6PublicIpId = d.Id()
+	}
+	req := map[string]interface{}{
+		"Ipv6PublicIpAddressId.1": ipv6PublicIpId,
+	}
+	results, err = s.ReadIpv6PublicIps(req)
+	if err != nil {
+		return data, err
+	}
+	for _, v := range results {
+		data = v.(map[string]interface{})
+	}
+	if len(data) == 0 {
+		return data, fmt.Errorf("Ipv6 public ip %s not exist ", ipv6PublicIpId)
+	}
+	return data, err
+}
+
+func (s *VpcService) ReadAndSetIpv6PublicIp(d *schema.ResourceData, r *schema.Resource) (err error) {
+	data, err := s.ReadIpv6PublicIp(d, "")
+	if err != nil {
+		if notFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	SdkResponseAutoResourceData(d, r, data, chargeExtraForVpc(data))
+	return err
+}
+
+func (s *VpcService) CreateIpv6PublicIpCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	req, err := SdkRequestAutoMapping(d, r, false, nil, nil, SdkReqParameter{
+		onlyTransform: false,
+	})
+	if err != nil {
+		return callback, err
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "CreateIpv6PublicIp",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.vpcconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.CreateIpv6PublicIp(call.param)
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			id, err := getSdkValue("Ipv6PublicIpAddress.Ipv6PublicIpAddressId", *resp)
+			if err != nil {
+				return err
+			}
+			d.SetId(id.(string))
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *VpcService) CreateIpv6PublicIp(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.CreateIpv6PublicIpCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *VpcService) ModifyIpv6PublicIpCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	transform := map[string]SdkReqTransform{
+		"band_width": {},
+	}
+	req, err := SdkRequestAutoMapping(d, r, true, transform, nil, SdkReqParameter{
+		onlyTransform: true,
+	})
+	if err != nil {
+		return callback, err
+	}
+	if len(req) > 0 {
+		req["Ipv6PublicIpAddressId"] = d.Id()
+		callback = ApiCall{
+			param:  &req,
+			action: "ModifyIpv6PublicIp",
+			executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+				conn := client.vpcconn
+				logger.Debug(logger.RespFormat, call.action, *(call.param))
+				resp, err = conn.ModifyIpv6PublicIp(call.param)
+				return resp, err
+			},
+			afterCall: logCall,
+		}
+	}
+	return callback, err
+}
+
+func (s *VpcService) ModifyIpv6PublicIp(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.ModifyIpv6PublicIpCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *VpcService) RemoveIpv6PublicIpCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	removeReq := map[string]interface{}{
+		"Ipv6PublicIpAddressId": d.Id(),
+	}
+	callback = ApiCall{
+		param:  &removeReq,
+		action: "ReleaseIpv6PublicIp",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.vpcconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.ReleaseIpv6PublicIp(call.param)
+			return resp, err
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			return resource.Retry(5*time.Minute, func() *resource.RetryError {
+				_, callErr := s.ReadIpv6PublicIp(d, "")
+				if callErr != nil {
+					if notFoundError(callErr) {
+						return nil
+					}
+					return resource.NonRetryableError(fmt.Errorf("error on reading ipv6 public ip when delete %q, %s", d.Id(), callErr))
+				}
+				_, callErr = call.executeCall(d, client, call)
+				if callErr == nil {
+					return nil
+				}
+				return resource.RetryableError(callErr)
+			})
+		},
+		afterCall: logCall,
+	}
+	return callback, err
+}
+
+func (s *VpcService) RemoveIpv6PublicIp(d *schema.ResourceData) (err error) {
+	call, err := s.RemoveIpv6PublicIpCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+// firstIpv6CidrBlock returns the first IPv6 CIDR block associated to a vpc or a subnet
+func firstIpv6CidrBlock(data map[string]interface{}) string {
+	associations, _ := data["Ipv6CidrBlockAssociationSet"].([]interface{})
+	for _, association := range associations {
+		if block, ok := association.(map[string]interface{})["Ipv6CidrBlock"].(string); ok && block != "" {
+			return block
+		}
+	}
+	return ""
+}
+
+func (s *VpcService) ReadVpcPeeringConnections(condition map[string]interface{}) (data []interface{}, err error) {
+	var (
+		resp    *map[string]interface{}
+		results interface{}
+	)
+	return pageQueryWithNextToken(condition, "MaxResults", "NextToken", 100, func(condition map[string]interface{}) ([]interface{}, string, error) {
+		conn := s.client.vpcconn
+		action := "DescribeVpcPeeringConnections"
+		logger.Debug(logger.ReqFormat, action, condition)
+		if condition == nil {
+			resp, err = conn.DescribeVpcPeeringConnections(nil)
+		} else {
+			resp, err = conn.DescribeVpcPeeringConnections(&condition)
+		}
+		if err != nil {
+			return data, "", err
+		}
+		nextToken := (*resp)["NextToken"]
+		results, err = getSdkValue("VpcPeeringConnectionSet", *resp)
+		if err != nil {
+			return data, "", err
+		}
+		data, _ = results.([]interface{})
+		return data, indirectString(nextToken), err
+	})
+}
+
+func (s *VpcService) ReadVpcPeeringConnection(d *schema.ResourceData, peeringId string) (data map[string]interface{}, err error) {
+	var (
+		results []interface{}
+	)
+	if peeringId == "" {
+		peeringId = d.Id()
+	}
+	req := map[string]interface{}{
+		"VpcPeeringConnectionId.1": peeringId,
+	}
+	results, err = s.ReadVpcPeeringConnections(req)
+	if err != nil {
+		return data, err
+	}
+	for _, v := range results {
+		data = v.(map[string]interface{})
+	}
+	if len(data) == 0 || data["State"] == "deleted" {
+		return data, fmt.Errorf("Vpc peering connection %s not exist ", peeringId)
+	}
+	return data, err
+}
+
+// flattenVpcPeeringConnection flattens the RequesterVpcInfo and AccepterVpcInfo of a peering connection into the vpc
+// of the own side and the peer vpc, the own side is the accepter side when accepter is true
+func flattenVpcPeeringConnection(data map[string]interface{}, accepter bool) map[string]interface{} {
+	own, _ := data["RequesterVpcInfo"].(map[string]interface{})
+	peer, _ := data["AccepterVpcInfo"].(map[string]interface{})
+	if accepter {
+		own, peer = peer, own
+	}
+	if vpcId, ok := own["VpcId"]; ok {
+		data["VpcId"] = vpcId
+	}
+	for k, v := range map[string]string{
+		"VpcId":     "PeerVpcId",
+		"Region":    "PeerRegion",
+		"AccountId": "PeerAccountId",
+		"CidrBlock": "PeerCidrBlock",
+	} {
+		if value, ok := peer[k]; ok {
+			data[v] = value
+		}
+	}
+	delete(data, "RequesterVpcInfo")
+	delete(data, "AccepterVpcInfo")
+	return data
+}
+
+func (s *VpcService) ReadAndSetVpcPeeringConnection(d *schema.ResourceData, r *schema.Resource, accepter bool) (err error) {
+	data, err := s.ReadVpcPeeringConnection(d, "")
+	if err != nil {
+		if notFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	data = flattenVpcPeeringConnection(data, accepter)
+	extra := chargeExtraForVpc(data)
+	if accepter {
+		extra = nil
+	}
+	SdkResponseAutoResourceData(d, r, data, extra)
+	return err
+}
+
+func (s *VpcService) ReadAndSetVpcPeeringConnections(d *schema.ResourceData, r *schema.Resource) (err error) {
+	transform := map[string]SdkReqTransform{
+		"ids": {
+			mapping: "VpcPeeringConnectionId",
+			Type:    TransformWithN,
+		},
+		"project_ids": {
+			mapping: "ProjectId",
+			Type:    TransformWithN,
+		},
+		"vpc_ids": {
+			mapping: "vpc-id",
+			Type:    TransformWithFilter,
+		},
+	}
+	req, err := mergeDataSourcesReq(d, r, transform)
+	if err != nil {
+		return err
+	}
+	data, err := s.ReadVpcPeeringConnections(req)
+	if err != nil {
+		return err
+	}
+	for _, item := range data {
+		peering := item.(map[string]interface{})
+		for k, side := range map[string]string{"RequesterVpcInfo": "requester_vpc", "AccepterVpcInfo": "accepter_vpc"} {
+			if info, ok := peering[k].(map[string]interface{}); ok {
+				peering[Downline2Hump(side)] = []interface{}{info}
+			}
+			delete(peering, k)
+		}
+	}
+
+	return mergeDataSourcesResp(d, r, ksyunDataSource{
+		collection:  data,
+		nameField:   "PeeringName",
+		idFiled:     "VpcPeeringConnectionId",
+		targetField: "vpc_peering_connections",
+		extra: map[string]SdkResponseMapping{
+			"PeeringName": {
+				Field: "name",
+			},
+			"VpcPeeringConnectionId": {
+				Field:    "id",
+				KeepAuto: true,
+			},
+		},
+	})
+}
+
+func (s *VpcService) vpcPeeringConnectionStateRefreshFunc(d *schema.ResourceData, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		data, err := s.ReadVpcPeeringConnection(d, "")
+		if err != nil {
+			return nil, "", err
+		}
+		state, _ := data["State"].(string)
+		for _, v := range failStates {
+			if v == state {
+				return nil, "", fmt.Errorf("vpc peering connection %s is %s", d.Id(), state)
+			}
+		}
+		return data, state, nil
+	}
+}
+
+// checkVpcPeeringConnectionState waits for the peering connection to get into one of the target states, it fails
+// when the peering connection is rejected or expires before that
+func (s *VpcService) checkVpcPeeringConnectionState(d *schema.ResourceData, target []string, timeout time.Duration) (err error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{},
+		Target:     target,
+		Refresh:    s.vpcPeeringConnectionStateRefreshFunc(d, []string{"rejected", "expired", "failed"}),
+		Timeout:    timeout,
+		Delay:      3 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	_, err = stateConf.WaitForState()
+	return err
+}
+
+func (s *VpcService) CreateVpcPeeringConnectionCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	req, err := SdkRequestAutoMapping(d, r, false, nil, nil, SdkReqParameter{
+		onlyTransform: false,
+	})
+	if err != nil {
+		return callback, err
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "CreateVpcPeeringConnection",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.vpcconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.CreateVpcPeeringConnection(call.param)
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			id, err := getSdkValue("VpcPeeringConnection.VpcPeeringConnectionId", *resp)
+			if err != nil {
+				return err
+			}
+			d.SetId(id.(string))
+			return s.checkVpcPeeringConnectionState(d, []string{"pending-acceptance", "active"}, d.Timeout(schema.TimeoutCreate))
+		},
+	}
+	return callback, err
+}
+
+func (s *VpcService) CreateVpcPeeringConnection(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.CreateVpcPeeringConnectionCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *VpcService) ModifyVpcPeeringConnectionCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	transform := map[string]SdkReqTransform{
+		"peering_name": {},
+		"band_width":   {},
+	}
+	req, err := SdkRequestAutoMapping(d, r, true, transform, nil, SdkReqParameter{
+		onlyTransform: true,
+	})
+	if err != nil {
+		return callback, err
+	}
+	if len(req) > 0 {
+		req["VpcPeeringConnectionId"] = d.Id()
+		callback = ApiCall{
+			param:  &req,
+			action: "ModifyVpcPeeringConnection",
+			executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+				conn := client.vpcconn
+				logger.Debug(logger.RespFormat, call.action, *(call.param))
+				resp, err = conn.ModifyVpcPeeringConnection(call.param)
+				return resp, err
+			},
+			afterCall: logCall,
+		}
+	}
+	return callback, err
+}
+
+func (s *VpcService) ModifyVpcPeeringConnection(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.ModifyVpcPeeringConnectionCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *VpcService) RemoveVpcPeeringConnectionCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	removeReq := map[string]interface{}{
+		"VpcPeeringConnectionId": d.Id(),
+	}
+	callback = ApiCall{
+		param:  &removeReq,
+		action: "DeleteVpcPeeringConnection",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.vpcconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.DeleteVpcPeeringConnection(call.param)
+			return resp, err
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			return resource.Retry(5*time.Minute, func() *resource.RetryError {
+				_, callErr := s.ReadVpcPeeringConnection(d, "")
+				if callErr != nil {
+					if notFoundError(callErr) {
+						return nil
+					}
+					return resource.NonRetryableError(fmt.Errorf("error on reading vpc peering connection when delete %q, %s", d.Id(), callErr))
+				}
+				_, callErr = call.executeCall(d, client, call)
+				if callErr == nil {
+					return nil
+				}
+				return resource.RetryableError(callErr)
+			})
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+				_, callErr := s.ReadVpcPeeringConnection(d, "")
+				if callErr != nil {
+					if notFoundError(callErr) {
+						return nil
+					}
+					return resource.NonRetryableError(callErr)
+				}
+				return resource.RetryableError(fmt.Errorf("vpc peering connection %s is still being deleted", d.Id()))
+			})
+		},
+	}
+	return callback, err
+}
+
+func (s *VpcService) RemoveVpcPeeringConnection(d *schema.ResourceData) (err error) {
+	call, err := s.RemoveVpcPeeringConnectionCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+// AcceptVpcPeeringConnection accepts the peering connection requested to a vpc of this account and region and waits
+// for it to be active, a peering connection already active is left as it is
+func (s *VpcService) AcceptVpcPeeringConnection(d *schema.ResourceData) (err error) {
+	peeringId := d.Get("vpc_peering_connection_id").(string)
+	data, err := s.ReadVpcPeeringConnection(d, peeringId)
+	if err != nil {
+		return err
+	}
+	d.SetId(peeringId)
+	if data["State"] == "active" {
+		return s.checkVpcPeeringConnectionState(d, []string{"active"}, d.Timeout(schema.TimeoutCreate))
+	}
+	req := map[string]interface{}{
+		"VpcPeeringConnectionId": peeringId,
+	}
+	callback := ApiCall{
+		param:  &req,
+		action: "AcceptVpcPeeringConnection",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.vpcconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.AcceptVpcPeeringConnection(call.param)
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return s.checkVpcPeeringConnectionState(d, []string{"active"}, d.Timeout(schema.TimeoutCreate))
+		},
+	}
+	err = ksyunApiCallNew([]ApiCall{callback}, d, s.client, true)
+	if err != nil {
+		d.SetId("")
+	}
+	return err
+}
+
+// doVpcCustomAction calls the vpc actions the vpc client of the sdk does not provide yet, such as the flow log ones
+func (s *VpcService) doVpcCustomAction(action string, input *map[string]interface{}) (resp *map[string]interface{}, err error) {
+	conn := s.client.vpcconn
+	op := &request.Operation{
+		Name:       action,
+		HTTPMethod: "GET",
+		HTTPPath:   "/",
+	}
+	if input == nil {
+		input = &map[string]interface{}{}
+	}
+	output := &map[string]interface{}{}
+	req := conn.NewRequest(op, input, output)
+	err = req.Send()
+	return output, err
+}
+
+func (s *VpcService) ReadFlowLogs(condition map[string]interface{}) (data []interface{}, err error) {
+	var (
+		resp    *map[string]interface{}
+		results interface{}
+	)
+	return pageQueryWithNextToken(condition, "MaxResults", "NextToken", 100, func(condition map[string]interface{}) ([]interface{}, string, error) {
+		action := "DescribeFlowLogs"
+		logger.Debug(logger.ReqFormat, action, condition)
+		resp, err = s.doVpcCustomAction(action, &condition)
+		if err != nil {
+			return data, "", err
+		}
+		nextToken := (*resp)["NextToken"]
+		results, err = getSdkValue("FlowLogs", *resp)
+		if err != nil {
+			return data, "", err
+		}
+		data, _ = results.([]interface{})
+		return data, indirectString(nextToken), err
+	})
+}
+
+func (s *VpcService) ReadFlowLog(d *schema.ResourceData, flowLogId string) (data map[string]interface{}, err error) {
+	var (
+		results []interface{}
+	)
+	if flowLogId == "" {
+		flowLogId = d.Id()
+	}
+	req := map[string]interface{}{
+		"FlowLogId.1": flowLogId,
+	}
+	results, err = s.ReadFlowLogs(req)
+	if err != nil {
+		return data, err
+	}
+	for _, v := range results {
+		data = v.(map[string]interface{})
+	}
+	if len(data) == 0 {
+		return data, fmt.Errorf("Flow log %s not exist ", flowLogId)
+	}
+	return data, err
+}
+
+func (s *VpcService) ReadAndSetFlowLog(d *schema.ResourceData, r *schema.Resource) (err error) {
+	data, err := s.ReadFlowLog(d, "")
+	if err != nil {
+		if notFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	SdkResponseAutoResourceData(d, r, data, nil)
+	return err
+}
+
+func (s *VpcService) CreateFlowLogCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	req, err := SdkRequestAutoMapping(d, r, false, nil, nil, SdkReqParameter{
+		onlyTransform: false,
+	})
+	if err != nil {
+		return callback, err
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "CreateFlowLog",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			return s.doVpcCustomAction(call.action, call.param)
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			id, err := getSdkValue("FlowLogId", *resp)
+			if err != nil {
+				return err
+			}
+			d.SetId(id.(string))
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *VpcService) CreateFlowLog(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.CreateFlowLogCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *VpcService) ModifyFlowLogCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	transform := map[string]SdkReqTransform{
+		"flow_log_name": {},
+		"window_time":   {},
+		"description":   {},
+	}
+	req, err := SdkRequestAutoMapping(d, r, true, transform, nil, SdkReqParameter{
+		onlyTransform: true,
+	})
+	if err != nil {
+		return callback, err
+	}
+	if len(req) > 0 {
+		req["FlowLogId"] = d.Id()
+		callback = ApiCall{
+			param:  &req,
+			action: "ModifyFlowLog",
+			executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+				logger.Debug(logger.RespFormat, call.action, *(call.param))
+				return s.doVpcCustomAction(call.action, call.param)
+			},
+			afterCall: logCall,
+		}
+	}
+	return callback, err
+}
+
+func (s *VpcService) ModifyFlowLog(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.ModifyFlowLogCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *VpcService) RemoveFlowLogCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	removeReq := map[string]interface{}{
+		"FlowLogId": d.Id(),
+	}
+	callback = ApiCall{
+		param:  &removeReq,
+		action: "DeleteFlowLog",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			return s.doVpcCustomAction(call.action, call.param)
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			return resource.Retry(5*time.Minute, func() *resource.RetryError {
+				_, callErr := s.ReadFlowLog(d, "")
+				if callErr != nil {
+					if notFoundError(callErr) {
+						return nil
+					}
+					return resource.NonRetryableError(fmt.Errorf("error on reading flow log when delete %q, %s", d.Id(), callErr))
+				}
+				_, callErr = call.executeCall(d, client, call)
+				if callErr == nil {
+					return nil
+				}
+				return resource.RetryableError(callErr)
+			})
+		},
+		afterCall: logCall,
+	}
+	return callback, err
+}
+
+func (s *VpcService) RemoveFlowLog(d *schema.ResourceData) (err error) {
+	call, err := s.RemoveFlowLogCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *VpcService) ReadHaVips(condition map[string]interface{}) (data []interface{}, err error) {
+	var (
+		resp    *map[string]interface{}
+		results interface{}
+	)
+	return pageQueryWithNextToken(condition, "MaxResults", "NextToken", 100, func(condition map[string]interface{}) ([]interface{}, string, error) {
+		action := "DescribeHaVip"
+		logger.Debug(logger.ReqFormat, action, condition)
+		resp, err = s.doVpcCustomAction(action, &condition)
+		if err != nil {
+			return data, "", err
+		}
+		nextToken := (*resp)["NextToken"]
+		results, err = getSdkValue("HaVipSet", *resp)
+		if err != nil {
+			return data, "", err
+		}
+		data, _ = results.([]interface{})
+		return data, indirectString(nextToken), err
+	})
+}
+
+func (s *VpcService) ReadHaVip(d *schema.ResourceData, haVipId string) (data map[string]interface{}, err error) {
+	var (
+		results []interface{}
+	)
+	if haVipId == "" {
+		haVipId = d.Id()
+	}
+	req := map[string]interface{}{
+		"HaVipId.1": haVipId,
+	}
+	results, err = s.ReadHaVips(req)
+	if err != nil {
+		return data, err
+	}
+	for _, v := range results {
+		data = v.(map[string]interface{})
+	}
+	if len(data) == 0 {
+		return data, fmt.Errorf("HaVip %s not exist ", haVipId)
+	}
+	return data, err
+}
+
+// haVipNetworkInterfaceRole returns the role of the network interface in the havip, master or slave,
+// and an empty string if the network interface is not attached to it
+func haVipNetworkInterfaceRole(data map[string]interface{}, networkInterfaceId string) string {
+	if networkInterfaceId == "" {
+		return ""
+	}
+	if data["MasterNetworkInterfaceId"] == networkInterfaceId {
+		return "master"
+	}
+	if slaves, ok := data["SlaveNetworkInterfaceIdSet"].([]interface{}); ok {
+		for _, slave := range slaves {
+			if slave == networkInterfaceId {
+				return "slave"
+			}
+		}
+	}
+	return ""
+}
+
+func haVipExtra() map[string]SdkResponseMapping {
+	return map[string]SdkResponseMapping{
+		"SlaveNetworkInterfaceIdSet": {
+			Field: "slave_network_interface_ids",
+		},
+	}
+}
+
+func (s *VpcService) ReadAndSetHaVip(d *schema.ResourceData, r *schema.Resource) (err error) {
+	data, err := s.ReadHaVip(d, "")
+	if err != nil {
+		if notFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	SdkResponseAutoResourceData(d, r, data, haVipExtra())
+	return err
+}
+
+func (s *VpcService) ReadAndSetHaVips(d *schema.ResourceData, r *schema.Resource) (err error) {
+	transform := map[string]SdkReqTransform{
+		"ids": {
+			mapping: "HaVipId",
+			Type:    TransformWithN,
+		},
+		"vpc_ids": {
+			mapping: "vpc-id",
+			Type:    TransformWithFilter,
+		},
+		"subnet_ids": {
+			mapping: "subnet-id",
+			Type:    TransformWithFilter,
+		},
+	}
+	req, err := mergeDataSourcesReq(d, r, transform)
+	if err != nil {
+		return err
+	}
+	data, err := s.ReadHaVips(req)
+	if err != nil {
+		return err
+	}
+	extra := haVipExtra()
+	extra["HaVipId"] = SdkResponseMapping{
+		Field:    "id",
+		KeepAuto: true,
+	}
+	return mergeDataSourcesResp(d, r, ksyunDataSource{
+		collection:  data,
+		idFiled:     "HaVipId",
+		targetField: "havips",
+		extra:       extra,
+	})
+}
+
+func (s *VpcService) CreateHaVipCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	req, err := SdkRequestAutoMapping(d, r, false, nil, nil, SdkReqParameter{
+		onlyTransform: false,
+	})
+	if err != nil {
+		return callback, err
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "CreateHaVip",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			return s.doVpcCustomAction(call.action, call.param)
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			id, err := getSdkValue("HaVip.HaVipId", *resp)
+			if err != nil {
+				return err
+			}
+			d.SetId(id.(string))
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *VpcService) CreateHaVip(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.CreateHaVipCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *VpcService) RemoveHaVipCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	removeReq := map[string]interface{}{
+		"HaVipId": d.Id(),
+	}
+	callback = ApiCall{
+		param:  &removeReq,
+		action: "DeleteHaVip",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			return s.doVpcCustomAction(call.action, call.param)
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			return resource.Retry(5*time.Minute, func() *resource.RetryError {
+				_, callErr := s.ReadHaVip(d, "")
+				if callErr != nil {
+					if notFoundError(callErr) {
+						return nil
+					}
+					return resource.NonRetryableError(fmt.Errorf("error on reading havip when delete %q, %s", d.Id(), callErr))
+				}
+				_, callErr = call.executeCall(d, client, call)
+				if callErr == nil {
+					return nil
+				}
+				return resource.RetryableError(callErr)
+			})
+		},
+		afterCall: logCall,
+	}
+	return callback, err
+}
+
+func (s *VpcService) RemoveHaVip(d *schema.ResourceData) (err error) {
+	call, err := s.RemoveHaVipCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+// readHaVipAttachmentNetworkInterfaceId returns the network interface of the havip attachment, the primary
+// network interface of the instance if only instance_id is set
+func (s *VpcService) readHaVipAttachmentNetworkInterfaceId(d *schema.ResourceData) (networkInterfaceId string, err error) {
+	if v, ok := d.GetOk("network_interface_id"); ok {
+		return v.(string), err
+	}
+	instanceId := d.Get("instance_id").(string)
+	kecService := KecService{s.client}
+	instance, err := kecService.readKecInstance(d, instanceId, true)
+	if err != nil {
+		return networkInterfaceId, err
+	}
+	if vifs, ok := instance["NetworkInterfaceSet"].([]interface{}); ok {
+		for _, vif := range vifs {
+			if vif.(map[string]interface{})["NetworkInterfaceType"] == "primary" {
+				networkInterfaceId, _ = vif.(map[string]interface{})["NetworkInterfaceId"].(string)
+			}
+		}
+	}
+	if networkInterfaceId == "" {
+		return networkInterfaceId, fmt.Errorf("the primary network interface of instance %s not exist ", instanceId)
+	}
+	return networkInterfaceId, err
+}
+
+func (s *VpcService) ReadHaVipAttachment(d *schema.ResourceData, haVipId string, networkInterfaceId string) (data map[string]interface{}, err error) {
+	data, err = s.ReadHaVip(d, haVipId)
+	if err != nil {
+		return data, err
+	}
+	if haVipNetworkInterfaceRole(data, networkInterfaceId) == "" {
+		return data, fmt.Errorf("NetworkInterface %s associated with HaVip %s not exist ", networkInterfaceId, haVipId)
+	}
+	return data, err
+}
+
+func (s *VpcService) ReadAndSetHaVipAttachment(d *schema.ResourceData, r *schema.Resource) (err error) {
+	haVipId := d.Get("havip_id").(string)
+	networkInterfaceId := d.Get("network_interface_id").(string)
+	data, err := s.ReadHaVipAttachment(d, haVipId, networkInterfaceId)
+	if err != nil {
+		if notFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	networkInterface, err := s.ReadNetworkInterface(d, networkInterfaceId)
+	if err != nil && !notFoundError(err) {
+		return err
+	}
+	err = d.Set("instance_id", networkInterface["InstanceId"])
+	if err != nil {
+		return err
+	}
+	return d.Set("role", haVipNetworkInterfaceRole(data, networkInterfaceId))
+}
+
+func (s *VpcService) checkHaVipAttachment(d *schema.ResourceData, haVipId string, networkInterfaceId string, attached bool, timeout time.Duration) (err error) {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		_, callErr := s.ReadHaVipAttachment(d, haVipId, networkInterfaceId)
+		if callErr != nil && !notFoundError(callErr) {
+			return resource.NonRetryableError(callErr)
+		}
+		if (callErr == nil) != attached {
+			return resource.RetryableError(fmt.Errorf("waiting for the association of network interface %s with havip %s", networkInterfaceId, haVipId))
+		}
+		return nil
+	})
+}
+
+func (s *VpcService) CreateHaVipAttachmentCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	networkInterfaceId, err := s.readHaVipAttachmentNetworkInterfaceId(d)
+	if err != nil {
+		return callback, err
+	}
+	req := map[string]interface{}{
+		"HaVipId":            d.Get("havip_id"),
+		"NetworkInterfaceId": networkInterfaceId,
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "AssociateHaVip",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			return s.doVpcCustomAction(call.action, call.param)
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			haVipId := d.Get("havip_id").(string)
+			err = s.checkHaVipAttachment(d, haVipId, networkInterfaceId, true, d.Timeout(schema.TimeoutCreate))
+			if err != nil {
+				return err
+			}
+			err = d.Set("network_interface_id", networkInterfaceId)
+			if err != nil {
+				return err
+			}
+			d.SetId(haVipId + ":" + networkInterfaceId)
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *VpcService) CreateHaVipAttachment(d *schema.ResourceData) (err error) {
+	call, err := s.CreateHaVipAttachmentCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *VpcService) RemoveHaVipAttachmentCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	haVipId := d.Get("havip_id").(string)
+	networkInterfaceId := d.Get("network_interface_id").(string)
+	removeReq := map[string]interface{}{
+		"HaVipId":            haVipId,
+		"NetworkInterfaceId": networkInterfaceId,
+	}
+	callback = ApiCall{
+		param:  &removeReq,
+		action: "UnAssociateHaVip",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			return s.doVpcCustomAction(call.action, call.param)
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			return resource.Retry(5*time.Minute, func() *resource.RetryError {
+				_, callErr := s.ReadHaVipAttachment(d, haVipId, networkInterfaceId)
+				if callErr != nil {
+					if notFoundError(callErr) {
+						return nil
+					}
+					return resource.NonRetryableError(fmt.Errorf("error on reading havip attachment when delete %q, %s", d.Id(), callErr))
+				}
+				_, callErr = call.executeCall(d, client, call)
+				if callErr == nil {
+					return nil
+				}
+				return resource.RetryableError(callErr)
+			})
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return s.checkHaVipAttachment(d, haVipId, networkInterfaceId, false, d.Timeout(schema.TimeoutDelete))
+		},
+	}
+	return callback, err
+}
+
+func (s *VpcService) RemoveHaVipAttachment(d *schema.ResourceData) (err error) {
+	call, err := s.RemoveHaVipAttachmentCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
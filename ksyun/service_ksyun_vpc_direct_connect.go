@@ -97,27 +97,22 @@ func (s *VpcService) readDirectConnectInterfaces(condition map[string]interface{
 		resp    *map[string]interface{}
 		results interface{}
 	)
-	conn := s.client.vpcconn
-	action := "DescribeDirectConnectInterfaces"
-	logger.Debug(logger.ReqFormat, action, condition)
-	if condition == nil {
-		resp, err = conn.DescribeDirectConnectInterfaces(nil)
+	return pageQueryWithNextToken(condition, "MaxResults", "NextToken", 100, func(condition map[string]interface{}) ([]interface{}, string, error) {
+		conn := s.client.vpcconn
+		action := "DescribeDirectConnectInterfaces"
+		logger.Debug(logger.ReqFormat, action, condition)
+		resp, err = conn.DescribeDirectConnectInterfaces(&condition)
 		if err != nil {
-			return data, err
+			return data, "", err
 		}
-	} else {
-		resp, err = conn.DescribeDirectConnectInterfaces(&condition)
+		nextToken := (*resp)["NextToken"]
+		results, err = getSdkValue("DirectConnectInterfaceSet", *resp)
 		if err != nil {
-			return data, err
+			return data, "", err
 		}
-	}
-
-	results, err = getSdkValue("DirectConnectInterfaceSet", *resp)
-	if err != nil {
-		return data, err
-	}
-	data = results.([]interface{})
-	return data, err
+		data, _ = results.([]interface{})
+		return data, indirectString(nextToken), err
+	})
 }
 
 func (s *VpcService) ModifyDirectConnectInterface(d *schema.ResourceData, r *schema.Resource) (err error) {
@@ -522,27 +517,22 @@ func (s *VpcService) readDirectConnectGateways(condition map[string]interface{})
 		resp    *map[string]interface{}
 		results interface{}
 	)
-	conn := s.client.vpcconn
-	action := "DescribeDirectConnectGateways"
-	logger.Debug(logger.ReqFormat, action, condition)
-	if condition == nil {
-		resp, err = conn.DescribeDirectConnectGateways(nil)
+	return pageQueryWithNextToken(condition, "MaxResults", "NextToken", 100, func(condition map[string]interface{}) ([]interface{}, string, error) {
+		conn := s.client.vpcconn
+		action := "DescribeDirectConnectGateways"
+		logger.Debug(logger.ReqFormat, action, condition)
+		resp, err = conn.DescribeDirectConnectGateways(&condition)
 		if err != nil {
-			return data, err
+			return data, "", err
 		}
-	} else {
-		resp, err = conn.DescribeDirectConnectGateways(&condition)
+		nextToken := (*resp)["NextToken"]
+		results, err = getSdkValue("DirectConnectGatewaySet", *resp)
 		if err != nil {
-			return data, err
+			return data, "", err
 		}
-	}
-
-	results, err = getSdkValue("DirectConnectGatewaySet", *resp)
-	if err != nil {
-		return data, err
-	}
-	data = results.([]interface{})
-	return data, err
+		data, _ = results.([]interface{})
+		return data, indirectString(nextToken), err
+	})
 }
 
 func (s *VpcService) attachDirectConnectGatewayWithVpcCall(d *schema.ResourceData, r *schema.Resource, vpcid string) (callback ApiCall, err error) {
@@ -723,27 +713,22 @@ func (s *VpcService) readDirectConnectGatewayRoutes(condition map[string]interfa
 		resp    *map[string]interface{}
 		results interface{}
 	)
-	conn := s.client.vpcconn
-	action := "DescribeDirectConnectGatewayRoutes"
-	logger.Debug(logger.ReqFormat, action, condition)
-	if condition == nil {
-		resp, err = conn.DescribeDirectConnectGatewayRoute(nil)
+	return pageQueryWithNextToken(condition, "MaxResults", "NextToken", 100, func(condition map[string]interface{}) ([]interface{}, string, error) {
+		conn := s.client.vpcconn
+		action := "DescribeDirectConnectGatewayRoutes"
+		logger.Debug(logger.ReqFormat, action, condition)
+		resp, err = conn.DescribeDirectConnectGatewayRoute(&condition)
 		if err != nil {
-			return data, err
+			return data, "", err
 		}
-	} else {
-		resp, err = conn.DescribeDirectConnectGatewayRoute(&condition)
+		nextToken := (*resp)["NextToken"]
+		results, err = getSdkValue("DirectConnectGatewayRouteSet", *resp)
 		if err != nil {
-			return data, err
+			return data, "", err
 		}
-	}
-
-	results, err = getSdkValue("DirectConnectGatewayRouteSet", *resp)
-	if err != nil {
-		return data, err
-	}
-	data = results.([]interface{})
-	return data, err
+		data, _ = results.([]interface{})
+		return data, indirectString(nextToken), err
+	})
 }
 
 func (s *VpcService) PublishDirectConnectRoute(d *schema.ResourceData) (err error) {
@@ -1079,3 +1064,180 @@ func (s *VpcService) removeDirectConnectBfdConfigCall(d *schema.ResourceData) (c
 	}
 	return callback, err
 }
+
+func (s *VpcService) ReadAndSetDirectConnectGateways(d *schema.ResourceData, r *schema.Resource) (err error) {
+	transform := map[string]SdkReqTransform{
+		"ids": {
+			mapping: "DirectConnectGatewayId",
+			Type:    TransformWithN,
+		},
+		"vpc_ids": {
+			mapping: "vpc-id",
+			Type:    TransformWithFilter,
+		},
+	}
+	req, err := mergeDataSourcesReq(d, r, transform)
+	if err != nil {
+		return err
+	}
+	data, err := s.readDirectConnectGateways(req)
+	if err != nil {
+		return err
+	}
+
+	return mergeDataSourcesResp(d, r, ksyunDataSource{
+		collection:  data,
+		nameField:   "DirectConnectGatewayName",
+		idFiled:     "DirectConnectGatewayId",
+		targetField: "direct_connect_gateways",
+		extra: map[string]SdkResponseMapping{
+			"DirectConnectGatewayId": {
+				Field:    "id",
+				KeepAuto: true,
+			},
+			"DirectConnectInterfaceInfoSet": {
+				Field: "direct_connect_interface_ids",
+				FieldRespFunc: func(i interface{}) interface{} {
+					var result []interface{}
+					for _, v := range i.([]interface{}) {
+						result = append(result, v.(map[string]interface{})["DirectConnectInterfaceId"])
+					}
+					return result
+				},
+			},
+		},
+	})
+}
+
+// directConnectInterfacesBgpStatus returns the bgp status by the interface ID, the set of the response of
+// DescribeDirectConnectInterfacesBgpStatus is not named by the API reference, so it is looked up by its items
+func directConnectInterfacesBgpStatus(resp map[string]interface{}) map[string]string {
+	result := make(map[string]string)
+	for _, v := range resp {
+		items, ok := v.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, item := range items {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			id, ok := m["DirectConnectInterfaceId"].(string)
+			if !ok {
+				continue
+			}
+			if status, ok := m["BgpStatus"].(string); ok {
+				result[id] = status
+			}
+		}
+	}
+	return result
+}
+
+func (s *VpcService) readDirectConnectInterfacesBgpStatus(ids []string) (result map[string]string, err error) {
+	if len(ids) == 0 {
+		return map[string]string{}, err
+	}
+	req := make(map[string]interface{})
+	for i, id := range ids {
+		req[fmt.Sprintf("DirectConnectInterfaceId.%d", i+1)] = id
+	}
+	action := "DescribeDirectConnectInterfacesBgpStatus"
+	logger.Debug(logger.ReqFormat, action, req)
+	resp, err := s.doVpcCustomAction(action, &req)
+	if err != nil {
+		return result, err
+	}
+	return directConnectInterfacesBgpStatus(*resp), err
+}
+
+func (s *VpcService) ReadAndSetDirectConnectInterfaces(d *schema.ResourceData, r *schema.Resource) (err error) {
+	transform := map[string]SdkReqTransform{
+		"ids": {
+			mapping: "DirectConnectInterfaceId",
+			Type:    TransformWithN,
+		},
+		"direct_connect_ids": {
+			Ignore: true,
+		},
+	}
+	req, err := mergeDataSourcesReq(d, r, transform)
+	if err != nil {
+		return err
+	}
+	results, err := s.readDirectConnectInterfaces(req)
+	if err != nil {
+		return err
+	}
+
+	// the interfaces can not be filtered by the direct connect on the server side
+	var (
+		data   []interface{}
+		bgpIds []string
+	)
+	directConnectIds := SchemaSetToStringSlice(d.Get("direct_connect_ids"))
+	for _, v := range results {
+		item := v.(map[string]interface{})
+		directConnectId, _ := item["DirectConnectId"].(string)
+		if len(directConnectIds) > 0 && !stringSliceContains(directConnectIds, directConnectId) {
+			continue
+		}
+		if item["RouteType"] == "BGP" {
+			bgpIds = append(bgpIds, item["DirectConnectInterfaceId"].(string))
+		}
+		data = append(data, item)
+	}
+	bgpStatus, err := s.readDirectConnectInterfacesBgpStatus(bgpIds)
+	if err != nil {
+		return err
+	}
+	for _, v := range data {
+		item := v.(map[string]interface{})
+		if status, ok := bgpStatus[item["DirectConnectInterfaceId"].(string)]; ok {
+			item["BgpStatus"] = status
+		}
+	}
+
+	return mergeDataSourcesResp(d, r, ksyunDataSource{
+		collection:  data,
+		nameField:   "DirectConnectInterfaceName",
+		idFiled:     "DirectConnectInterfaceId",
+		targetField: "direct_connect_interfaces",
+		extra: map[string]SdkResponseMapping{
+			"DirectConnectInterfaceId": {
+				Field:    "id",
+				KeepAuto: true,
+			},
+		},
+	})
+}
+
+func (s *VpcService) ReadAndSetDirectConnectGatewayRoutes(d *schema.ResourceData, r *schema.Resource) (err error) {
+	transform := map[string]SdkReqTransform{
+		"destination_cidr_blocks": {
+			mapping: "cidr-block",
+			Type:    TransformWithFilter,
+		},
+	}
+	req, err := mergeDataSourcesReq(d, r, transform)
+	if err != nil {
+		return err
+	}
+	data, err := s.readDirectConnectGatewayRoutes(req)
+	if err != nil {
+		return err
+	}
+
+	return mergeDataSourcesResp(d, r, ksyunDataSource{
+		collection:  data,
+		idFiled:     "DirectConnectGatewayRouteId",
+		targetField: "routes",
+		extra: map[string]SdkResponseMapping{
+			"DirectConnectGatewayRouteId": {
+				Field:    "id",
+				KeepAuto: true,
+			},
+		},
+	})
+}
@@ -3,6 +3,9 @@ package ksyun
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -282,3 +285,841 @@ func (s *VpcService) DescribeDnats(param DescribeDnatsParams) (dnats []interface
 		return dnats, nil
 	})
 }
+
+// dnatRule is a port mapping of ksyun_dnat_rules, a dnat maps a single port or every port, so the port ranges of the
+// configuration are expanded into one rule per public port
+type dnatRule struct {
+	protocol         string
+	publicPort       string
+	privateIpAddress string
+	privatePort      string
+	description      string
+	dnatId           string
+}
+
+// key identifies the rule by its public port, the private side and the description are changed by ModifyDnat
+func (r dnatRule) key() string {
+	return r.protocol + "-" + r.publicPort
+}
+
+func (r dnatRule) mapsAs(other dnatRule) bool {
+	return r.privateIpAddress == other.privateIpAddress && r.privatePort == other.privatePort &&
+		r.description == other.description
+}
+
+// dnatRulePortRangeMax is the most ports of a mapping, CreateDnat maps a single port, so a range is created as one dnat
+// per port and a wide range would be as many calls
+const dnatRulePortRangeMax = 100
+
+// expandDnatRule expands an element of rule into one rule per public port, the private port range starts at
+// private_port_from, or at public_port_from when not set, and has the length of the public port range
+func expandDnatRule(m map[string]interface{}) (rules []dnatRule, err error) {
+	rule := dnatRule{
+		protocol:         m["ip_protocol"].(string),
+		privateIpAddress: m["private_ip_address"].(string),
+		description:      m["description"].(string),
+	}
+	publicFrom, publicTo := m["public_port_from"].(int), m["public_port_to"].(int)
+	privateFrom, privateTo := m["private_port_from"].(int), m["private_port_to"].(int)
+	if rule.protocol == AnyPortType {
+		if publicFrom != 0 || publicTo != 0 || privateFrom != 0 || privateTo != 0 {
+			return rules, fmt.Errorf("the rule of %s with ip_protocol Any maps every port, the ports must not be set",
+				rule.privateIpAddress)
+		}
+		rule.publicPort, rule.privatePort = AnyPortType, AnyPortType
+		return []dnatRule{rule}, err
+	}
+	if publicFrom == 0 {
+		return rules, fmt.Errorf("public_port_from of the %s rule of %s must be set", rule.protocol, rule.privateIpAddress)
+	}
+	if publicTo == 0 {
+		publicTo = publicFrom
+	}
+	if privateFrom == 0 {
+		privateFrom = publicFrom
+	}
+	if privateTo == 0 {
+		privateTo = privateFrom + publicTo - publicFrom
+	}
+	if publicFrom > publicTo {
+		return rules, fmt.Errorf("public_port_from %d is greater than public_port_to %d", publicFrom, publicTo)
+	}
+	if publicTo-publicFrom >= dnatRulePortRangeMax {
+		return rules, fmt.Errorf("the public port range %d-%d has more than %d ports, split it into several rules",
+			publicFrom, publicTo, dnatRulePortRangeMax)
+	}
+	if privateTo-privateFrom != publicTo-publicFrom {
+		return rules, fmt.Errorf("the private port range %d-%d and the public port range %d-%d have different lengths",
+			privateFrom, privateTo, publicFrom, publicTo)
+	}
+	if privateTo > 65535 {
+		return rules, fmt.Errorf("the private port range %d-%d is out of range [1,65535]", privateFrom, privateTo)
+	}
+	for i := 0; i <= publicTo-publicFrom; i++ {
+		rule.publicPort = strconv.Itoa(publicFrom + i)
+		rule.privatePort = strconv.Itoa(privateFrom + i)
+		rules = append(rules, rule)
+	}
+	return rules, err
+}
+
+// validateDnatRules rejects the public ports mapped by more than one rule of the same protocol, a rule of protocol Any
+// maps every port of the nat ip and must be the only rule
+func validateDnatRules(rules []dnatRule) error {
+	mapped := make(map[string]bool)
+	for _, rule := range rules {
+		if rule.protocol == AnyPortType && len(rules) > 1 {
+			return fmt.Errorf("the rule of %s with ip_protocol Any maps every port of the nat ip and can not be used with other rules",
+				rule.privateIpAddress)
+		}
+		if mapped[rule.key()] {
+			return fmt.Errorf("%s public port %s is mapped by more than one rule", rule.protocol, rule.publicPort)
+		}
+		mapped[rule.key()] = true
+	}
+	return nil
+}
+
+// expandDnatRulesFromConfig returns the expanded and validated rules of the rule elements
+func expandDnatRulesFromConfig(get func(string) interface{}) (rules []dnatRule, err error) {
+	elements, ok := get("rule").(*schema.Set)
+	if !ok {
+		return rules, err
+	}
+	for _, item := range elements.List() {
+		var expanded []dnatRule
+		expanded, err = expandDnatRule(item.(map[string]interface{}))
+		if err != nil {
+			return rules, err
+		}
+		rules = append(rules, expanded...)
+	}
+	return rules, validateDnatRules(rules)
+}
+
+// dnatRuleKeys returns the keys of the rules expanded from the rule sets, the invalid elements are skipped
+func dnatRuleKeys(sets ...interface{}) map[string]bool {
+	keys := make(map[string]bool)
+	for _, v := range sets {
+		elements, ok := v.(*schema.Set)
+		if !ok {
+			continue
+		}
+		for _, item := range elements.List() {
+			expanded, _ := expandDnatRule(item.(map[string]interface{}))
+			for _, rule := range expanded {
+				keys[rule.key()] = true
+			}
+		}
+	}
+	return keys
+}
+
+// dnatRulesManagedKeys returns the keys of the rules managed by the resource, which are the public ports of the rule
+// sets, or nil if the resource is exclusive and manages every dnat of the nat ip
+func dnatRulesManagedKeys(d *schema.ResourceData, sets ...interface{}) map[string]bool {
+	if d.Get("exclusive").(bool) {
+		return nil
+	}
+	return dnatRuleKeys(sets...)
+}
+
+// filterDnatRules returns the rules whose key is in keys, or every rule if keys is nil
+func filterDnatRules(rules []dnatRule, keys map[string]bool) []dnatRule {
+	if keys == nil {
+		return rules
+	}
+	var result []dnatRule
+	for _, rule := range rules {
+		if keys[rule.key()] {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+// dnatRulesFromDnatSet converts the DnatSet of DescribeDnats into the rules of the nat ip ordered by protocol and
+// public port
+func dnatRulesFromDnatSet(dnats []interface{}, natIp string) (rules []dnatRule) {
+	for _, item := range dnats {
+		dnat, ok := item.(map[string]interface{})
+		if !ok || dnat["NatIp"] != natIp {
+			continue
+		}
+		rule := dnatRule{}
+		rule.protocol, _ = dnat["IpProtocol"].(string)
+		rule.publicPort, _ = dnat["PublicPort"].(string)
+		rule.privateIpAddress, _ = dnat["PrivateIpAddress"].(string)
+		rule.privatePort, _ = dnat["PrivatePort"].(string)
+		rule.description, _ = dnat["Description"].(string)
+		rule.dnatId, _ = dnat["DnatId"].(string)
+		rules = append(rules, rule)
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].protocol != rules[j].protocol {
+			return rules[i].protocol < rules[j].protocol
+		}
+		pi, _ := strconv.Atoi(rules[i].publicPort)
+		pj, _ := strconv.Atoi(rules[j].publicPort)
+		return pi < pj
+	})
+	return rules
+}
+
+// flattenDnatRules converts the rules into the elements of rule. The configured elements whose ports are all mapped as
+// configured are kept as they are, so the ranges split or written differently in the configuration have no diff, the
+// other rules are merged into the ranges of consecutive ports with the same private ip address and description.
+func flattenDnatRules(rules []dnatRule, configured []interface{}) []interface{} {
+	result := make([]interface{}, 0)
+	remaining := make(map[string]dnatRule)
+	for _, rule := range rules {
+		remaining[rule.key()] = rule
+	}
+	for _, item := range configured {
+		expanded, err := expandDnatRule(item.(map[string]interface{}))
+		if err != nil || len(expanded) == 0 {
+			continue
+		}
+		matched := true
+		for _, rule := range expanded {
+			if current, ok := remaining[rule.key()]; !ok || !current.mapsAs(rule) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		for _, rule := range expanded {
+			delete(remaining, rule.key())
+		}
+		result = append(result, item)
+	}
+
+	var element map[string]interface{}
+	var last dnatRule
+	lastPublic, lastPrivate := 0, 0
+	for _, rule := range rules {
+		if _, ok := remaining[rule.key()]; !ok {
+			continue
+		}
+		publicPort, _ := strconv.Atoi(rule.publicPort)
+		privatePort, _ := strconv.Atoi(rule.privatePort)
+		if element != nil && rule.protocol != AnyPortType && rule.protocol == last.protocol &&
+			rule.privateIpAddress == last.privateIpAddress && rule.description == last.description &&
+			publicPort == lastPublic+1 && privatePort == lastPrivate+1 &&
+			publicPort-element["public_port_from"].(int) < dnatRulePortRangeMax {
+			element["public_port_to"] = publicPort
+		} else {
+			element = map[string]interface{}{
+				"ip_protocol":        rule.protocol,
+				"private_ip_address": rule.privateIpAddress,
+				"public_port_from":   publicPort,
+				"public_port_to":     0,
+				"private_port_from":  0,
+				"private_port_to":    0,
+				"description":        rule.description,
+			}
+			if privatePort != publicPort {
+				element["private_port_from"] = privatePort
+			}
+			result = append(result, element)
+		}
+		last, lastPublic, lastPrivate = rule, publicPort, privatePort
+	}
+	return result
+}
+
+func (s *VpcService) CreateDnatCommonCall(req map[string]interface{}) (callback ApiCall, err error) {
+	callback = ApiCall{
+		param:  &req,
+		action: "CreateDnat",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (*map[string]interface{}, error) {
+			conn := client.vpcconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			return conn.CreateDnat(call.param)
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) error {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return nil
+		},
+	}
+	return callback, err
+}
+
+func (s *VpcService) ModifyDnatCommonCall(req map[string]interface{}) (callback ApiCall, err error) {
+	callback = ApiCall{
+		param:  &req,
+		action: "ModifyDnat",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (*map[string]interface{}, error) {
+			conn := client.vpcconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			return conn.ModifyDnat(call.param)
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) error {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return nil
+		},
+	}
+	return callback, err
+}
+
+func (s *VpcService) RemoveDnatCommonCall(dnatId string) (callback ApiCall, err error) {
+	req := map[string]interface{}{
+		"DnatId": dnatId,
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "DeleteDnat",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (*map[string]interface{}, error) {
+			conn := client.vpcconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			return conn.DeleteDnat(call.param)
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			return resource.Retry(RetryTimeoutMinute, func() *resource.RetryError {
+				params := NewDescribeDnatsParams()
+				params.DnatIds = append(params.DnatIds, dnatId)
+				dnats, err := s.DescribeDnats(params)
+				if err != nil {
+					if notFoundError(err) {
+						return nil
+					}
+					return retryError(err)
+				}
+				if len(dnats) == 0 {
+					return nil
+				}
+				_, err = call.executeCall(d, client, call)
+				return retryError(err)
+			})
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) error {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return nil
+		},
+	}
+	return callback, err
+}
+
+// dnatRulesDeltaCalls turns the current rules of the nat ip into the desired ones, the stale rules are deleted first
+// so that a rule of protocol Any can be replaced by the rules of single ports and the other way round, the rules whose
+// public port is kept are modified in place so that the port is not left unmapped
+func (s *VpcService) dnatRulesDeltaCalls(natId string, natIp string, current []dnatRule, desired []dnatRule) (callbacks []ApiCall, err error) {
+	existing := make(map[string]dnatRule)
+	for _, rule := range current {
+		existing[rule.key()] = rule
+	}
+	wanted := make(map[string]bool)
+	var modifies, creates []ApiCall
+	for _, rule := range desired {
+		var callback ApiCall
+		wanted[rule.key()] = true
+		if old, ok := existing[rule.key()]; ok {
+			if !old.mapsAs(rule) {
+				callback, err = s.ModifyDnatCommonCall(map[string]interface{}{
+					"DnatId":           old.dnatId,
+					"NatId":            natId,
+					"PrivateIpAddress": rule.privateIpAddress,
+					"PrivatePort":      rule.privatePort,
+					"Description":      rule.description,
+				})
+				if err != nil {
+					return callbacks, err
+				}
+				modifies = append(modifies, callback)
+			}
+			continue
+		}
+		req := map[string]interface{}{
+			"NatId":            natId,
+			"NatIp":            natIp,
+			"DnatName":         strings.ToLower(rule.protocol) + "-" + strings.ToLower(rule.publicPort),
+			"IpProtocol":       rule.protocol,
+			"PublicPort":       rule.publicPort,
+			"PrivateIpAddress": rule.privateIpAddress,
+			"PrivatePort":      rule.privatePort,
+		}
+		if rule.description != "" {
+			req["Description"] = rule.description
+		}
+		callback, err = s.CreateDnatCommonCall(req)
+		if err != nil {
+			return callbacks, err
+		}
+		creates = append(creates, callback)
+	}
+	for _, rule := range current {
+		if wanted[rule.key()] {
+			continue
+		}
+		var callback ApiCall
+		callback, err = s.RemoveDnatCommonCall(rule.dnatId)
+		if err != nil {
+			return callbacks, err
+		}
+		callbacks = append(callbacks, callback)
+	}
+	callbacks = append(callbacks, modifies...)
+	callbacks = append(callbacks, creates...)
+	return callbacks, err
+}
+
+// ReadDnatRules returns the rules of the nat ip with a single DescribeDnats, one page per 200 rules
+func (s *VpcService) ReadDnatRules(natId string, natIp string) (rules []dnatRule, err error) {
+	param := NewDescribeDnatsParams()
+	param.Filter.NatId = natId
+	param.Filter.NatIp = natIp
+	dnats, err := s.DescribeDnats(param)
+	if err != nil {
+		// the nat ip without any dnat
+		if notFoundError(err) {
+			return rules, nil
+		}
+		return rules, err
+	}
+	return dnatRulesFromDnatSet(dnats, natIp), err
+}
+
+// dnatRulesConcurrency is the number of the dnat calls run at the same time
+const dnatRulesConcurrency = 8
+
+// dnatCallTarget describes the dnat of the call in the errors
+func dnatCallTarget(call ApiCall) string {
+	if port, ok := (*call.param)["PublicPort"]; ok {
+		return fmt.Sprintf("%v public port %v", (*call.param)["IpProtocol"], port)
+	}
+	return fmt.Sprintf("%v", (*call.param)["DnatId"])
+}
+
+// withDnatCallTarget names the dnat of the call in its error, after the callError of the call, if any, has handled
+// the error of the api
+func withDnatCallTarget(call ApiCall) ApiCall {
+	callError := call.callError
+	call.callError = func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+		err := baseErr
+		if callError != nil {
+			err = callError(d, client, call, baseErr)
+		}
+		if err != nil {
+			return fmt.Errorf("%s of %s: %s", call.action, dnatCallTarget(call), err)
+		}
+		return nil
+	}
+	return call
+}
+
+// runDnatRulesCalls runs the consecutive calls of the same action concurrently, at most dnatRulesConcurrency at a time,
+// and the calls of the next action after all of them finish. The calls of an action are all run even if some of them
+// fail, the failed ones are reported together and the calls of the later actions are not run.
+func (s *VpcService) runDnatRulesCalls(d *schema.ResourceData, calls []ApiCall) error {
+	for start := 0; start < len(calls); {
+		end := start
+		for end < len(calls) && calls[end].action == calls[start].action {
+			end++
+		}
+		apiProcess := NewApiProcess(context.Background(), d, s.client, true)
+		apiProcess.MulNum = dnatRulesConcurrency
+		for _, call := range calls[start:end] {
+			apiProcess.PutCalls(withDnatCallTarget(call))
+		}
+		if errs := apiProcess.ConRun(); len(errs) > 0 {
+			messages := make([]string, 0, len(errs))
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			sort.Strings(messages)
+			return fmt.Errorf("%d of %d %s calls failed, %s", len(errs), end-start, calls[start].action, strings.Join(messages, "; "))
+		}
+		start = end
+	}
+	return nil
+}
+
+// ApplyDnatRules makes the managed dnats of the nat ip exactly the rules, it reads the dnats of the nat ip again so
+// that the dnats changed out of band are reconciled as well. The managed dnats are the ones of the public ports of the
+// previous and the desired rules, or every dnat of the nat ip if exclusive. If some calls fail, the dnats applied
+// before are read into the state and the next apply retries the others.
+func (s *VpcService) ApplyDnatRules(d *schema.ResourceData) (err error) {
+	natId := d.Get("nat_id").(string)
+	natIp := d.Get("nat_ip").(string)
+	if d.Id() == "" {
+		d.SetId(natId + ":" + natIp)
+	}
+	desired, err := expandDnatRulesFromConfig(d.Get)
+	if err != nil {
+		return err
+	}
+	old, _ := d.GetChange("rule")
+	keys := dnatRulesManagedKeys(d, old, d.Get("rule"))
+	current, err := s.ReadDnatRules(natId, natIp)
+	if err != nil {
+		return err
+	}
+	calls, err := s.dnatRulesDeltaCalls(natId, natIp, filterDnatRules(current, keys), desired)
+	if err != nil {
+		return err
+	}
+	err = s.runDnatRulesCalls(d, calls)
+	if err != nil {
+		if readErr := s.setDnatRules(d, keys); readErr != nil {
+			return fmt.Errorf("%s, reading the applied dnats failed, %s", err, readErr)
+		}
+		return err
+	}
+	return err
+}
+
+func (s *VpcService) ReadAndSetDnatRules(d *schema.ResourceData) (err error) {
+	_, err = s.ReadNat(d, d.Get("nat_id").(string))
+	if err != nil {
+		if notFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	return s.setDnatRules(d, dnatRulesManagedKeys(d, d.Get("rule")))
+}
+
+// setDnatRules sets the rule to the dnats of the nat ip whose key is in keys, every dnat if keys is nil
+func (s *VpcService) setDnatRules(d *schema.ResourceData, keys map[string]bool) (err error) {
+	current, err := s.ReadDnatRules(d.Get("nat_id").(string), d.Get("nat_ip").(string))
+	if err != nil {
+		return err
+	}
+	var configured []interface{}
+	if elements, ok := d.Get("rule").(*schema.Set); ok {
+		configured = elements.List()
+	}
+	return d.Set("rule", flattenDnatRules(filterDnatRules(current, keys), configured))
+}
+
+func (s *VpcService) RemoveDnatRules(d *schema.ResourceData) (err error) {
+	natId := d.Get("nat_id").(string)
+	natIp := d.Get("nat_ip").(string)
+	current, err := s.ReadDnatRules(natId, natIp)
+	if err != nil {
+		return err
+	}
+	calls, err := s.dnatRulesDeltaCalls(natId, natIp, filterDnatRules(current, dnatRulesManagedKeys(d, d.Get("rule"))), nil)
+	if err != nil {
+		return err
+	}
+	return s.runDnatRulesCalls(d, calls)
+}
+
+func (s *VpcService) ReadNatIp(d *schema.ResourceData, natId string, natIpId string) (data map[string]interface{}, err error) {
+	nat, err := s.ReadNat(d, natId)
+	if err != nil {
+		return data, err
+	}
+	natIps, _ := nat["NatIpSet"].([]interface{})
+	for _, v := range natIps {
+		item, ok := v.(map[string]interface{})
+		if ok && item["NatIpId"] == natIpId {
+			item["NatId"] = natId
+			return item, err
+		}
+	}
+	return data, fmt.Errorf("nat ip %s not exist in nat %s ", natIpId, natId)
+}
+
+func (s *VpcService) ReadAndSetNatIp(d *schema.ResourceData, r *schema.Resource) error {
+	data, err := s.ReadNatIp(d, d.Get("nat_id").(string), d.Get("nat_ip_id").(string))
+	if err != nil {
+		if notFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	SdkResponseAutoResourceData(d, r, data, nil)
+	return nil
+}
+
+// natIpAddedId returns the id of the nat ip in natIpSet but not in the existing ids
+func natIpAddedId(existing []string, natIpSet []interface{}) (natIpId string, err error) {
+	for _, v := range natIpSet {
+		item, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := item["NatIpId"].(string)
+		if id == "" || stringSliceContains(existing, id) {
+			continue
+		}
+		if natIpId != "" {
+			return "", fmt.Errorf("more than one nat ip is added, %s and %s", natIpId, id)
+		}
+		natIpId = id
+	}
+	if natIpId == "" {
+		return "", fmt.Errorf("no nat ip is added")
+	}
+	return natIpId, nil
+}
+
+func (s *VpcService) CreateNatIp(d *schema.ResourceData, r *schema.Resource) error {
+	var apiProcess = NewApiProcess(context.Background(), d, s.client, true)
+	call, err := s.CreateNatIpCall(d, r)
+	if err != nil {
+		return err
+	}
+	apiProcess.PutCalls(call)
+	return apiProcess.Run()
+}
+
+// natIpIds returns the ids of the nat ips of the nat
+func natIpIds(nat map[string]interface{}) (ids []string) {
+	natIps, _ := nat["NatIpSet"].([]interface{})
+	for _, v := range natIps {
+		if item, ok := v.(map[string]interface{}); ok {
+			if id, ok := item["NatIpId"].(string); ok {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+func (s *VpcService) CreateNatIpCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	var natIpId string
+	req := map[string]interface{}{
+		"NatId":     d.Get("nat_id"),
+		"AddNumber": 1,
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "AddNatIp",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			// the response of AddNatIp may not contain the added nat ip, so it is found by the nat ips read before and
+			// after adding, and the nat ips are added one by one
+			natIpMutex.Lock()
+			defer natIpMutex.Unlock()
+			natId := d.Get("nat_id").(string)
+			nat, err := s.ReadNat(d, natId)
+			if err != nil {
+				return resp, err
+			}
+			existing := natIpIds(nat)
+			conn := client.vpcconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.AddNatIp(call.param)
+			if err != nil {
+				return resp, err
+			}
+			err = resource.Retry(RetryTimeoutMinute, func() *resource.RetryError {
+				nat, readErr := s.ReadNat(d, natId)
+				if readErr != nil {
+					return retryError(readErr)
+				}
+				natIps, _ := nat["NatIpSet"].([]interface{})
+				natIpId, readErr = natIpAddedId(existing, natIps)
+				if readErr == nil {
+					return nil
+				}
+				// the added nat ip may be listed a while later
+				if len(natIps) <= len(existing) {
+					return resource.RetryableError(readErr)
+				}
+				return resource.NonRetryableError(readErr)
+			})
+			if err != nil {
+				return resp, fmt.Errorf("the nat ip is added to the nat %s but not found, %s", natId, err)
+			}
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			err = d.Set("nat_ip_id", natIpId)
+			if err != nil {
+				return err
+			}
+			d.SetId(d.Get("nat_id").(string) + ":" + natIpId)
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *VpcService) RemoveNatIp(d *schema.ResourceData) error {
+	var apiProcess = NewApiProcess(context.Background(), d, s.client, true)
+	call, err := s.RemoveNatIpCall(d)
+	if err != nil {
+		return err
+	}
+	apiProcess.PutCalls(call)
+	return apiProcess.Run()
+}
+
+func (s *VpcService) RemoveNatIpCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	req := map[string]interface{}{
+		"NatId":   d.Get("nat_id"),
+		"NatIpId": d.Get("nat_ip_id"),
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "DeleteNatIp",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (*map[string]interface{}, error) {
+			conn := client.vpcconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			return conn.DeleteNatIp(call.param)
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			return resource.Retry(RetryTimeoutMinute, func() *resource.RetryError {
+				_, err := s.ReadNatIp(d, d.Get("nat_id").(string), d.Get("nat_ip_id").(string))
+				if err != nil {
+					if notFoundError(err) {
+						return nil
+					}
+					return retryError(err)
+				}
+
+				_, err = call.executeCall(d, client, call)
+
+				// the previous sub-order of the nat may be not finished
+				return retryError(err, "CreateOrderFailed")
+			})
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) error {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return nil
+		},
+	}
+	return callback, err
+}
+
+// snatEntrySource returns the action binding the source of the snat entry to the nat and the source id.
+// A subnet is bound by AssociateNat and a network interface by AssociateInstance.
+func snatEntrySource(d *schema.ResourceData) (action string, key string, sourceId string) {
+	if subnetId, ok := d.GetOk("subnet_id"); ok {
+		return "AssociateNat", "SubnetId", subnetId.(string)
+	}
+	return "AssociateInstance", "NetworkInterfaceId", d.Get("network_interface_id").(string)
+}
+
+// snatEntryFromNat finds the association of the subnet or the network interface in the nat, and resolves its NatIps,
+// the ids or the addresses of the nat ips, by the NatIpSet of the nat. The nat ip in natIpId is preferred when the
+// source leaves through more than one nat ip.
+func snatEntryFromNat(nat map[string]interface{}, subnetId, networkInterfaceId, natIpId string) (data map[string]interface{}, err error) {
+	setKey, sourceKey, sourceId := "AssociateNatSet", "SubnetId", subnetId
+	if sourceId == "" {
+		setKey, sourceKey, sourceId = "AssociateInstanceSet", "NetworkInterfaceId", networkInterfaceId
+	}
+	var natIps []interface{}
+	found := false
+	items, _ := nat[setKey].([]interface{})
+	for _, v := range items {
+		item, ok := v.(map[string]interface{})
+		if ok && item[sourceKey] == sourceId {
+			natIps, _ = item["NatIps"].([]interface{})
+			found = true
+			break
+		}
+	}
+	if !found {
+		return data, fmt.Errorf("snat entry of %s not exist in Nat %s ", sourceId, nat["NatId"])
+	}
+
+	natIpSet, _ := nat["NatIpSet"].([]interface{})
+	for _, v := range natIps {
+		for _, n := range natIpSet {
+			natIp, ok := n.(map[string]interface{})
+			if !ok || (natIp["NatIpId"] != v && natIp["NatIp"] != v) {
+				continue
+			}
+			if data == nil || natIp["NatIpId"] == natIpId {
+				data = map[string]interface{}{
+					sourceKey: sourceId,
+					"NatIpId": natIp["NatIpId"],
+					"NatIp":   natIp["NatIp"],
+				}
+			}
+		}
+	}
+	if data == nil {
+		data = map[string]interface{}{
+			sourceKey: sourceId,
+		}
+	}
+	data["NatId"] = nat["NatId"]
+	return data, err
+}
+
+func (s *VpcService) ReadSnat(d *schema.ResourceData) (data map[string]interface{}, err error) {
+	nat, err := s.ReadNat(d, d.Get("nat_id").(string))
+	if err != nil {
+		return data, err
+	}
+	return snatEntryFromNat(nat, d.Get("subnet_id").(string), d.Get("network_interface_id").(string), d.Get("nat_ip_id").(string))
+}
+
+func (s *VpcService) ReadAndSetSnat(d *schema.ResourceData, r *schema.Resource) error {
+	data, err := s.ReadSnat(d)
+	if err != nil {
+		if notFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	SdkResponseAutoResourceData(d, r, data, nil)
+	return nil
+}
+
+func (s *VpcService) CreateSnat(d *schema.ResourceData, r *schema.Resource) error {
+	var apiProcess = NewApiProcess(context.Background(), d, s.client, true)
+	call, err := s.CreateSnatCall(d, r)
+	if err != nil {
+		return err
+	}
+	apiProcess.PutCalls(call)
+	return apiProcess.Run()
+}
+
+func (s *VpcService) CreateSnatCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	action, key, sourceId := snatEntrySource(d)
+	req := map[string]interface{}{
+		"NatId":     d.Get("nat_id"),
+		key:         sourceId,
+		"NatIpId.1": d.Get("nat_ip_id"),
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: action,
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.vpcconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			if call.action == "AssociateNat" {
+				return conn.AssociateNat(call.param)
+			}
+			return conn.AssociateInstance(call.param)
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) error {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			d.SetId(d.Get("nat_id").(string) + ":" + sourceId)
+			return nil
+		},
+	}
+	return callback, err
+}
+
+func (s *VpcService) RemoveSnat(d *schema.ResourceData) error {
+	var apiProcess = NewApiProcess(context.Background(), d, s.client, true)
+	call, err := s.RemoveSnatCall(d)
+	if err != nil {
+		return err
+	}
+	apiProcess.PutCalls(call)
+	return apiProcess.Run()
+}
+
+func (s *VpcService) RemoveSnatCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	action, _, sourceId := snatEntrySource(d)
+	natId := d.Get("nat_id").(string)
+	if action == "AssociateNat" {
+		return s.RemoveNatAssociateCall(d, natId, sourceId)
+	}
+	return s.RemoveNatInstanceAssociateCall(d, natId, sourceId)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-ksyun/logger"
@@ -11,7 +12,11 @@ import (
 
 var (
 	// vpnV1Attribute the flowing fields are invalid when vpn1.0
-	vpnV2Attribute = []string{"ha_mode", "open_health_check", "local_peer_ip", "customer_peer_ip", "ike_version"}
+	vpnV2Attribute = []string{"ha_mode", "open_health_check", "local_peer_ip", "customer_peer_ip", "ike_version",
+		"bgp_local_asn", "bgp_peer_asn", "bgp_local_ip", "bgp_peer_ip", "bgp_advertised_cidr_blocks"}
+
+	// vpnBgpAttribute the flowing fields are valid only when vpn2.0 and tunnel type is RouteIpsec
+	vpnBgpAttribute = []string{"bgp_local_asn", "bgp_peer_asn", "bgp_local_ip", "bgp_peer_ip", "bgp_advertised_cidr_blocks"}
 
 	// vpnV1Attribute the flowing fields are invalid when vpn2.0
 	vpnV1Attribute = []string{"vpn_gre_ip", "ha_vpn_gre_ip", "customer_gre_ip", "ha_customer_gre_ip"}
@@ -202,3 +207,68 @@ func (v *VpnSrv) ReadAndSetVpnGatewayRoutes(d *schema.ResourceData, r *schema.Re
 		extra:       nil,
 	})
 }
+
+func (v *VpnSrv) ReadAndSetVpnTunnelBgpRoutes(d *schema.ResourceData, r *schema.Resource) error {
+	vpcService := VpcService{v.client}
+	vpnTunnelId := d.Get("vpn_tunnel_id").(string)
+	vpnTunnel, err := vpcService.ReadVpnTunnel(d, vpnTunnelId)
+	if err != nil {
+		return err
+	}
+
+	transform := map[string]SdkReqTransform{
+		"vpn_tunnel_id": {
+			Ignore: true,
+		},
+		"cidr_blocks": {
+			mapping: "cidr-block",
+			Type:    TransformWithFilter,
+		},
+	}
+	req, err := mergeDataSourcesReq(d, r, transform)
+	if err != nil {
+		return err
+	}
+	req["VpnGatewayId"] = vpnTunnel["VpnGatewayId"]
+	routes, err := v.DescribeVpnGatewayRoutes(req)
+	if err != nil {
+		return err
+	}
+	vpnTunnelName, _ := vpnTunnel["VpnTunnelName"].(string)
+
+	return mergeDataSourcesResp(d, r, ksyunDataSource{
+		collection:  vpnTunnelBgpRoutes(routes, vpnTunnelId, vpnTunnelName),
+		idFiled:     "VpnGatewayRouteId",
+		targetField: "routes",
+		extra: map[string]SdkResponseMapping{
+			"VpnGatewayRouteId": {
+				Field:    "id",
+				KeepAuto: true,
+			},
+		},
+	})
+}
+
+// vpnTunnelBgpRoutes picks the routes learned by BGP over the vpn tunnel from the routes of the vpn gateway,
+// the next hop of a route is matched by the tunnel name if the route does not return the next hop id.
+func vpnTunnelBgpRoutes(routes []interface{}, vpnTunnelId string, vpnTunnelName string) (data []interface{}) {
+	for _, v := range routes {
+		route, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		routeType, _ := route["RouteType"].(string)
+		if !strings.EqualFold(routeType, "bgp") {
+			continue
+		}
+		if nextHopId, ok := route["NextHopInstanceId"].(string); ok && nextHopId != "" {
+			if nextHopId != vpnTunnelId {
+				continue
+			}
+		} else if vpnTunnelName == "" || route["NextHopInstanceName"] != vpnTunnelName {
+			continue
+		}
+		data = append(data, route)
+	}
+	return data
+}
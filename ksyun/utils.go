@@ -4,9 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"os"
 	"os/user"
 	"path/filepath"
 	"reflect"
@@ -67,24 +65,20 @@ func hashStringArray(arr []string) string {
 	return fmt.Sprintf("%d", hashcode.String(buf.String()))
 }
 
-func writeToFile(filePath string, data interface{}) error {
+func writeToFile(filePath string, data interface{}, options ...outputOptions) error {
 	absPath, err := getAbsPath(filePath)
 	if err != nil {
 		return err
 	}
-	os.Remove(absPath)
-	var bs []byte
-	switch data := data.(type) {
-	case string:
-		bs = []byte(data)
-	default:
-		bs, err = json.MarshalIndent(data, "", "\t")
-		if err != nil {
-			return fmt.Errorf("MarshalIndent data %#v and got an error: %#v", data, err)
-		}
+	var option outputOptions
+	if len(options) > 0 {
+		option = options[0]
 	}
-
-	return ioutil.WriteFile(absPath, bs, 0422)
+	bs, err := marshalOutput(data, option)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(absPath, bs, 0422)
 }
 
 func getAbsPath(filePath string) (string, error) {
@@ -475,6 +469,9 @@ func SdkRequestAutoMapping(d *schema.ResourceData, resource *schema.Resource, is
 				} else {
 					count, err = requestCreateMapping(d, k, v, count, nil, &req, false)
 				}
+			} else if k == "region" {
+				// the region of a regional resource or data source selects the client, it is not a request parameter
+				continue
 			} else {
 				if isUpdate {
 					count, err = requestUpdateMapping(d, k, SdkReqTransform{}, count, extraMapping, &req)
@@ -777,6 +774,13 @@ func SdkSliceMapping(d *schema.ResourceData, result interface{}, sdkSliceData Sd
 		}
 
 		if d != nil && sdkSliceData.TargetName != "" {
+			if _, ok := d.GetOk("filter"); ok {
+				ids, data, err = filterDataSourceItems(d, ids, data)
+				if err != nil {
+					return nil, nil, err
+				}
+				length = len(data)
+			}
 			d.SetId(hashStringArray(ids))
 			_ = d.Set("total_count", length)
 			err = d.Set(sdkSliceData.TargetName, data)
@@ -784,7 +788,7 @@ func SdkSliceMapping(d *schema.ResourceData, result interface{}, sdkSliceData Sd
 				return nil, nil, err
 			}
 			if outputFile, ok := d.GetOk("output_file"); ok && outputFile.(string) != "" {
-				err = writeToFile(outputFile.(string), data)
+				err = writeToFile(outputFile.(string), data, dataSourceOutputOptions(d))
 				if err != nil {
 					return nil, nil, err
 				}
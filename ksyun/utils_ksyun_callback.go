@@ -148,7 +148,8 @@ func (a *ApiProcess) SetClient(client *KsyunClient) {
 	a.client = client
 }
 
-// NewApiProcess returns a ApiProcess, but now it doesn't support concurrency
+// NewApiProcess returns a ApiProcess, Run processes the calls in order and ConRun processes them concurrently,
+// MulNum calls at a time
 func NewApiProcess(ctx context.Context, d *schema.ResourceData, client *KsyunClient, dryRun bool) ApiProcess {
 	mulNum := 1
 
@@ -166,25 +167,41 @@ func NewApiProcess(ctx context.Context, d *schema.ResourceData, client *KsyunCli
 	return p
 }
 
-// ConRun will process ApiProcess concurrently
+// ConRun will process ApiProcess concurrently, at most MulNum calls at a time. It waits for the started calls
+// and returns the errors of the failed ones.
 func (a *ApiProcess) ConRun() []error {
+	defer a.Clean()
+	if a.MulNum < 1 {
+		a.MulNum = 1
+	}
+	a.errors = nil
+	a.errCh = make(chan error, a.MulNum)
+	a.concurrentCh = make(chan struct{}, a.MulNum)
+
 	// receive errs
+	received := make(chan struct{})
 	go func() {
+		defer close(received)
 		for callErr := range a.errCh {
-			a.errors = append(a.errors, callErr)
+			if callErr != nil {
+				a.errors = append(a.errors, callErr)
+			}
 		}
 	}()
 
 	// concurrency api call run
+	stopped := false
 	for _, call := range a.apiProcessQueue {
 		select {
 		case <-a.Ctx.Done():
-			a.errors = append(a.errors, fmt.Errorf("stop api call early"))
-			return a.errors
+			stopped = true
 		case <-a.earlyStop:
-			return a.errors
+			stopped = true
 		default:
 		}
+		if stopped {
+			break
+		}
 
 		a.wg.Add(1)
 		a.concurrentCh <- struct{}{}
@@ -193,11 +210,15 @@ func (a *ApiProcess) ConRun() []error {
 				a.wg.Done()
 				<-a.concurrentCh
 			}()
-			callErr := call.RightNow(a.d, a.client, a.DryRun)
-			a.errCh <- callErr
+			a.errCh <- call.RightNow(a.d, a.client, a.DryRun)
 		}(call)
 	}
 	a.wg.Wait()
+	close(a.errCh)
+	<-received
+	if stopped && a.Ctx.Err() != nil {
+		a.errors = append(a.errors, fmt.Errorf("stop api call early"))
+	}
 	return a.errors
 }
 
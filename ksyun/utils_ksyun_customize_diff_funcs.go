@@ -108,3 +108,77 @@ func bareMetalCustomizeDiff(d *schema.ResourceDiff, meta interface{}) (err error
 	}
 	return err
 }
+
+// ipv6CidrBlockCustomizeDiff replaces the vpc or the subnet when provided_ipv6_cidr_block is turned off,
+// the IPv6 CIDR block can be assigned in place but not released
+func ipv6CidrBlockCustomizeDiff(d *schema.ResourceDiff, meta interface{}) (err error) {
+	if d.Id() != "" && d.HasChange("provided_ipv6_cidr_block") && !d.Get("provided_ipv6_cidr_block").(bool) {
+		return d.ForceNew("provided_ipv6_cidr_block")
+	}
+	return err
+}
+
+// subnetCustomizeDiff keeps cidr_block and cidr_prefix_length consistent, a literal cidr_block gives the prefix
+// length, and a new prefix length plans the lowest free cidr_block of the vpc, which is known after apply only
+// when the vpc is not created yet
+func subnetCustomizeDiff(d *schema.ResourceDiff, meta interface{}) (err error) {
+	if err = ipv6CidrBlockCustomizeDiff(d, meta); err != nil {
+		return err
+	}
+	if d.HasChange("cidr_block") && d.NewValueKnown("cidr_block") {
+		if cidr := d.Get("cidr_block").(string); cidr != "" {
+			return d.SetNew("cidr_prefix_length", cidrPrefixLength(cidr))
+		}
+	}
+	if d.Id() == "" && d.Get("cidr_block").(string) == "" || d.Id() != "" && d.HasChange("cidr_prefix_length") {
+		client, ok := meta.(*KsyunClient)
+		prefixLength := d.Get("cidr_prefix_length").(int)
+		if !ok || !d.NewValueKnown("vpc_id") || !d.NewValueKnown("cidr_prefix_length") || prefixLength == 0 {
+			return d.SetNewComputed("cidr_block")
+		}
+		vpcService := VpcService{client}
+		cidr, err := vpcService.PlanSubnetCidrBlock(d.Get("vpc_id").(string), prefixLength)
+		if err != nil {
+			return err
+		}
+		return d.SetNew("cidr_block", cidr)
+	}
+	return err
+}
+
+// networkAclRulesCustomizeDiff numbers the ingress and egress entries and rejects the duplicated or shadowed ones
+// before apply, the entries of the previous state keep their numbers
+func networkAclRulesCustomizeDiff(d *schema.ResourceDiff, meta interface{}) (err error) {
+	_, err = expandNetworkAclRulesFromConfig(d.Get, networkAclRulesFromState(d.GetChange))
+	return err
+}
+
+// dnatRulesCustomizeDiff expands the port ranges of the dnat rules and rejects the public ports mapped twice before
+// apply
+func dnatRulesCustomizeDiff(d *schema.ResourceDiff, meta interface{}) (err error) {
+	// the unknown ports are read as 0, so the rules are validated on apply
+	if !d.NewValueKnown("rule") {
+		return err
+	}
+	_, err = expandDnatRulesFromConfig(d.Get)
+	return err
+}
+
+// vpcFlowLogCustomizeDiff checks that the klog project and log pool of the flow log exist before apply
+func vpcFlowLogCustomizeDiff(d *schema.ResourceDiff, meta interface{}) (err error) {
+	if d.Id() != "" && !d.HasChange("project_name") && !d.HasChange("log_pool_name") {
+		return err
+	}
+	if !d.NewValueKnown("project_name") || !d.NewValueKnown("log_pool_name") {
+		return err
+	}
+	klogService := KlogProjectService{meta.(*KsyunClient)}
+	return klogService.CheckLogPool(d.Get("project_name").(string), d.Get("log_pool_name").(string))
+}
+
+func cenBandwidthPackageCustomizeDiff(d *schema.ResourceDiff, meta interface{}) (err error) {
+	if !d.NewValueKnown("package_band_width") || !d.NewValueKnown("region_bandwidths") {
+		return err
+	}
+	return validateCenRegionBandwidths(d.Get("package_band_width").(int), d.Get("region_bandwidths").(*schema.Set).List())
+}
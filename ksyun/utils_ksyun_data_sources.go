@@ -1,8 +1,12 @@
 package ksyun
 
 import (
+	"fmt"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-ksyun/logger"
@@ -33,8 +37,16 @@ func mergeDataSourcesReq(d *schema.ResourceData, r *schema.Resource, transform m
 			Ignore: true,
 		}
 	}
-	return SdkRequestAutoMapping(d, r, false, transform, nil,
+	transform["filter"] = SdkReqTransform{
+		Ignore: true,
+	}
+	req, err = SdkRequestAutoMapping(d, r, false, transform, nil,
 		SdkReqParameter{false})
+	if err != nil {
+		return req, err
+	}
+	err = mergeDataSourcesFilterReq(d, transform, req)
+	return req, err
 }
 
 func mergeDataSourcesResp(d *schema.ResourceData, r *schema.Resource, dataSource ksyunDataSource, plugIns ...matchPlugin) (err error) {
@@ -178,3 +190,396 @@ func mergeDataSourcesRespIdInObj(d *schema.ResourceData, r *schema.Resource, dat
 	})
 	return err
 }
+
+// dataSourceFiltersSchema is the generic `filter` block of the list data sources
+func dataSourceFiltersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Description: "Generic filters applied to the results, a result is kept when it matches all the filters. " +
+			"Filters on the arguments the API can filter on are also sent to the API.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+					Description: "The attribute of the results to filter on, e.g. `vpc_id`. " +
+						"Nested attributes are separated by dots, e.g. `tags.env`.",
+				},
+				"values": {
+					Type:        schema.TypeList,
+					Required:    true,
+					MinItems:    1,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "The accepted values, a filter matches when any of them matches. `*` and `?` can be used as wildcards.",
+				},
+			},
+		},
+	}
+}
+
+// addDataSourceFilters adds the `filter` block to a data source returning a list of objects
+func addDataSourceFilters(r *schema.Resource) {
+	if _, ok := r.Schema["output_file"]; !ok {
+		return
+	}
+	if _, ok := r.Schema["filter"]; ok {
+		return
+	}
+	for _, v := range r.Schema {
+		if _, ok := v.Elem.(*schema.Resource); ok && v.Computed && (v.Type == schema.TypeList || v.Type == schema.TypeSet) {
+			r.Schema["filter"] = dataSourceFiltersSchema()
+			return
+		}
+	}
+}
+
+type dataSourceFilter struct {
+	name   string
+	path   []string
+	values []string
+	match  []*regexp.Regexp
+}
+
+func readDataSourceFilters(d *schema.ResourceData) (filters []dataSourceFilter, err error) {
+	if d == nil {
+		return filters, err
+	}
+	set, ok := d.Get("filter").(*schema.Set)
+	if !ok {
+		return filters, err
+	}
+	for _, raw := range set.List() {
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// the api spelling of a filter name (vpc-id) is accepted as well
+		name := strings.Replace(m["name"].(string), "-", "_", -1)
+		filter := dataSourceFilter{
+			name: name,
+			path: strings.Split(name, "."),
+		}
+		for _, v := range m["values"].([]interface{}) {
+			value, _ := v.(string)
+			match, err := wildcardRegexp(value)
+			if err != nil {
+				return filters, fmt.Errorf("filter %s has an invalid value %q: %s", name, value, err)
+			}
+			filter.values = append(filter.values, value)
+			filter.match = append(filter.match, match)
+		}
+		filters = append(filters, filter)
+	}
+	// keep the api request stable
+	sort.Slice(filters, func(i, j int) bool {
+		return filters[i].name < filters[j].name
+	})
+	return filters, err
+}
+
+func wildcardRegexp(value string) (*regexp.Regexp, error) {
+	pattern := regexp.QuoteMeta(value)
+	pattern = strings.Replace(pattern, `\*`, ".*", -1)
+	pattern = strings.Replace(pattern, `\?`, ".", -1)
+	return regexp.Compile("^" + pattern + "$")
+}
+
+func (f dataSourceFilter) hasWildcard() bool {
+	for _, v := range f.values {
+		if strings.ContainsAny(v, "*?") {
+			return true
+		}
+	}
+	return false
+}
+
+// matches checks the filter against a flattened result, lists are matched when any of their elements matches
+func (f dataSourceFilter) matches(item map[string]interface{}) bool {
+	for _, v := range dataSourceFilterValues(item, f.path) {
+		for _, match := range f.match {
+			if match.MatchString(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func dataSourceFilterValues(v interface{}, path []string) (values []string) {
+	switch v := v.(type) {
+	case nil:
+		return values
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return values
+		}
+		return dataSourceFilterValues(v[path[0]], path[1:])
+	case []map[string]interface{}:
+		for _, e := range v {
+			values = append(values, dataSourceFilterValues(e, path)...)
+		}
+		return values
+	case []interface{}:
+		for _, e := range v {
+			values = append(values, dataSourceFilterValues(e, path)...)
+		}
+		return values
+	case []string:
+		if len(path) > 0 {
+			return values
+		}
+		return v
+	case *schema.Set:
+		return dataSourceFilterValues(v.List(), path)
+	}
+	if len(path) > 0 {
+		return values
+	}
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	case float32:
+		return []string{strconv.FormatFloat(float64(v), 'f', -1, 32)}
+	}
+	return []string{fmt.Sprintf("%v", v)}
+}
+
+// mergeDataSourcesFilterReq sends the filters to the api when it supports Filter.N, only the filters
+// matching one of the api filters already used by the data source and without wildcard are sent.
+// Every filter is still evaluated on the results, see filterDataSourceItems.
+func mergeDataSourcesFilterReq(d *schema.ResourceData, transform map[string]SdkReqTransform, req map[string]interface{}) error {
+	filters, err := readDataSourceFilters(d)
+	if err != nil || len(filters) == 0 {
+		return err
+	}
+
+	apiFilters := make(map[string]bool)
+	for k, t := range transform {
+		if t.Type != TransformWithFilter {
+			continue
+		}
+		if strings.TrimSpace(t.mapping) == "" {
+			apiFilters[Downline2Filter(k)] = true
+		} else {
+			apiFilters[t.mapping] = true
+		}
+	}
+	if len(apiFilters) == 0 {
+		return nil
+	}
+
+	index := 1
+	for k := range req {
+		var i int
+		if n, _ := fmt.Sscanf(k, "Filter.%d.", &i); n == 1 && i >= index {
+			index = i + 1
+		}
+	}
+	for _, filter := range filters {
+		name := Downline2Filter(filter.name)
+		if !apiFilters[name] || filter.hasWildcard() {
+			continue
+		}
+		req["Filter."+strconv.Itoa(index)+".Name"] = name
+		for i, v := range filter.values {
+			req["Filter."+strconv.Itoa(index)+".Value."+strconv.Itoa(i+1)] = v
+		}
+		index++
+	}
+	return nil
+}
+
+// filterDataSourceItems evaluates the `filter` blocks on the flattened results of a data source,
+// ids are kept aligned with the results when both have the same length
+func filterDataSourceItems(d *schema.ResourceData, ids []string, data []map[string]interface{}) ([]string, []map[string]interface{}, error) {
+	filters, err := readDataSourceFilters(d)
+	if err != nil || len(filters) == 0 {
+		return ids, data, err
+	}
+
+	withIds := len(ids) == len(data)
+	var (
+		resultIds  []string
+		resultData []map[string]interface{}
+	)
+	for i, item := range data {
+		matched := true
+		for _, filter := range filters {
+			if !filter.matches(item) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		resultData = append(resultData, item)
+		if withIds {
+			resultIds = append(resultIds, ids[i])
+		}
+	}
+	if !withIds {
+		resultIds = ids
+	}
+	if resultIds == nil {
+		resultIds = []string{}
+	}
+	if resultData == nil {
+		resultData = []map[string]interface{}{}
+	}
+	return resultIds, resultData, nil
+}
+
+// singularDataSource describes a data source returning exactly one object of a list data source
+type singularDataSource struct {
+	// plural builds the list data source which is read to find the object
+	plural      func() *schema.Resource
+	targetField string
+	idField     string
+	// arguments maps the attributes that can be used as arguments to the arguments of the list data source
+	arguments map[string]string
+	// mostRecent is the creation time attribute, when set the `most_recent` argument is added
+	mostRecent string
+}
+
+// newSingularDataSource builds a data source with the attributes of one object of a list data source,
+// the read fails unless exactly one object matches the arguments
+func newSingularDataSource(s singularDataSource) *schema.Resource {
+	plural := s.plural()
+	elem := plural.Schema[s.targetField].Elem.(*schema.Resource)
+	m := make(map[string]*schema.Schema)
+	for k, v := range elem.Schema {
+		// the id is set by the data source itself
+		if k == "id" {
+			continue
+		}
+		attribute := *v
+		attribute.Optional = false
+		attribute.Required = false
+		attribute.Computed = true
+		if _, ok := s.arguments[k]; ok {
+			attribute.Optional = true
+		}
+		m[k] = &attribute
+	}
+	if v, ok := plural.Schema["name_regex"]; ok {
+		m["name_regex"] = v
+	}
+	m["filter"] = dataSourceFiltersSchema()
+	m["output_file"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "File name where to save data source results (after running `terraform plan`).",
+	}
+	if s.mostRecent != "" {
+		m["most_recent"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "If more than one result is returned, use the most recent one.",
+		}
+	}
+
+	return &schema.Resource{
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return s.read(d, meta)
+		},
+		Schema: m,
+	}
+}
+
+func (s singularDataSource) read(d *schema.ResourceData, meta interface{}) (err error) {
+	plural := s.plural()
+	addDataSourceFilters(plural)
+	pd := plural.Data(nil)
+
+	for k, target := range s.arguments {
+		v, ok := d.GetOk(k)
+		if !ok {
+			continue
+		}
+		t := plural.Schema[target]
+		if t.Type == schema.TypeSet || t.Type == schema.TypeList {
+			if e, ok := t.Elem.(*schema.Schema); ok && e.Type == schema.TypeString {
+				v = fmt.Sprintf("%v", v)
+			}
+			v = []interface{}{v}
+		}
+		if err = pd.Set(target, v); err != nil {
+			return err
+		}
+	}
+	for _, k := range []string{"name_regex", "filter"} {
+		if v, ok := d.GetOk(k); ok {
+			if err = pd.Set(k, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err = plural.Read(pd, meta); err != nil {
+		return err
+	}
+	items, _ := pd.Get(s.targetField).([]interface{})
+
+	if len(items) > 1 && s.mostRecent != "" && d.Get("most_recent").(bool) {
+		var latest int
+		var latestTime time.Time
+		for i, item := range items {
+			t, err := parseCreationTime(fmt.Sprintf("%v", item.(map[string]interface{})[s.mostRecent]))
+			if err != nil {
+				return fmt.Errorf("unable to select the most recent %s: %s", s.targetField, err)
+			}
+			if i == 0 || t.After(latestTime) {
+				latest, latestTime = i, t
+			}
+		}
+		items = items[latest : latest+1]
+	}
+	switch {
+	case len(items) == 0:
+		return fmt.Errorf("no %s match the given arguments", s.targetField)
+	case len(items) > 1:
+		return fmt.Errorf("%d %s match the given arguments, change the arguments to select exactly one", len(items), s.targetField)
+	}
+
+	item := items[0].(map[string]interface{})
+	d.SetId(fmt.Sprintf("%v", item[s.idField]))
+	for k, v := range item {
+		if k == "id" {
+			continue
+		}
+		if err = d.Set(k, v); err != nil {
+			return fmt.Errorf("error set %s: %s", k, err)
+		}
+	}
+	if outputFile, ok := d.GetOk("output_file"); ok && outputFile.(string) != "" {
+		return writeToFile(outputFile.(string), item, dataSourceOutputOptions(d))
+	}
+	return nil
+}
+
+// creationTimeLayouts are the layouts of the creation times returned by the APIs, the ones without
+// a zone are in the time of Beijing
+var creationTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// parseCreationTime parses a creation time of any of creationTimeLayouts so that the times of
+// different layouts and zones are compared by the instant they represent
+func parseCreationTime(value string) (time.Time, error) {
+	beijing := time.FixedZone("CST", 8*3600)
+	for _, layout := range creationTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, beijing); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unknown time format %q", value)
+}
@@ -0,0 +1,170 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDataSourceFilterData(t *testing.T, filters []interface{}) *schema.ResourceData {
+	r := dataSourceKsyunSubnets()
+	addDataSourceFilters(r)
+	return schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"vpc_ids": []interface{}{"vpc-1"},
+		"filter":  filters,
+	})
+}
+
+func TestFilterDataSourceItems(t *testing.T) {
+	d := testDataSourceFilterData(t, []interface{}{
+		map[string]interface{}{"name": "subnet_name", "values": []interface{}{"web-*", "db"}},
+		map[string]interface{}{"name": "tags.env", "values": []interface{}{"prod"}},
+	})
+	data := []map[string]interface{}{
+		{"subnet_name": "web-1", "tags": map[string]interface{}{"env": "prod"}},
+		{"subnet_name": "web-2", "tags": map[string]interface{}{"env": "test"}},
+		{"subnet_name": "db", "tags": map[string]interface{}{"env": "prod"}},
+		{"subnet_name": "cache", "tags": map[string]interface{}{"env": "prod"}},
+		{"subnet_name": "web-3"},
+	}
+	ids, result, err := filterDataSourceItems(d, []string{"s1", "s2", "s3", "s4", "s5"}, data)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"s1", "s3"}, ids)
+	assert.Equal(t, 2, len(result))
+}
+
+func TestDataSourceFilterValues(t *testing.T) {
+	item := map[string]interface{}{
+		"port":     float64(8080),
+		"enabled":  true,
+		"ip_set":   []interface{}{map[string]interface{}{"ip": "10.0.0.1"}, map[string]interface{}{"ip": "10.0.0.2"}},
+		"key_list": []interface{}{"a", "b"},
+	}
+	assert.Equal(t, []string{"8080"}, dataSourceFilterValues(item, []string{"port"}))
+	assert.Equal(t, []string{"true"}, dataSourceFilterValues(item, []string{"enabled"}))
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, dataSourceFilterValues(item, []string{"ip_set", "ip"}))
+	assert.Equal(t, []string{"a", "b"}, dataSourceFilterValues(item, []string{"key_list"}))
+	assert.Empty(t, dataSourceFilterValues(item, []string{"missing"}))
+}
+
+func TestMergeDataSourcesFilterReq(t *testing.T) {
+	d := testDataSourceFilterData(t, []interface{}{
+		map[string]interface{}{"name": "subnet_type", "values": []interface{}{"Reserve"}},
+		map[string]interface{}{"name": "nat-id", "values": []interface{}{"nat-*"}},
+		map[string]interface{}{"name": "subnet_name", "values": []interface{}{"web"}},
+	})
+	req, err := mergeDataSourcesReq(d, dataSourceKsyunSubnets(), map[string]SdkReqTransform{
+		"vpc_ids":      {mapping: "vpc-id", Type: TransformWithFilter},
+		"subnet_types": {mapping: "subnet-type", Type: TransformWithFilter},
+		"nat_ids":      {mapping: "nat-id", Type: TransformWithFilter},
+	})
+	assert.NoError(t, err)
+	// subnet_type is an api filter, nat-id has a wildcard and subnet_name is not an api filter
+	assert.Equal(t, map[string]interface{}{
+		"Filter.1.Name":    "vpc-id",
+		"Filter.1.Value.1": "vpc-1",
+		"Filter.2.Name":    "subnet-type",
+		"Filter.2.Value.1": "Reserve",
+	}, req)
+}
+
+func TestProviderDataSourceFilters(t *testing.T) {
+	p := Provider().(*schema.Provider)
+	assert.Contains(t, p.DataSourcesMap["ksyun_vpcs"].Schema, "filter")
+	assert.Contains(t, p.DataSourcesMap["ksyun_instances"].Schema, "filter")
+	// the results are plain strings, there is nothing to filter on
+	assert.NotContains(t, p.DataSourcesMap["ksyun_subnet_available_addresses"].Schema, "filter")
+}
+
+func testSingularDataSource(items []interface{}, requests *[]map[string]interface{}) singularDataSource {
+	plural := func() *schema.Resource {
+		return &schema.Resource{
+			Read: func(d *schema.ResourceData, meta interface{}) error {
+				*requests = append(*requests, map[string]interface{}{
+					"ids":        d.Get("ids").(*schema.Set).List(),
+					"name_regex": d.Get("name_regex"),
+				})
+				return d.Set("images", items)
+			},
+			Schema: map[string]*schema.Schema{
+				"ids":         {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+				"name_regex":  {Type: schema.TypeString, Optional: true},
+				"output_file": {Type: schema.TypeString, Optional: true},
+				"images": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"image_id":      {Type: schema.TypeString, Computed: true},
+							"name":          {Type: schema.TypeString, Computed: true},
+							"creation_date": {Type: schema.TypeString, Computed: true},
+						},
+					},
+				},
+			},
+		}
+	}
+	return singularDataSource{
+		plural:      plural,
+		targetField: "images",
+		idField:     "image_id",
+		arguments: map[string]string{
+			"image_id": "ids",
+		},
+		mostRecent: "creation_date",
+	}
+}
+
+func TestSingularDataSource(t *testing.T) {
+	items := []interface{}{
+		map[string]interface{}{"image_id": "img-1", "name": "centos", "creation_date": "2023-01-02 10:00:00"},
+		map[string]interface{}{"image_id": "img-2", "name": "centos", "creation_date": "2024-05-01 10:00:00"},
+	}
+	var requests []map[string]interface{}
+
+	r := newSingularDataSource(testSingularDataSource(items, &requests))
+	assert.NoError(t, r.InternalValidate(nil, false))
+	assert.True(t, r.Schema["image_id"].Optional)
+	assert.False(t, r.Schema["name"].Optional)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"image_id": "img-1", "name_regex": "^centos"})
+	err := r.Read(d, nil)
+	assert.EqualError(t, err, "2 images match the given arguments, change the arguments to select exactly one")
+	assert.Equal(t, []map[string]interface{}{{"ids": []interface{}{"img-1"}, "name_regex": "^centos"}}, requests)
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"most_recent": true})
+	assert.NoError(t, r.Read(d, nil))
+	assert.Equal(t, "img-2", d.Id())
+	assert.Equal(t, "2024-05-01 10:00:00", d.Get("creation_date"))
+
+	r = newSingularDataSource(testSingularDataSource(items[:1], &requests))
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	assert.NoError(t, r.Read(d, nil))
+	assert.Equal(t, "img-1", d.Id())
+	assert.Equal(t, "centos", d.Get("name"))
+
+	r = newSingularDataSource(testSingularDataSource(nil, &requests))
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	assert.EqualError(t, r.Read(d, nil), "no images match the given arguments")
+}
+
+func TestSingularDataSourceMostRecentTimes(t *testing.T) {
+	items := []interface{}{
+		map[string]interface{}{"image_id": "img-1", "name": "centos", "creation_date": "2024-05-01T10:00:00+08:00"},
+		// 2024-05-01 11:00:00 in the time of Beijing, later than img-1 though smaller as a string
+		map[string]interface{}{"image_id": "img-2", "name": "centos", "creation_date": "2024-05-01T03:00:00Z"},
+		map[string]interface{}{"image_id": "img-3", "name": "centos", "creation_date": "2024-04-30"},
+	}
+	var requests []map[string]interface{}
+
+	r := newSingularDataSource(testSingularDataSource(items, &requests))
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"most_recent": true})
+	assert.NoError(t, r.Read(d, nil))
+	assert.Equal(t, "img-2", d.Id())
+
+	items = append(items, map[string]interface{}{"image_id": "img-4", "name": "centos", "creation_date": "yesterday"})
+	r = newSingularDataSource(testSingularDataSource(items, &requests))
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"most_recent": true})
+	assert.Error(t, r.Read(d, nil))
+}
@@ -1,6 +1,8 @@
 package ksyun
 
 import (
+	"net"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -120,7 +122,7 @@ func kcsSecurityGroupDiffSuppressFunc(k, old, new string, d *schema.ResourceData
 }
 
 func networkAclEntryDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
-	if d.Get("protocol") != "icmp" && (k == "icmp_type" || k == "icmp_code") {
+	if !isIcmpProtocol(d.Get("protocol")) && (k == "icmp_type" || k == "icmp_code") {
 		return true
 	}
 	if d.Get("protocol") != "tcp" && d.Get("protocol") != "udp" && (k == "port_range_from" || k == "port_range_to") {
@@ -129,8 +131,42 @@ func networkAclEntryDiffSuppressFunc(k, old, new string, d *schema.ResourceData)
 	return false
 }
 
+// networkAclRuleNumberDiffSuppressFunc ignores the changes of the rule_number of an element of ingress or egress
+// which keep its effective rule number, such as setting the rule number it is numbered with automatically or
+// leaving the number it keeps unset
+func networkAclRuleNumberDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	parts := strings.Split(k, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	index, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	direction := networkAclRuleDirection(parts[0])
+	oldStep, newStep := d.GetChange("rule_number_step")
+	oldEntries, newEntries := d.GetChange(parts[0])
+	oldNumbers := networkAclRuleNumbers(direction, oldEntries.([]interface{}), oldStep.(int), nil)
+	newNumbers := networkAclRuleNumbers(direction, newEntries.([]interface{}), newStep.(int), networkAclRulesFromState(d.GetChange))
+	return index < len(oldNumbers) && index < len(newNumbers) && oldNumbers[index] == newNumbers[index]
+}
+
+// networkAclRuleDiffSuppressFunc is networkAclEntryDiffSuppressFunc for the elements of ingress and egress
+func networkAclRuleDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	prefix := k[:strings.LastIndex(k, ".")+1]
+	protocol := d.Get(prefix + "protocol")
+	field := strings.TrimPrefix(k, prefix)
+	if !isIcmpProtocol(protocol) && (field == "icmp_type" || field == "icmp_code") {
+		return true
+	}
+	if protocol != "tcp" && protocol != "udp" && (field == "port_range_from" || field == "port_range_to") {
+		return true
+	}
+	return false
+}
+
 func securityGroupEntryDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
-	if d.Get("protocol") != "icmp" && (k == "icmp_type" || k == "icmp_code") {
+	if !isIcmpProtocol(d.Get("protocol")) && (k == "icmp_type" || k == "icmp_code") {
 		return true
 	}
 	if d.Get("protocol") != "tcp" && d.Get("protocol") != "udp" && (k == "port_range_from" || k == "port_range_to") {
@@ -509,3 +545,20 @@ func pdnsZoneRecordDiffSuppressFunc(k, old, new string, d *schema.ResourceData)
 	}
 	return false
 }
+
+// cidrBlockDiffSuppressFunc ignores the notation differences of the same CIDR block, such as an IPv6 CIDR block
+// written in upper case or not compressed
+func cidrBlockDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+	oldIp, oldNet, err := net.ParseCIDR(old)
+	if err != nil {
+		return false
+	}
+	newIp, newNet, err := net.ParseCIDR(new)
+	if err != nil {
+		return false
+	}
+	return oldIp.Equal(newIp) && oldNet.String() == newNet.String()
+}
@@ -126,7 +126,7 @@ func securityGroupEntryHashBase(v interface{}, isHump bool) (buf bytes.Buffer) {
 }
 
 func generateEntryField(protocol string) (fields []string) {
-	if protocol == "icmp" {
+	if isIcmpProtocol(protocol) {
 		fields = []string{
 			"icmp_type",
 			"icmp_code",
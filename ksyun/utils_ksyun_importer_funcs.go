@@ -204,7 +204,7 @@ func importSecurityGroupEntry(d *schema.ResourceData, meta interface{}) ([]*sche
 		if len(items) != 6 {
 			return []*schema.ResourceData{d}, fmt.Errorf("import id must split with ':' and size must  5")
 		}
-		if protocol == "icmp" {
+		if isIcmpProtocol(protocol) {
 			var (
 				t int
 				c int
@@ -549,3 +549,116 @@ func importKpfsAcl(d *schema.ResourceData, meta interface{}) ([]*schema.Resource
 	}
 	return retD, nil
 }
+
+func importIamProjectMember(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	projectId, _, err := parseIamProjectMemberId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	_ = d.Set("project_id", projectId)
+	return []*schema.ResourceData{d}, nil
+}
+
+func importHaVipAttachment(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	var err error
+	items := strings.Split(d.Id(), ":")
+	if len(items) < 2 {
+		return []*schema.ResourceData{d}, fmt.Errorf("import id must split with ':'")
+	}
+	err = d.Set("havip_id", items[0])
+	if err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+	err = d.Set("network_interface_id", items[1])
+	if err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func importCenInstanceAttachment(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	var err error
+	items := strings.Split(d.Id(), ":")
+	if len(items) < 2 {
+		return []*schema.ResourceData{d}, fmt.Errorf("import id must split with ':'")
+	}
+	err = d.Set("cen_id", items[0])
+	if err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+	err = d.Set("instance_id", items[1])
+	if err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func importNatIp(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	var err error
+	items := strings.Split(d.Id(), ":")
+	if len(items) < 2 {
+		return []*schema.ResourceData{d}, fmt.Errorf("import id must split with ':'")
+	}
+	err = d.Set("nat_id", items[0])
+	if err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+	err = d.Set("nat_ip_id", items[1])
+	if err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// importDnatRules accepts nat_id:nat_ip, or the nat_id alone when all of the dnats of the nat use one nat ip
+func importDnatRules(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	var err error
+	vpcService := VpcService{meta.(*KsyunClient)}
+	items := strings.Split(d.Id(), ":")
+	natId, natIp := items[0], ""
+	if len(items) > 1 {
+		natIp = items[1]
+	} else {
+		param := NewDescribeDnatsParams()
+		param.Filter.NatId = natId
+		var dnats []interface{}
+		dnats, err = vpcService.DescribeDnats(param)
+		if err != nil && !notFoundError(err) {
+			return []*schema.ResourceData{d}, err
+		}
+		var natIps []string
+		for _, v := range dnats {
+			ip, _ := v.(map[string]interface{})["NatIp"].(string)
+			if ip != "" && !stringSliceContains(natIps, ip) {
+				natIps = append(natIps, ip)
+			}
+		}
+		if len(natIps) != 1 {
+			return []*schema.ResourceData{d}, fmt.Errorf("the dnats of nat %s use the nat ips %v, import id must be nat_id:nat_ip", natId, natIps)
+		}
+		natIp = natIps[0]
+	}
+	err = d.Set("nat_id", natId)
+	if err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+	err = d.Set("nat_ip", natIp)
+	if err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+	// every dnat of the nat ip is imported
+	rules, err := vpcService.ReadDnatRules(natId, natIp)
+	if err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+	err = d.Set("rule", flattenDnatRules(rules, nil))
+	if err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+	err = d.Set("exclusive", false)
+	if err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+	d.SetId(natId + ":" + natIp)
+	return []*schema.ResourceData{d}, nil
+}
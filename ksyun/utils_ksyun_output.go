@@ -0,0 +1,236 @@
+package ksyun
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputFormatJson  = "json"
+	outputFormatJsonl = "jsonl"
+	outputFormatYaml  = "yaml"
+	outputFormatCsv   = "csv"
+)
+
+// outputOptions controls how writeToFile serializes the data source results
+type outputOptions struct {
+	format  string
+	columns []string
+}
+
+// addDataSourceOutputFormat adds the `output_format` and `output_columns` arguments next to `output_file`
+func addDataSourceOutputFormat(r *schema.Resource) {
+	if _, ok := r.Schema["output_file"]; !ok {
+		return
+	}
+	if _, ok := r.Schema["output_format"]; !ok {
+		r.Schema["output_format"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  outputFormatJson,
+			ValidateFunc: validation.StringInSlice([]string{
+				outputFormatJson,
+				outputFormatJsonl,
+				outputFormatYaml,
+				outputFormatCsv,
+			}, false),
+			Description: "The format of `output_file`, valid values: `json`, `jsonl`, `yaml`, `csv`. Default is `json`.",
+		}
+	}
+	if _, ok := r.Schema["output_columns"]; !ok {
+		r.Schema["output_columns"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Description: "The columns of `output_file` when `output_format` is `csv`, nested attributes are separated by dots, e.g. `tags.env`. " +
+				"All the top level attributes are written by default.",
+		}
+	}
+}
+
+func dataSourceOutputOptions(d *schema.ResourceData) outputOptions {
+	option := outputOptions{}
+	if v, ok := d.Get("output_format").(string); ok {
+		option.format = v
+	}
+	if v, ok := d.Get("output_columns").([]interface{}); ok {
+		for _, column := range v {
+			if column, ok := column.(string); ok && column != "" {
+				option.columns = append(option.columns, column)
+			}
+		}
+	}
+	return option
+}
+
+func marshalOutput(data interface{}, option outputOptions) (bs []byte, err error) {
+	if s, ok := data.(string); ok {
+		return []byte(s), nil
+	}
+	data = normalizeOutputValue(data)
+
+	switch option.format {
+	case outputFormatJsonl:
+		var buf bytes.Buffer
+		for _, row := range outputRows(data) {
+			line, err := json.Marshal(row)
+			if err != nil {
+				return nil, fmt.Errorf("Marshal data %#v and got an error: %#v", row, err)
+			}
+			buf.Write(line)
+			buf.WriteByte('\n')
+		}
+		return buf.Bytes(), nil
+	case outputFormatYaml:
+		bs, err = yaml.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("Marshal data %#v to yaml and got an error: %#v", data, err)
+		}
+		return bs, nil
+	case outputFormatCsv:
+		return marshalOutputCsv(outputRows(data), option.columns)
+	default:
+		bs, err = json.MarshalIndent(data, "", "\t")
+		if err != nil {
+			return nil, fmt.Errorf("MarshalIndent data %#v and got an error: %#v", data, err)
+		}
+		return bs, nil
+	}
+}
+
+// outputRows returns the elements of a list, any other value is a single row
+func outputRows(data interface{}) []interface{} {
+	if l, ok := data.([]interface{}); ok {
+		return l
+	}
+	return []interface{}{data}
+}
+
+func marshalOutputCsv(rows []interface{}, columns []string) ([]byte, error) {
+	if len(columns) == 0 {
+		keys := make(map[string]bool)
+		for _, row := range rows {
+			for k := range outputCsvRow(row) {
+				keys[k] = true
+			}
+		}
+		for k := range keys {
+			columns = append(columns, k)
+		}
+		sort.Strings(columns)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(columns); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		m := outputCsvRow(row)
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = outputCsvValue(m, column)
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// outputCsvRow returns the cells of a row, a plain value is written in a `value` column
+func outputCsvRow(row interface{}) map[string]interface{} {
+	if m, ok := row.(map[string]interface{}); ok {
+		return m
+	}
+	return map[string]interface{}{"value": row}
+}
+
+// outputCsvValue formats a cell, lists and objects are written as json and the values
+// of a nested column are joined with ';'
+func outputCsvValue(row map[string]interface{}, column string) string {
+	if strings.Contains(column, ".") {
+		return strings.Join(dataSourceFilterValues(row, strings.Split(column, ".")), ";")
+	}
+	v, ok := row[column]
+	if !ok || v == nil {
+		return ""
+	}
+	switch v.(type) {
+	case []interface{}, map[string]interface{}:
+		bs, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(bs)
+	}
+	values := dataSourceFilterValues(v, nil)
+	return strings.Join(values, ";")
+}
+
+// normalizeOutputValue converts the values read from a schema.ResourceData (sets, typed slices and maps)
+// to plain lists and maps, so that every format serializes them the same way
+func normalizeOutputValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *schema.Set:
+		return normalizeOutputValue(v.List())
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = normalizeOutputValue(e)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, e := range v {
+			l[i] = normalizeOutputValue(e)
+		}
+		return l
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+		l := make([]interface{}, rv.Len())
+		for i := range l {
+			l[i] = normalizeOutputValue(rv.Index(i).Interface())
+		}
+		return l
+	}
+	return v
+}
+
+// writeFileAtomic writes to a temporary file of the same directory and renames it, readers never
+// see a partially written file and concurrent writers do not interleave their content
+func writeFileAtomic(filePath string, bs []byte, perm os.FileMode) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+	if _, err = f.Write(bs); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(f.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filePath)
+}
@@ -0,0 +1,66 @@
+package ksyun
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testOutputData() []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"instance_id":   "kec-1",
+			"instance_name": "web, 1",
+			"cpu":           float64(2),
+			"tags":          map[string]interface{}{"env": "prod"},
+			"key_id":        schema.NewSet(schema.HashString, []interface{}{"key-1"}),
+		},
+		{
+			"instance_id":   "kec-2",
+			"instance_name": "web-2",
+			"cpu":           float64(4),
+		},
+	}
+}
+
+func TestMarshalOutput(t *testing.T) {
+	bs, err := marshalOutput(testOutputData(), outputOptions{format: outputFormatCsv, columns: []string{"instance_id", "instance_name", "cpu", "tags.env"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "instance_id,instance_name,cpu,tags.env\nkec-1,\"web, 1\",2,prod\nkec-2,web-2,4,\n", string(bs))
+
+	bs, err = marshalOutput(testOutputData(), outputOptions{format: outputFormatCsv})
+	assert.NoError(t, err)
+	assert.Equal(t, "cpu,instance_id,instance_name,key_id,tags\n2,kec-1,\"web, 1\",\"[\"\"key-1\"\"]\",\"{\"\"env\"\":\"\"prod\"\"}\"\n4,kec-2,web-2,,\n", string(bs))
+
+	bs, err = marshalOutput(testOutputData(), outputOptions{format: outputFormatJsonl})
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"cpu\":2,\"instance_id\":\"kec-1\",\"instance_name\":\"web, 1\",\"key_id\":[\"key-1\"],\"tags\":{\"env\":\"prod\"}}\n"+
+		"{\"cpu\":4,\"instance_id\":\"kec-2\",\"instance_name\":\"web-2\"}\n", string(bs))
+
+	bs, err = marshalOutput(testOutputData()[1:], outputOptions{format: outputFormatYaml})
+	assert.NoError(t, err)
+	assert.Equal(t, "- cpu: 4\n  instance_id: kec-2\n  instance_name: web-2\n", string(bs))
+
+	bs, err = marshalOutput([]string{"10.0.0.1", "10.0.0.2"}, outputOptions{format: outputFormatCsv})
+	assert.NoError(t, err)
+	assert.Equal(t, "value\n10.0.0.1\n10.0.0.2\n", string(bs))
+}
+
+func TestWriteToFileReplacesFile(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "output.json")
+
+	assert.NoError(t, writeToFile(filePath, []string{"a"}))
+	assert.NoError(t, writeToFile(filePath, []string{"b"}))
+	bs, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "[\n\t\"b\"\n]", string(bs))
+
+	// no temporary file is left behind
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(entries))
+}
@@ -43,7 +43,8 @@ func pageQueryWithNextToken(condition map[string]interface{}, limitParam string,
 			return data, err
 		}
 		data = append(data, d...)
-		if len(d) < limit {
+		// a full last page comes back without the token of the next one
+		if len(d) < limit || nextToken == "" {
 			break
 		}
 	}
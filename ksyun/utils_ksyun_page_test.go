@@ -0,0 +1,32 @@
+package ksyun
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPageQueryWithNextToken(t *testing.T) {
+	pages := map[string][]interface{}{
+		"":   {1, 2},
+		"p2": {3, 4},
+	}
+	tokens := map[string]string{
+		"":   "p2",
+		"p2": "",
+	}
+	calls := 0
+	data, err := pageQueryWithNextToken(nil, "MaxResults", "NextToken", 2, func(condition map[string]interface{}) ([]interface{}, string, error) {
+		calls++
+		if calls > 3 {
+			return nil, "", fmt.Errorf("the last page is requested again")
+		}
+		token, _ := condition["NextToken"].(string)
+		return pages[token], tokens[token], nil
+	})
+	if err != nil {
+		t.Fatalf("pageQueryWithNextToken() returned error: %s", err)
+	}
+	if len(data) != 4 || calls != 2 {
+		t.Errorf("pageQueryWithNextToken() read %v in %d calls, want the 4 items of the 2 full pages in 2 calls", data, calls)
+	}
+}
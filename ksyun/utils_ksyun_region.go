@@ -0,0 +1,114 @@
+package ksyun
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// regionImportSeparator separates the resource id from its region in an import id, e.g. `vpc-abc123456@cn-shanghai-2`
+const regionImportSeparator = "@"
+
+// regionalResource adds the optional `region` argument to a resource, all the operations of the resource
+// are run with the client of that region. The region is recorded in the state, resources created before
+// the argument existed get the provider region on their next refresh.
+func regionalResource(r *schema.Resource) *schema.Resource {
+	r.Schema["region"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: "The region in which the resource is managed, defaults to the provider region.",
+	}
+
+	if r.Create != nil {
+		r.Create = withRegionalClient(r.Create)
+	}
+	if r.Read != nil {
+		r.Read = withRegionalClient(r.Read)
+	}
+	if r.Update != nil {
+		r.Update = withRegionalClient(r.Update)
+	}
+	if r.Delete != nil {
+		r.Delete = withRegionalClient(r.Delete)
+	}
+	if r.Exists != nil {
+		exists := r.Exists
+		r.Exists = func(d *schema.ResourceData, meta interface{}) (bool, error) {
+			client, err := regionalClient(d.Get("region").(string), meta)
+			if err != nil {
+				return false, err
+			}
+			return exists(d, client)
+		}
+	}
+	if r.CustomizeDiff != nil {
+		customizeDiff := r.CustomizeDiff
+		r.CustomizeDiff = func(d *schema.ResourceDiff, meta interface{}) error {
+			client, err := regionalClient(d.Get("region").(string), meta)
+			if err != nil {
+				return err
+			}
+			return customizeDiff(d, client)
+		}
+	}
+	if r.Importer != nil && r.Importer.State != nil {
+		state := r.Importer.State
+		r.Importer.State = func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			if i := strings.LastIndex(d.Id(), regionImportSeparator); i > 0 {
+				if err := d.Set("region", d.Id()[i+1:]); err != nil {
+					return nil, err
+				}
+				d.SetId(d.Id()[:i])
+			}
+			client, err := regionalClient(d.Get("region").(string), meta)
+			if err != nil {
+				return nil, err
+			}
+			return state(d, client)
+		}
+	}
+	return r
+}
+
+// regionalDataSource adds the optional `region` argument to a data source, the data source is read
+// with the client of that region.
+func regionalDataSource(r *schema.Resource) *schema.Resource {
+	r.Schema["region"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "The region to query, defaults to the provider region.",
+	}
+	r.Read = withRegionalClient(r.Read)
+	return r
+}
+
+func withRegionalClient(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		client, err := regionalClient(d.Get("region").(string), meta)
+		if err != nil {
+			return err
+		}
+		region := d.Get("region")
+		if err = f(d, client); err != nil {
+			return err
+		}
+		// the resource may be gone after a read or a delete, the region read from the
+		// resource itself is kept so that the drift is detected
+		if d.Id() != "" && d.Get("region") == region {
+			return d.Set("region", client.region)
+		}
+		return nil
+	}
+}
+
+func regionalClient(region string, meta interface{}) (*KsyunClient, error) {
+	client, ok := meta.(*KsyunClient)
+	if !ok {
+		return nil, fmt.Errorf("unexpected provider meta %T", meta)
+	}
+	return client.WithRegion(region)
+}
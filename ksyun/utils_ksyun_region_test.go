@@ -0,0 +1,142 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/ks3sdklib/ksyun-ks3-go-sdk/ks3"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRegionClient(t *testing.T) *KsyunClient {
+	config := Config{
+		AccessKey: "ak",
+		SecretKey: "sk",
+		Region:    "cn-beijing-6",
+		Endpoint:  "ks3-cn-beijing.ksyuncs.com",
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	return client
+}
+
+func TestKsyunClientWithRegion(t *testing.T) {
+	client := testRegionClient(t)
+
+	same, err := client.WithRegion("")
+	assert.NoError(t, err)
+	assert.True(t, same == client)
+
+	shanghai, err := client.WithRegion("cn-shanghai-2")
+	assert.NoError(t, err)
+	assert.Equal(t, "cn-shanghai-2", shanghai.region)
+	assert.Equal(t, "ks3-cn-shanghai.ksyuncs.com", shanghai.ks3Endpoint)
+	assert.Equal(t, "ks3-cn-shanghai.ksyuncs.com", shanghai.config.Endpoint)
+	assert.Equal(t, "cn-beijing-6", client.config.Region)
+
+	again, err := client.WithRegion("cn-shanghai-2")
+	assert.NoError(t, err)
+	assert.True(t, again == shanghai)
+
+	// the cache is shared, going back to the provider region gives the provider client
+	back, err := shanghai.WithRegion("cn-beijing-6")
+	assert.NoError(t, err)
+	assert.True(t, back == client)
+}
+
+func TestRegionalResource(t *testing.T) {
+	var regions []string
+	record := func(d *schema.ResourceData, meta interface{}) error {
+		regions = append(regions, meta.(*KsyunClient).region)
+		return nil
+	}
+	r := regionalResource(&schema.Resource{
+		Create: record,
+		Read:   record,
+		Delete: record,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	})
+	assert.NoError(t, r.InternalValidate(nil, true))
+	assert.True(t, r.Schema["region"].ForceNew)
+
+	client := testRegionClient(t)
+
+	d := r.TestResourceData()
+	d.SetId("vpc-1")
+	assert.NoError(t, r.Read(d, client))
+	assert.Equal(t, "cn-beijing-6", d.Get("region"))
+
+	d = r.TestResourceData()
+	d.SetId("vpc-2@cn-guangzhou-1")
+	states, err := r.Importer.State(d, client)
+	assert.NoError(t, err)
+	assert.Equal(t, "vpc-2", states[0].Id())
+	assert.Equal(t, "cn-guangzhou-1", states[0].Get("region"))
+	assert.NoError(t, r.Read(states[0], client))
+
+	assert.Equal(t, []string{"cn-beijing-6", "cn-guangzhou-1"}, regions)
+}
+
+func TestKsyunClientWithRegionUnknownKs3Endpoint(t *testing.T) {
+	client := testRegionClient(t)
+
+	c, err := client.WithRegion("cn-northwest-1")
+	assert.NoError(t, err)
+	_, err = c.WithKs3Client(func(ks3Client *ks3.Client) (interface{}, error) {
+		return nil, nil
+	})
+	assert.Error(t, err)
+}
+
+func TestKsyunClientWithRegionCustomEndpoint(t *testing.T) {
+	config := Config{
+		AccessKey: "ak",
+		SecretKey: "sk",
+		Region:    "cn-beijing-6",
+		Endpoint:  "ks3.private.example.com",
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	same, err := client.WithRegion("cn-beijing-6")
+	assert.NoError(t, err)
+	assert.True(t, same == client)
+
+	_, err = client.WithRegion("cn-shanghai-2")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "custom endpoint")
+}
+
+func TestRegionalResourceRequest(t *testing.T) {
+	r := regionalResource(&schema.Resource{
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return d.Set("region", "cn-shanghai-2")
+		},
+		Schema: map[string]*schema.Schema{
+			"vpc_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	})
+	d := r.TestResourceData()
+	assert.NoError(t, d.Set("vpc_name", "tf"))
+	assert.NoError(t, d.Set("region", "cn-beijing-6"))
+	req, err := SdkRequestAutoMapping(d, r, false, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"VpcName": "tf"}, req)
+
+	// the region read from the resource itself is kept
+	d.SetId("vpc-1")
+	assert.NoError(t, r.Read(d, testRegionClient(t)))
+	assert.Equal(t, "cn-shanghai-2", d.Get("region"))
+}
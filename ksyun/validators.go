@@ -358,3 +358,17 @@ func IsDuplicationInSlice(vs []string) bool {
 	}
 	return false
 }
+
+func validateQuotaLimits(v interface{}, k string) (ws []string, errors []error) {
+	for service, limit := range v.(map[string]interface{}) {
+		if !checkValueInSlice(quotaServices, service) {
+			errors = append(errors, fmt.Errorf(
+				"%q contains an unsupported service %q, the valid ones are %v", k, service, quotaServices))
+			continue
+		}
+		if n, ok := limit.(int); ok && n < 0 {
+			errors = append(errors, fmt.Errorf("%q must not contain a negative limit, got %d for %s", k, n, service))
+		}
+	}
+	return
+}
@@ -1,6 +1,11 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/KscSDK/ksc-sdk-go/ksc"
 	"github.com/hashicorp/terraform-plugin-sdk/plugin"
 	"github.com/terraform-providers/terraform-provider-ksyun/ksyun"
@@ -13,7 +18,46 @@ var (
 func main() {
 	ksc.SDKName = "terraform-provider-ksyun"
 	ksc.SDKVersion = version
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := export(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "export: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: ksyun.Provider,
 	})
 }
+
+type stringSlice []string
+
+func (s *stringSlice) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSlice) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+// export generates hcl and import blocks for resources created outside terraform, e.g.
+//
+//	terraform-provider-ksyun export --region cn-beijing-6 --services vpc,kec,slb --filter tag:team=x
+func export(args []string) error {
+	var filters stringSlice
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	region := fs.String("region", "", "region to export, defaults to KSYUN_REGION")
+	services := fs.String("services", strings.Join(ksyun.ExportServices(), ","), "comma separated services to export")
+	output := fs.String("output", ".", "directory the .tf files are written to")
+	fs.Var(&filters, "filter", "tag:KEY=VALUE or ATTRIBUTE=VALUE, can be repeated")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	return ksyun.Export(ksyun.ExportConfig{
+		Region:    *region,
+		Services:  strings.Split(*services, ","),
+		Filters:   filters,
+		OutputDir: *output,
+	})
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity classifies a single schema change
+type Severity string
+
+const (
+	SeverityBreaking    Severity = "BREAKING"
+	SeverityNonBreaking Severity = "NON-BREAKING"
+)
+
+// Change is one difference between two snapshots
+type Change struct {
+	Severity Severity `json:"severity"`
+	Kind     string   `json:"kind"` // provider, resource or data_source
+	Name     string   `json:"name"`
+	Path     string   `json:"path,omitempty"`
+	Message  string   `json:"message"`
+}
+
+func (c Change) String() string {
+	target := c.Kind
+	if c.Name != "" {
+		target = fmt.Sprintf("%s %s", c.Kind, c.Name)
+	}
+	if c.Path != "" {
+		return fmt.Sprintf("%-12s %s: %s: %s", c.Severity, target, c.Path, c.Message)
+	}
+	return fmt.Sprintf("%-12s %s: %s", c.Severity, target, c.Message)
+}
+
+type differ struct {
+	kind    string
+	name    string
+	changes []Change
+}
+
+func (d *differ) add(severity Severity, path string, format string, args ...interface{}) {
+	d.changes = append(d.changes, Change{
+		Severity: severity,
+		Kind:     d.kind,
+		Name:     d.name,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Compare returns all changes from old to new, sorted by kind, name and path
+func Compare(old, new *Snapshot) []Change {
+	var changes []Change
+
+	pd := &differ{kind: "provider"}
+	pd.block("", old.Provider, new.Provider)
+	changes = append(changes, pd.changes...)
+
+	changes = append(changes, compareBlocks("resource", old.Resources, new.Resources)...)
+	changes = append(changes, compareBlocks("data_source", old.DataSources, new.DataSources)...)
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+// HasBreaking reports whether any of the changes is breaking
+func HasBreaking(changes []Change) bool {
+	for _, c := range changes {
+		if c.Severity == SeverityBreaking {
+			return true
+		}
+	}
+	return false
+}
+
+func compareBlocks(kind string, old, new map[string]*Block) []Change {
+	var changes []Change
+	for _, name := range unionKeys(old, new) {
+		d := &differ{kind: kind, name: name}
+		o, oOk := old[name]
+		n, nOk := new[name]
+		switch {
+		case !nOk:
+			d.add(SeverityBreaking, "", "removed")
+		case !oOk:
+			d.add(SeverityNonBreaking, "", "added")
+		default:
+			d.block("", o, n)
+		}
+		changes = append(changes, d.changes...)
+	}
+	return changes
+}
+
+func (d *differ) block(prefix string, old, new *Block) {
+	if old == nil {
+		old = &Block{}
+	}
+	if new == nil {
+		new = &Block{}
+	}
+	keys := make(map[string]bool)
+	for k := range old.Attributes {
+		keys[k] = true
+	}
+	for k := range new.Attributes {
+		keys[k] = true
+	}
+	var sorted []string
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, k := range sorted {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		o, oOk := old.Attributes[k]
+		n, nOk := new.Attributes[k]
+		switch {
+		case !nOk:
+			d.add(SeverityBreaking, path, "attribute removed")
+		case !oOk:
+			if n.Required {
+				d.add(SeverityBreaking, path, "new required attribute")
+			} else {
+				d.add(SeverityNonBreaking, path, "attribute added")
+			}
+		default:
+			d.attribute(path, o, n)
+		}
+	}
+}
+
+func (d *differ) attribute(path string, old, new *Attribute) {
+	if old.Type != new.Type {
+		d.add(SeverityBreaking, path, "type changed from %s to %s", old.Type, new.Type)
+		// nested comparison makes no sense once the type differs
+		return
+	}
+	if old.ElemType != new.ElemType {
+		d.add(SeverityBreaking, path, "element type changed from %s to %s", displayType(old.ElemType), displayType(new.ElemType))
+	}
+
+	if !old.ForceNew && new.ForceNew {
+		d.add(SeverityBreaking, path, "became ForceNew")
+	} else if old.ForceNew && !new.ForceNew {
+		d.add(SeverityNonBreaking, path, "is no longer ForceNew")
+	}
+
+	if !old.Required && new.Required {
+		d.add(SeverityBreaking, path, "changed from %s to Required", optionality(old))
+	} else if old.Required && !new.Required {
+		d.add(SeverityNonBreaking, path, "changed from Required to %s", optionality(new))
+	}
+
+	if (old.Optional || old.Required) && !new.Optional && !new.Required && new.Computed {
+		d.add(SeverityBreaking, path, "became Computed only, it can no longer be set")
+	}
+
+	if old.Computed && !new.Computed && !new.Required {
+		d.add(SeverityNonBreaking, path, "is no longer Computed")
+	}
+
+	if oldDefault, newDefault := jsonString(old.Default), jsonString(new.Default); oldDefault != newDefault {
+		d.add(SeverityBreaking, path, "default changed from %s to %s", oldDefault, newDefault)
+	}
+
+	if !old.HasValidation && new.HasValidation {
+		d.add(SeverityNonBreaking, path, "validation added")
+	} else if old.HasValidation && !new.HasValidation {
+		d.add(SeverityNonBreaking, path, "validation removed")
+	}
+
+	if new.MaxItems > 0 && (old.MaxItems == 0 || new.MaxItems < old.MaxItems) {
+		d.add(SeverityBreaking, path, "max_items tightened from %d to %d", old.MaxItems, new.MaxItems)
+	}
+	if new.MinItems > old.MinItems {
+		d.add(SeverityBreaking, path, "min_items tightened from %d to %d", old.MinItems, new.MinItems)
+	}
+
+	if old.Deprecated == "" && new.Deprecated != "" {
+		d.add(SeverityNonBreaking, path, "deprecated: %s", new.Deprecated)
+	}
+
+	if old.Sensitive != new.Sensitive {
+		d.add(SeverityNonBreaking, path, "sensitive changed from %t to %t", old.Sensitive, new.Sensitive)
+	}
+
+	newConflicts := difference(new.ConflictsWith, old.ConflictsWith)
+	if len(newConflicts) > 0 {
+		d.add(SeverityBreaking, path, "now conflicts with %s", strings.Join(newConflicts, ", "))
+	}
+
+	if old.ElemBlock != nil || new.ElemBlock != nil {
+		d.block(path, old.ElemBlock, new.ElemBlock)
+	}
+}
+
+func optionality(a *Attribute) string {
+	switch {
+	case a.Required:
+		return "Required"
+	case a.Optional && a.Computed:
+		return "Optional+Computed"
+	case a.Optional:
+		return "Optional"
+	default:
+		return "Computed"
+	}
+}
+
+func displayType(t string) string {
+	if t == "" {
+		return "<none>"
+	}
+	return t
+}
+
+func jsonString(v interface{}) string {
+	if v == nil {
+		return "<none>"
+	}
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(bs)
+}
+
+func difference(a, b []string) []string {
+	exists := make(map[string]bool, len(b))
+	for _, v := range b {
+		exists[v] = true
+	}
+	var result []string
+	for _, v := range a {
+		if !exists[v] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func unionKeys(a, b map[string]*Block) []string {
+	keys := make(map[string]bool)
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	var result []string
+	for k := range keys {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSnapshot(instance map[string]*schema.Schema) *Snapshot {
+	p := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"ksyun_instance": {Schema: instance},
+		},
+	}
+	s, _ := normalize(NewSnapshot(p))
+	return s
+}
+
+func TestCompareBreaking(t *testing.T) {
+	old := testSnapshot(map[string]*schema.Schema{
+		"image_id":      {Type: schema.TypeString, Required: true},
+		"instance_name": {Type: schema.TypeString, Optional: true},
+		"subnet_id":     {Type: schema.TypeString, Optional: true},
+		"keep_image":    {Type: schema.TypeBool, Optional: true},
+		"tags":          {Type: schema.TypeMap, Optional: true},
+	})
+	current := testSnapshot(map[string]*schema.Schema{
+		"image_id":      {Type: schema.TypeString, Required: true, ForceNew: true},
+		"instance_name": {Type: schema.TypeString, Required: true},
+		"subnet_id":     {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"tags":          {Type: schema.TypeMap, Optional: true},
+		"project_id":    {Type: schema.TypeString, Required: true},
+	})
+
+	changes := Compare(old, current)
+	a := assert.New(t)
+	a.True(HasBreaking(changes))
+
+	messages := map[string]Change{}
+	for _, c := range changes {
+		messages[c.Path] = c
+	}
+	a.Equal("became ForceNew", messages["image_id"].Message)
+	a.Equal("changed from Optional to Required", messages["instance_name"].Message)
+	a.Equal("type changed from TypeString to TypeList", messages["subnet_id"].Message)
+	a.Equal("attribute removed", messages["keep_image"].Message)
+	a.Equal("new required attribute", messages["project_id"].Message)
+	for _, c := range changes {
+		a.Equal(SeverityBreaking, c.Severity, c.String())
+	}
+}
+
+func TestCompareNonBreaking(t *testing.T) {
+	old := testSnapshot(map[string]*schema.Schema{
+		"image_id":      {Type: schema.TypeString, Required: true, ForceNew: true},
+		"instance_name": {Type: schema.TypeString, Required: true},
+		"data_disk": {Type: schema.TypeList, Optional: true, Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"size": {Type: schema.TypeInt, Optional: true, Default: 20},
+			},
+		}},
+	})
+	current := testSnapshot(map[string]*schema.Schema{
+		"image_id":      {Type: schema.TypeString, Required: true},
+		"instance_name": {Type: schema.TypeString, Optional: true, ValidateFunc: validation.StringLenBetween(1, 64)},
+		"data_disk": {Type: schema.TypeList, Optional: true, Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"size": {Type: schema.TypeInt, Optional: true, Default: 20},
+				"type": {Type: schema.TypeString, Optional: true},
+			},
+		}},
+		"description": {Type: schema.TypeString, Optional: true},
+	})
+
+	changes := Compare(old, current)
+	assert.False(t, HasBreaking(changes))
+	assert.Len(t, changes, 5)
+}
+
+func TestCompareNestedDefault(t *testing.T) {
+	old := testSnapshot(map[string]*schema.Schema{
+		"data_disk": {Type: schema.TypeList, Optional: true, Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"size": {Type: schema.TypeInt, Optional: true, Default: 20},
+			},
+		}},
+	})
+	current := testSnapshot(map[string]*schema.Schema{
+		"data_disk": {Type: schema.TypeList, Optional: true, Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"size": {Type: schema.TypeInt, Optional: true, Default: 40},
+			},
+		}},
+	})
+
+	changes := Compare(old, current)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "data_disk.size", changes[0].Path)
+	assert.Equal(t, SeverityBreaking, changes[0].Severity)
+}
+
+func TestCompareIdenticalProvider(t *testing.T) {
+	s, err := normalize(currentSnapshot())
+	assert.NoError(t, err)
+	assert.Empty(t, Compare(s, s))
+}
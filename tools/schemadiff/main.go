@@ -0,0 +1,128 @@
+// schemadiff dumps the ksyun provider schema to json and detects breaking changes between two dumps.
+//
+// Usage:
+//
+//	schemadiff dump [-o schema.json]
+//	schemadiff diff [-json] [-allow-breaking] old.json [new.json]
+//
+// When new.json is omitted, diff compares against the schema of the provider being built.
+// diff exits with status 1 when a breaking change is found, unless -allow-breaking is set.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	cloud "github.com/terraform-providers/terraform-provider-ksyun/ksyun"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "dump":
+		err = runDump(os.Args[2:])
+	case "diff":
+		var breaking bool
+		breaking, err = runDiff(os.Args[2:])
+		if err == nil && breaking {
+			os.Exit(1)
+		}
+	case "-h", "-help", "--help", "help":
+		usage()
+		return
+	default:
+		err = fmt.Errorf("unknown command %q", os.Args[1])
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "schemadiff: %s\n", err)
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage:")
+	fmt.Fprintln(os.Stderr, "  schemadiff dump [-o schema.json]")
+	fmt.Fprintln(os.Stderr, "  schemadiff diff [-json] [-allow-breaking] old.json [new.json]")
+}
+
+func runDump(args []string) error {
+	fs := flag.NewFlagSet("dump", flag.ContinueOnError)
+	output := fs.String("o", "-", "file to write the snapshot to, '-' means stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	return currentSnapshot().WriteFile(*output)
+}
+
+func runDiff(args []string) (bool, error) {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	asJson := fs.Bool("json", false, "print the changes as json")
+	allowBreaking := fs.Bool("allow-breaking", false, "exit with status 0 even if breaking changes are found")
+	if err := fs.Parse(args); err != nil {
+		return false, err
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		return false, fmt.Errorf("diff expects one or two snapshot files, got %d", fs.NArg())
+	}
+
+	old, err := ReadSnapshot(fs.Arg(0))
+	if err != nil {
+		return false, err
+	}
+
+	var current *Snapshot
+	if fs.NArg() == 2 {
+		current, err = ReadSnapshot(fs.Arg(1))
+	} else {
+		// round trip through json, so that values such as defaults are compared in the same shape
+		current, err = normalize(currentSnapshot())
+	}
+	if err != nil {
+		return false, err
+	}
+
+	changes := Compare(old, current)
+	if *asJson {
+		bs, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return false, err
+		}
+		fmt.Println(string(bs))
+	} else {
+		for _, c := range changes {
+			fmt.Println(c.String())
+		}
+		if len(changes) == 0 {
+			fmt.Println("no schema changes")
+		}
+	}
+
+	return HasBreaking(changes) && !*allowBreaking, nil
+}
+
+func currentSnapshot() *Snapshot {
+	s := NewSnapshot(cloud.Provider().(*schema.Provider))
+	// some defaults are generated when the schema is built (e.g. ksyun_ks3_bucket.bucket),
+	// build the schema a second time to find them
+	s.DropVolatileDefaults(NewSnapshot(cloud.Provider().(*schema.Provider)))
+	return s
+}
+
+func normalize(s *Snapshot) (*Snapshot, error) {
+	bs, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	result := &Snapshot{}
+	err = json.Unmarshal(bs, result)
+	return result, err
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const snapshotFormatVersion = 1
+
+// Snapshot is the serializable form of the provider schema
+type Snapshot struct {
+	FormatVersion int               `json:"format_version"`
+	Provider      *Block            `json:"provider"`
+	Resources     map[string]*Block `json:"resources"`
+	DataSources   map[string]*Block `json:"data_sources"`
+}
+
+// Block describes a schema.Resource, either a top level one or a nested block
+type Block struct {
+	Attributes map[string]*Attribute `json:"attributes"`
+}
+
+// Attribute describes a single schema.Schema
+type Attribute struct {
+	Type          string      `json:"type"`
+	Required      bool        `json:"required,omitempty"`
+	Optional      bool        `json:"optional,omitempty"`
+	Computed      bool        `json:"computed,omitempty"`
+	ForceNew      bool        `json:"force_new,omitempty"`
+	Sensitive     bool        `json:"sensitive,omitempty"`
+	Default       interface{} `json:"default,omitempty"`
+	DefaultFunc   bool        `json:"default_func,omitempty"`
+	HasValidation bool        `json:"has_validation,omitempty"`
+	Deprecated    string      `json:"deprecated,omitempty"`
+	Removed       string      `json:"removed,omitempty"`
+	MinItems      int         `json:"min_items,omitempty"`
+	MaxItems      int         `json:"max_items,omitempty"`
+	ConflictsWith []string    `json:"conflicts_with,omitempty"`
+	ElemType      string      `json:"elem_type,omitempty"`
+	ElemBlock     *Block      `json:"elem_block,omitempty"`
+}
+
+// NewSnapshot walks the whole provider and builds its snapshot
+func NewSnapshot(p *schema.Provider) *Snapshot {
+	s := &Snapshot{
+		FormatVersion: snapshotFormatVersion,
+		Provider:      newBlock(p.Schema),
+		Resources:     make(map[string]*Block),
+		DataSources:   make(map[string]*Block),
+	}
+	for name, r := range p.ResourcesMap {
+		s.Resources[name] = newBlock(r.Schema)
+	}
+	for name, r := range p.DataSourcesMap {
+		s.DataSources[name] = newBlock(r.Schema)
+	}
+	return s
+}
+
+func newBlock(m map[string]*schema.Schema) *Block {
+	b := &Block{
+		Attributes: make(map[string]*Attribute, len(m)),
+	}
+	for k, v := range m {
+		b.Attributes[k] = newAttribute(v)
+	}
+	return b
+}
+
+func newAttribute(v *schema.Schema) *Attribute {
+	a := &Attribute{
+		Type:          v.Type.String(),
+		Required:      v.Required,
+		Optional:      v.Optional,
+		Computed:      v.Computed,
+		ForceNew:      v.ForceNew,
+		Sensitive:     v.Sensitive,
+		Default:       v.Default,
+		DefaultFunc:   v.DefaultFunc != nil,
+		HasValidation: v.ValidateFunc != nil,
+		Deprecated:    v.Deprecated,
+		Removed:       v.Removed,
+		MinItems:      v.MinItems,
+		MaxItems:      v.MaxItems,
+	}
+	if len(v.ConflictsWith) > 0 {
+		a.ConflictsWith = append([]string{}, v.ConflictsWith...)
+		sort.Strings(a.ConflictsWith)
+	}
+	switch elem := v.Elem.(type) {
+	case *schema.Schema:
+		a.ElemType = elem.Type.String()
+	case *schema.Resource:
+		a.ElemBlock = newBlock(elem.Schema)
+	case schema.ValueType:
+		a.ElemType = elem.String()
+	}
+	return a
+}
+
+// DropVolatileDefaults compares the snapshot with another one taken from the same code, defaults that
+// differ between both are generated at runtime and are recorded as a default func instead
+func (s *Snapshot) DropVolatileDefaults(other *Snapshot) {
+	s.Provider.dropVolatileDefaults(other.Provider)
+	for name, b := range s.Resources {
+		b.dropVolatileDefaults(other.Resources[name])
+	}
+	for name, b := range s.DataSources {
+		b.dropVolatileDefaults(other.DataSources[name])
+	}
+}
+
+func (b *Block) dropVolatileDefaults(other *Block) {
+	if b == nil || other == nil {
+		return
+	}
+	for k, a := range b.Attributes {
+		o, ok := other.Attributes[k]
+		if !ok {
+			continue
+		}
+		if a.Default != nil && !reflect.DeepEqual(a.Default, o.Default) {
+			a.Default = nil
+			a.DefaultFunc = true
+		}
+		a.ElemBlock.dropVolatileDefaults(o.ElemBlock)
+	}
+}
+
+// WriteFile stores the snapshot as indented json, map keys are sorted by encoding/json
+// so that two snapshots of the same schema are byte-identical
+func (s *Snapshot) WriteFile(filePath string) error {
+	bs, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	bs = append(bs, '\n')
+	if filePath == "" || filePath == "-" {
+		_, err = os.Stdout.Write(bs)
+		return err
+	}
+	return os.WriteFile(filePath, bs, 0644)
+}
+
+// ReadSnapshot loads a snapshot previously written by WriteFile
+func ReadSnapshot(filePath string) (*Snapshot, error) {
+	bs, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	s := &Snapshot{}
+	if err = json.Unmarshal(bs, s); err != nil {
+		return nil, fmt.Errorf("parse snapshot %s error: %s", filePath, err)
+	}
+	if s.FormatVersion != snapshotFormatVersion {
+		return nil, fmt.Errorf("snapshot %s has format version %d, expected %d", filePath, s.FormatVersion, snapshotFormatVersion)
+	}
+	return s, nil
+}